@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/lint"
+
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	app := &cli.App{
+		Name:      "terraform-provider-grafana-lint",
+		Usage:     "Check dashboards, rule groups and message templates without a Grafana instance.",
+		UsageText: "terraform-provider-grafana-lint [options] [paths...]",
+		Description: `Checks Terraform files (grafana_dashboard, grafana_rule_group and grafana_message_template resources),
+dashboard and rule group JSON files, and message template files (.tmpl, .gotmpl). Directories are walked recursively.
+If no path is given, the current directory is checked.`,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "data-source",
+				Usage:   "UID or name of a data source that exists outside of the checked files. If no data source is known, data source references aren't checked.",
+				EnvVars: []string{"GRAFANA_LINT_DATA_SOURCES"},
+			},
+			&cli.StringSliceFlag{
+				Name:    "library-panel",
+				Usage:   "UID or name of a library panel that exists outside of the checked files. If no library panel is known, library panel references aren't checked.",
+				EnvVars: []string{"GRAFANA_LINT_LIBRARY_PANELS"},
+			},
+			&cli.BoolFlag{
+				Name:    "strict",
+				Usage:   "Fail on warnings, as well as errors",
+				EnvVars: []string{"GRAFANA_LINT_STRICT"},
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			paths := ctx.Args().Slice()
+			if len(paths) == 0 {
+				paths = []string{"."}
+			}
+			issues, err := lint.Lint(lint.Config{
+				Paths:         paths,
+				DataSources:   ctx.StringSlice("data-source"),
+				LibraryPanels: ctx.StringSlice("library-panel"),
+			})
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, issue := range issues {
+				fmt.Println(issue)
+				if issue.Severity == grafana.LintError || ctx.Bool("strict") {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return cli.Exit(fmt.Sprintf("%d issue(s) found", failed), 1)
+			}
+			return nil
+		},
+	}
+
+	return app.Run(os.Args)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/urfave/cli/v2"
+
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/migrate"
+)
+
+func main() {
+	err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	app := &cli.App{
+		Name:  "terraform-provider-grafana-migrate",
+		Usage: "Generate the Terraform configuration to migrate resources to other resource types, without recreating them in Grafana.",
+		Commands: []*cli.Command{
+			{
+				Name:  "permissions",
+				Usage: "Migrate grafana_folder_permission, grafana_dashboard_permission and grafana_data_source_permission resources to their _item resources.",
+				Description: `Generates a grafana_*_permission_item resource and an import block for each permission of the authoritative resources,
+and a removed block to forget the authoritative resources without destroying their permissions. Requires Terraform 1.7+.
+After writing the output to a .tf file, delete the authoritative resources from the configuration and run terraform plan: no permission should change.
+Resources in child modules are skipped, since they must be migrated in the module.`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "state",
+						Usage: "Path to the output of \"terraform show -json\", or \"-\" for stdin. If not set, \"terraform show -json\" is run in the current directory.",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "File to write the configuration to. If not set, it's written to stdout.",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					state, err := readState(ctx.Context, ctx.String("state"))
+					if err != nil {
+						return err
+					}
+					file, skipped, err := migrate.PermissionItems(state)
+					if err != nil {
+						return err
+					}
+					for _, address := range skipped {
+						fmt.Fprintf(os.Stderr, "skipped %s: resources in modules must be migrated in the module\n", address)
+					}
+
+					if output := ctx.String("output"); output != "" {
+						return os.WriteFile(output, file.Bytes(), 0600)
+					}
+					_, err = os.Stdout.Write(file.Bytes())
+					return err
+				},
+			},
+		},
+	}
+
+	return app.Run(os.Args)
+}
+
+func readState(ctx context.Context, path string) (*tfjson.State, error) {
+	var content []byte
+	var err error
+	switch path {
+	case "":
+		var execPath string
+		execPath, err = exec.LookPath("terraform")
+		if err != nil {
+			return nil, fmt.Errorf("terraform not found, set --state to the output of `terraform show -json`: %w", err)
+		}
+		content, err = exec.CommandContext(ctx, execPath, "show", "-json").Output()
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
+	default:
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the state: %w", err)
+	}
+
+	var state tfjson.State
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse the state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,25 @@
+resource "grafana_contact_point" "my_contact_point" {
+  name = "My Contact Point"
+
+  email {
+    addresses = ["one@company.org", "two@company.org"]
+  }
+
+  # Send a test notification whenever the contact point changes
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.grafana_contact_point_send_test_notification.my_contact_point]
+    }
+  }
+}
+
+# Can also be run with `terraform apply -invoke=action.grafana_contact_point_send_test_notification.my_contact_point`
+action "grafana_contact_point_send_test_notification" "my_contact_point" {
+  config {
+    name = grafana_contact_point.my_contact_point.name
+    annotations = {
+      summary = "Test notification sent by Terraform"
+    }
+  }
+}
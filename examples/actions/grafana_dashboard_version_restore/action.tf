@@ -0,0 +1,11 @@
+data "grafana_dashboard_versions" "production" {
+  dashboard_uid = grafana_dashboard.production.uid
+}
+
+# Run with `terraform apply -invoke=action.grafana_dashboard_version_restore.rollback` to restore the version before the last one
+action "grafana_dashboard_version_restore" "rollback" {
+  config {
+    dashboard_uid = grafana_dashboard.production.uid
+    version       = data.grafana_dashboard_versions.production.versions[1].version
+  }
+}
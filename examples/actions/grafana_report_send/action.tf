@@ -0,0 +1,7 @@
+# Run with `terraform apply -invoke=action.grafana_report_send.weekly`
+action "grafana_report_send" "weekly" {
+  config {
+    report_id = grafana_report.weekly.id
+    emails    = ["oncall@company.org"]
+  }
+}
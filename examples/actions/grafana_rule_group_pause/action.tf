@@ -0,0 +1,17 @@
+# Run with `terraform apply -invoke=action.grafana_rule_group_pause.maintenance` before a maintenance window
+action "grafana_rule_group_pause" "maintenance" {
+  config {
+    folder_uid = grafana_rule_group.my_rule_group.folder_uid
+    name       = grafana_rule_group.my_rule_group.name
+    paused     = true
+  }
+}
+
+# Run with `terraform apply -invoke=action.grafana_rule_group_pause.resume` after it
+action "grafana_rule_group_pause" "resume" {
+  config {
+    folder_uid = grafana_rule_group.my_rule_group.folder_uid
+    name       = grafana_rule_group.my_rule_group.name
+    paused     = false
+  }
+}
@@ -0,0 +1,18 @@
+resource "grafana_service_account" "admin" {
+  name = "admin sa"
+  role = "Admin"
+}
+
+resource "grafana_service_account_token" "admin" {
+  name               = "admin sa token"
+  service_account_id = grafana_service_account.admin.id
+}
+
+# Run with `terraform apply -invoke=action.grafana_service_account_token_revoke.admin`,
+# then `terraform apply` to replace the revoked token
+action "grafana_service_account_token_revoke" "admin" {
+  config {
+    service_account_id = grafana_service_account.admin.id
+    token_id           = grafana_service_account_token.admin.id
+  }
+}
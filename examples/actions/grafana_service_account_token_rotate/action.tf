@@ -0,0 +1,21 @@
+resource "grafana_service_account" "ci" {
+  name = "ci sa"
+  role = "Editor"
+}
+
+variable "ci_token_id" {
+  description = "The ID of the token currently used by the CI jobs"
+  type        = string
+}
+
+# Run with `terraform apply -invoke=action.grafana_service_account_token_rotate.ci`,
+# then upload the key in ci-token.key to the CI secrets and delete the file
+action "grafana_service_account_token_rotate" "ci" {
+  config {
+    service_account_id = grafana_service_account.ci.id
+    token_id           = var.ci_token_id
+    name               = "ci token ${formatdate("YYYY-MM-DD", timestamp())}"
+    seconds_to_live    = 7776000 # 90 days
+    key_file           = "${path.module}/ci-token.key"
+  }
+}
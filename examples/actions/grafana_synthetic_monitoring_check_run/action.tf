@@ -0,0 +1,6 @@
+# Run with `terraform apply -invoke=action.grafana_synthetic_monitoring_check_run.http`
+action "grafana_synthetic_monitoring_check_run" "http" {
+  config {
+    check_id = grafana_synthetic_monitoring_check.http.id
+  }
+}
@@ -0,0 +1,12 @@
+variable "new_password" {
+  type      = string
+  sensitive = true
+}
+
+# Run with `terraform apply -invoke=action.grafana_user_password_reset.staff`
+action "grafana_user_password_reset" "staff" {
+  config {
+    user_id  = grafana_user.staff.id
+    password = var.new_password
+  }
+}
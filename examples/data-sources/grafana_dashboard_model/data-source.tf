@@ -0,0 +1,70 @@
+locals {
+  services = ["api", "worker"]
+}
+
+data "grafana_dashboard_model" "services" {
+  title     = "Services"
+  uid       = "services-model"
+  tags      = ["generated"]
+  refresh   = "1m"
+  time_from = "now-3h"
+
+  variable {
+    name            = "instance"
+    label           = "Instance"
+    query           = "label_values(up, instance)"
+    datasource_uid  = "prometheus"
+    datasource_type = "prometheus"
+    multi           = true
+    include_all     = true
+  }
+
+  link {
+    title = "Runbook"
+    url   = "https://example.com/runbook"
+  }
+
+  panel {
+    title  = "Overview"
+    type   = "text"
+    width  = 24
+    height = 3
+    options_json = jsonencode({
+      mode    = "markdown"
+      content = "Requests and errors of each service."
+    })
+  }
+
+  dynamic "row" {
+    for_each = local.services
+    content {
+      title = row.value
+
+      panel {
+        title           = "Requests"
+        datasource_uid  = "prometheus"
+        datasource_type = "prometheus"
+        unit            = "reqps"
+        target {
+          expr          = "sum by (instance) (rate(http_requests_total{service=\"${row.value}\", instance=~\"$instance\"}[5m]))"
+          legend_format = "{{instance}}"
+        }
+      }
+
+      panel {
+        title           = "Errors"
+        type            = "stat"
+        datasource_uid  = "prometheus"
+        datasource_type = "prometheus"
+        unit            = "percentunit"
+        target {
+          expr = "sum(rate(http_requests_total{service=\"${row.value}\", code=~\"5..\"}[5m])) / sum(rate(http_requests_total{service=\"${row.value}\"}[5m]))"
+        }
+      }
+    }
+  }
+}
+
+resource "grafana_dashboard" "services" {
+  config_json = data.grafana_dashboard_model.services.config_json
+}
@@ -0,0 +1,12 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "dashboard-versions"
+    title = "Production Overview"
+  })
+  message = "Initial version"
+}
+
+data "grafana_dashboard_versions" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+  limit         = 10
+}
@@ -0,0 +1,5 @@
+data "grafana_server_info" "current" {}
+
+output "grafana_version" {
+  value = data.grafana_server_info.current.version
+}
@@ -0,0 +1,18 @@
+provider "grafana" {
+  cloud_access_policy_token = "my-token"
+}
+
+resource "grafana_cloud_stack" "my_stack" {
+  name        = "myteststack"
+  slug        = "myteststack"
+  region_slug = "us"
+}
+
+// The folder is created in the new stack, in the same apply
+resource "grafana_folder" "my_folder" {
+  title = "Test Folder"
+
+  grafana_connection {
+    stack_slug = grafana_cloud_stack.my_stack.slug
+  }
+}
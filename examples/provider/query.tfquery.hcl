@@ -0,0 +1,13 @@
+# Run with `terraform query` to list existing dashboards, then `terraform query -generate-config-out=generated.tf` to import them
+list "grafana_dashboard" "production" {
+  provider = grafana
+
+  config {
+    folder_uid = "production"
+    tags       = ["team-a"]
+  }
+}
+
+list "grafana_synthetic_monitoring_check" "all" {
+  provider = grafana
+}
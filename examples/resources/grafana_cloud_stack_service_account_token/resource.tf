@@ -11,6 +11,17 @@ resource "grafana_cloud_stack_service_account_token" "foo" {
   service_account_id = grafana_cloud_stack_service_account.cloud_sa.id
 }
 
+# Rotated every 30 days. The previous key stays valid for a day after each rotation.
+resource "grafana_cloud_stack_service_account_token" "rotated" {
+  name               = "key_rotated"
+  service_account_id = grafana_cloud_stack_service_account.cloud_sa.id
+
+  rotation {
+    rotate_after = "720h"
+    overlap      = "24h"
+  }
+}
+
 output "service_account_token_foo_key" {
   value     = grafana_cloud_stack_service_account_token.foo.key
   sensitive = true
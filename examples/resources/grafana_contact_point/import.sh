@@ -1,2 +1,3 @@
 terraform import grafana_contact_point.name "{{ name }}"
 terraform import grafana_contact_point.name "{{ orgID }}:{{ name }}"
+terraform import grafana_contact_point.name "contact_point:{{ name }}"
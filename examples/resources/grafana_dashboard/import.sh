@@ -1,2 +1,4 @@
 terraform import grafana_dashboard.name "{{ uid }}"
 terraform import grafana_dashboard.name "{{ orgID }}:{{ uid }}"
+terraform import grafana_dashboard.name "dashboard:title={{ title }}"
+terraform import grafana_dashboard.name "dashboard:title={{ title }}@{{ folderPath }}"
@@ -61,3 +61,38 @@ resource "grafana_data_source" "prometheus" {
   })
 }
 
+
+# The configuration of common data source types can be set with typed blocks, which are validated
+resource "grafana_data_source" "loki" {
+  type = "loki"
+  name = "loki"
+  url  = "http://loki.example.net:3100"
+
+  loki {
+    max_lines     = "5000"
+    manage_alerts = true
+  }
+
+  # Keys that aren't in the typed block can still be set in json_data_encoded
+  json_data_encoded = jsonencode({
+    derivedFields = [{
+      datasourceUid = "tempo"
+      matcherRegex  = "traceID=(\\w+)"
+      name          = "TraceID"
+      url           = "$${__value.raw}"
+    }]
+  })
+}
+
+# The health of the data source can be checked after it is created or updated.
+# An unhealthy data source is reported as a warning, or fails the apply with fail_on_error.
+resource "grafana_data_source" "checked" {
+  type = "prometheus"
+  name = "checked-prometheus"
+  url  = "https://prometheus.example.net"
+
+  health_check {
+    fail_on_error = false
+    timeout       = "10s"
+  }
+}
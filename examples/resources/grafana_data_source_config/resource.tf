@@ -37,10 +37,14 @@ resource "grafana_data_source_config" "loki" {
 resource "grafana_data_source_config" "tempo" {
   uid = grafana_data_source.tempo.uid
 
+  tempo {
+    traces_to_logs_datasource_uid = grafana_data_source.loki.uid
+    node_graph_enabled            = true
+  }
+
   json_data_encoded = jsonencode({
     tracesToLogsV2 = {
       customQuery     = true
-      datasourceUid   = grafana_data_source.loki.uid
       filterBySpanID  = false
       filterByTraceID = false
       query           = "|=\"$${__trace.traceId}\" | json"
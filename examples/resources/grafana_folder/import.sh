@@ -1,2 +1,3 @@
 terraform import grafana_folder.name "{{ uid }}"
 terraform import grafana_folder.name "{{ orgID }}:{{ uid }}"
+terraform import grafana_folder.name "folder:{{ path }}"
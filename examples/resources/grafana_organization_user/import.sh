@@ -0,0 +1,2 @@
+terraform import grafana_organization_user.name "{{ userID }}"
+terraform import grafana_organization_user.name "{{ orgID }}:{{ userID }}"
@@ -0,0 +1,18 @@
+resource "grafana_user" "editor" {
+  name     = "Editor"
+  email    = "editor@example.com"
+  login    = "editor"
+  password = "my-password"
+}
+
+# The organization may be managed in another configuration
+resource "grafana_organization" "shared" {
+  name         = "Shared Organization"
+  manage_users = false
+}
+
+resource "grafana_organization_user" "editor" {
+  org_id  = grafana_organization.shared.org_id
+  user_id = grafana_user.editor.id
+  role    = "Editor"
+}
@@ -14,6 +14,17 @@ resource "grafana_service_account_token" "bar" {
   seconds_to_live    = 30
 }
 
+# Rotated every 30 days. The previous key stays valid for a day after each rotation.
+resource "grafana_service_account_token" "rotated" {
+  name               = "key_rotated"
+  service_account_id = grafana_service_account.test.id
+
+  rotation {
+    rotate_after = "720h"
+    overlap      = "24h"
+  }
+}
+
 
 output "service_account_token_foo_key_only" {
   value     = grafana_service_account_token.foo.key
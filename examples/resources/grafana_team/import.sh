@@ -1,2 +1,3 @@
 terraform import grafana_team.name "{{ id }}"
 terraform import grafana_team.name "{{ orgID }}:{{ id }}"
+terraform import grafana_team.name "team:{{ name }}"
@@ -0,0 +1,2 @@
+terraform import grafana_team_member.name "{{ teamID }}:{{ userID }}"
+terraform import grafana_team_member.name "{{ orgID }}:{{ teamID }}:{{ userID }}"
@@ -0,0 +1,17 @@
+resource "grafana_user" "viewer" {
+  name     = "Viewer"
+  email    = "viewer@example.com"
+  login    = "viewer"
+  password = "my-password"
+}
+
+# The team may be managed in another configuration
+resource "grafana_team" "shared" {
+  name           = "Shared Team"
+  manage_members = false
+}
+
+resource "grafana_team_member" "viewer" {
+  team_id = grafana_team.shared.id
+  user_id = grafana_user.viewer.id
+}
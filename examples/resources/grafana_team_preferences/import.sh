@@ -0,0 +1,2 @@
+terraform import grafana_team_preferences.name "{{ teamID }}"
+terraform import grafana_team_preferences.name "{{ orgID }}:{{ teamID }}"
@@ -1,14 +1,18 @@
 resource "grafana_dashboard" "metrics" {
-  config_json = file("grafana-dashboard.json")
+  config_json = jsonencode({
+    title = "Metrics"
+  })
 }
 
 resource "grafana_team" "team" {
-  name = "Team Name"
+  name               = "Team Name"
+  manage_preferences = false
 }
 
 resource "grafana_team_preferences" "team_preferences" {
   team_id            = grafana_team.team.id
   theme              = "dark"
   timezone           = "browser"
+  language           = "fr-FR"
   home_dashboard_uid = grafana_dashboard.metrics.uid
 }
@@ -1 +1,2 @@
 terraform import grafana_user.name "{{ id }}"
+terraform import grafana_user.name "user:{{ login }}"
@@ -0,0 +1,14 @@
+resource "grafana_dashboard" "metrics" {
+  config_json = jsonencode({
+    title = "Metrics"
+  })
+}
+
+resource "grafana_user_preferences" "preferences" {
+  theme                  = "dark"
+  timezone               = "utc"
+  week_start             = "monday"
+  language               = "en-US"
+  query_history_home_tab = "starred"
+  home_dashboard_uid     = grafana_dashboard.metrics.uid
+}
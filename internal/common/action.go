@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+)
+
+// Action represents a Terraform action, implemented with the Terraform Plugin Framework.
+// Actions run one-off operations (ex: sending a test notification). Unlike resources, they don't have a state.
+type Action struct {
+	ResourceCommon
+	PluginFrameworkSchema action.ActionWithConfigure
+}
+
+func NewAction(category ResourceCategory, name string, schema action.ActionWithConfigure) *Action {
+	a := &Action{
+		ResourceCommon: ResourceCommon{
+			Name:     name,
+			Category: category,
+		},
+		PluginFrameworkSchema: &frameworkAction{ActionWithConfigure: schema, name: name},
+	}
+	return a
+}
+
+// WithRequirements sets the Grafana server requirements of the action. They are checked when planning.
+func (a *Action) WithRequirements(requirements GrafanaRequirements) *Action {
+	a.Requirements = &requirements
+	a.PluginFrameworkSchema.(*frameworkAction).requirements = a.Requirements
+	return a
+}
+
+// frameworkAction wraps framework actions to check their Grafana requirements.
+type frameworkAction struct {
+	action.ActionWithConfigure
+	name         string
+	requirements *GrafanaRequirements
+	client       *Client
+}
+
+var _ action.ActionWithModifyPlan = (*frameworkAction)(nil)
+
+func (a *frameworkAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.ActionWithConfigure.Configure(ctx, req, resp)
+	if client, ok := req.ProviderData.(*Client); ok {
+		a.client = client
+	}
+}
+
+func (a *frameworkAction) ModifyPlan(ctx context.Context, req action.ModifyPlanRequest, resp *action.ModifyPlanResponse) {
+	if modifier, ok := a.ActionWithConfigure.(action.ActionWithModifyPlan); ok {
+		modifier.ModifyPlan(ctx, req, resp)
+	}
+	if a.requirements == nil {
+		return
+	}
+	if err := checkGrafanaRequirements(a.name, a.requirements, a.client); err != nil {
+		resp.Diagnostics.AddError("Unsupported Grafana server", err.Error())
+	}
+}
@@ -0,0 +1,378 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/runtime"
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	gcom "github.com/grafana/grafana-com-public-clients/go"
+	slo "github.com/grafana/slo-openapi-client/go"
+	SMAPI "github.com/grafana/synthetic-monitoring-api-go-client"
+	"github.com/hashicorp/go-cty/cty"
+	frameworkDiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// APIError is the decoded form of an error returned by one of the APIs used by the provider (Grafana, Grafana Cloud, OnCall, SM, SLO or ML).
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, or 0 if it is unknown.
+	StatusCode int
+	// Message is the main error message returned by the API.
+	Message string
+	// FieldErrors are the validation errors that relate to a specific field of the request.
+	FieldErrors []APIFieldError
+}
+
+// APIFieldError is a validation error that relates to a specific field of the request.
+type APIFieldError struct {
+	// Path is the path of the field in the API request, ex: ["rules", "2", "data", "0", "model"]
+	Path    []string
+	Message string
+}
+
+var (
+	// The ML client returns errors as plain strings. The body can end with a newline
+	mlErrorRegex = regexp.MustCompile(`(?s)status: (\d{3}), body: (.*)$`)
+	// Grafana sometimes prefixes its validation messages with the path of the invalid field, ex: `rules[2].data[0].model: invalid query`
+	fieldPathMessageRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*(?:\[\d+\])*(?:\.[a-zA-Z_][a-zA-Z0-9_]*(?:\[\d+\])*)*): (.+)$`)
+	fieldPathSegmentRegex = regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*|\d+`)
+)
+
+// DecodeAPIError extracts the status code, message and field errors from an error returned by one of the API clients.
+// It returns false if the error doesn't come from an API response.
+func DecodeAPIError(err error) (*APIError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var (
+		onCallErr  *onCallAPI.ErrorResponse
+		smErr      *SMAPI.HTTPError
+		sloErr     *slo.GenericOpenAPIError
+		gcomErr    *gcom.GenericOpenAPIError
+		openAPIErr *runtime.APIError
+	)
+	switch {
+	case errors.As(err, &onCallErr):
+		apiErr := &APIError{}
+		if onCallErr.Response != nil {
+			apiErr.StatusCode = onCallErr.Response.StatusCode
+		}
+		decodeAPIErrorBody(apiErr, onCallErr.Body)
+		if apiErr.Message == "" && len(apiErr.FieldErrors) == 0 {
+			apiErr.Message = onCallErr.Message
+		}
+		return apiErr, true
+	case errors.As(err, &smErr):
+		message := smErr.Api.Msg
+		if smErr.Api.Error != "" {
+			message = strings.TrimPrefix(message+": "+smErr.Api.Error, ": ")
+		}
+		return &APIError{StatusCode: smErr.Code, Message: message}, true
+	case errors.As(err, &sloErr):
+		apiErr := &APIError{StatusCode: statusCodeFromStatusLine(sloErr.Error())}
+		decodeAPIErrorBody(apiErr, sloErr.Body())
+		return apiErr, true
+	case errors.As(err, &gcomErr):
+		apiErr := &APIError{StatusCode: statusCodeFromStatusLine(gcomErr.Error())}
+		decodeAPIErrorBody(apiErr, gcomErr.Body())
+		return apiErr, true
+	case errors.As(err, &openAPIErr):
+		apiErr := &APIError{StatusCode: openAPIErr.Code}
+		decodeAPIErrorPayload(apiErr, openAPIErr.Response)
+		return apiErr, true
+	}
+
+	// Errors from the Grafana OpenAPI client are typed per response (ex: *PutAlertRuleGroupBadRequest)
+	// They all have a Code() method and a GetPayload() method returning the decoded body
+	var responseErr interface {
+		runtime.ClientResponseStatus
+		Code() int
+	}
+	if errors.As(err, &responseErr) {
+		apiErr := &APIError{StatusCode: responseErr.Code()}
+		if getPayload := reflect.ValueOf(responseErr).MethodByName("GetPayload"); getPayload.IsValid() && getPayload.Type().NumIn() == 0 && getPayload.Type().NumOut() == 1 {
+			decodeAPIErrorPayload(apiErr, getPayload.Call(nil)[0].Interface())
+		}
+		return apiErr, true
+	}
+
+	if matches := mlErrorRegex.FindStringSubmatch(err.Error()); matches != nil {
+		code, _ := strconv.Atoi(matches[1])
+		body := strings.TrimSpace(matches[2])
+		apiErr := &APIError{StatusCode: code}
+		decodeAPIErrorBody(apiErr, []byte(body))
+		if apiErr.Message == "" && len(apiErr.FieldErrors) == 0 {
+			apiErr.Message = body
+		}
+		return apiErr, true
+	}
+
+	return nil, false
+}
+
+// APIErrorDiagnostics converts an error returned by one of the API clients to diagnostics.
+// Field errors are reported as separate diagnostics, with an attribute path built from the API field path (see APIFieldAttributePath).
+// `fieldNames` maps API field names to attribute names, when the conversion from camelCase to snake_case isn't enough (ex: `rules` -> `rule`).
+// A hint is added to the detail of the diagnostic for common status codes (403, 409, 412).
+//
+// Errors that don't come from an API response are returned as a single diagnostic, prefixed with the summary.
+func APIErrorDiagnostics(summary string, err error, fieldNames map[string]string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	apiErr, ok := DecodeAPIError(err)
+	if !ok {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: fmt.Sprintf("%s: %v", summary, err)}}
+	}
+
+	var detail []string
+	if apiErr.Message != "" {
+		detail = append(detail, apiErr.Message)
+	} else if len(apiErr.FieldErrors) == 0 {
+		detail = append(detail, err.Error())
+	}
+	if hint := apiErrorHint(apiErr.StatusCode); hint != "" {
+		detail = append(detail, hint)
+	}
+	if apiErr.StatusCode != 0 {
+		summary = fmt.Sprintf("%s (status %d)", summary, apiErr.StatusCode)
+	}
+
+	var diags diag.Diagnostics
+	if len(detail) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   strings.Join(detail, "\n\n"),
+		})
+	}
+	for _, fieldErr := range apiErr.FieldErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        fmt.Sprintf("%s: %s", strings.Join(fieldErr.Path, "."), fieldErr.Message),
+			AttributePath: APIFieldAttributePath(fieldErr.Path, fieldNames),
+		})
+	}
+	return diags
+}
+
+// APIErrorFrameworkDiagnostics is the equivalent of APIErrorDiagnostics for resources implemented with the plugin framework.
+func APIErrorFrameworkDiagnostics(summary string, err error, fieldNames map[string]string) frameworkDiag.Diagnostics {
+	var diags frameworkDiag.Diagnostics
+	for _, d := range APIErrorDiagnostics(summary, err, fieldNames) {
+		if attrPath, ok := frameworkAttributePath(d.AttributePath); ok {
+			diags.AddAttributeError(attrPath, d.Summary, d.Detail)
+		} else {
+			diags.AddError(d.Summary, d.Detail)
+		}
+	}
+	return diags
+}
+
+// frameworkAttributePath converts an attribute path built by APIFieldAttributePath to a plugin framework path.
+func frameworkAttributePath(attrPath cty.Path) (path.Path, bool) {
+	if len(attrPath) == 0 {
+		return path.Empty(), false
+	}
+	first, ok := attrPath[0].(cty.GetAttrStep)
+	if !ok {
+		return path.Empty(), false
+	}
+	result := path.Root(first.Name)
+	for _, step := range attrPath[1:] {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			result = result.AtName(s.Name)
+		case cty.IndexStep:
+			index, _ := s.Key.AsBigFloat().Int64()
+			result = result.AtListIndex(int(index))
+		}
+	}
+	return result, true
+}
+
+// APIFieldAttributePath converts the path of a field in an API request to the path of the matching Terraform attribute.
+// Numeric segments are list indexes, other segments are converted from camelCase to snake_case unless they are found in `fieldNames`.
+// Ex: ["rules", "2", "data", "0", "relativeTimeRange"] with {"rules": "rule"} -> rule[2].data[0].relative_time_range
+func APIFieldAttributePath(path []string, fieldNames map[string]string) cty.Path {
+	var attrPath cty.Path
+	for _, segment := range path {
+		if index, err := strconv.Atoi(segment); err == nil {
+			attrPath = attrPath.IndexInt(index)
+			continue
+		}
+		if name, ok := fieldNames[segment]; ok {
+			attrPath = attrPath.GetAttr(name)
+			continue
+		}
+		attrPath = attrPath.GetAttr(camelToSnakeCase(segment))
+	}
+	return attrPath
+}
+
+func apiErrorHint(statusCode int) string {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return "Hint: the credentials used by the provider were rejected. Check that the `auth` attribute (or the matching environment variable) is valid and has not expired."
+	case http.StatusForbidden:
+		return "Hint: the credentials used by the provider are missing a permission for this action. " +
+			"For service accounts and tokens, check their role and, if RBAC is enabled, the actions and scopes granted to them."
+	case http.StatusConflict:
+		return "Hint: the resource conflicts with an existing one (for example, the name or UID is already in use). " +
+			"If it already exists, import it into Terraform instead of creating it."
+	case http.StatusPreconditionFailed:
+		return "Hint: the resource was modified by someone else since it was last read (version mismatch). " +
+			"Run `terraform apply -refresh-only` to review the remote changes, then apply again."
+	}
+	return ""
+}
+
+// decodeAPIErrorPayload reads the message and field errors from a response payload already decoded by the client.
+func decodeAPIErrorPayload(apiErr *APIError, payload interface{}) {
+	if payload == nil {
+		return
+	}
+	switch p := payload.(type) {
+	case string:
+		decodeAPIErrorBody(apiErr, []byte(p))
+		if apiErr.Message == "" && len(apiErr.FieldErrors) == 0 {
+			apiErr.Message = p
+		}
+		return
+	case []byte:
+		decodeAPIErrorBody(apiErr, p)
+		return
+	}
+	if body, err := json.Marshal(payload); err == nil {
+		decodeAPIErrorBody(apiErr, body)
+	}
+}
+
+// decodeAPIErrorBody reads the message and field errors from a JSON response body.
+// The formats used by the different APIs are supported:
+// - Grafana: {"message": "...", "messageId": "...", "error": "..."}
+// - Grafana Cloud, SLO: {"code": "...", "message": "..."} or {"code": 123, "error": "..."}
+// - SM: {"msg": "...", "err": "..."}
+// - OnCall (Django REST framework): {"detail": "..."} or {"field": ["error"], "nested": {"field": ["error"]}}
+func decodeAPIErrorBody(apiErr *APIError, body []byte) {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		if apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(body))
+		}
+		return
+	}
+
+	obj, ok := decoded.(map[string]interface{})
+	if !ok {
+		collectFieldErrors(apiErr, nil, decoded)
+		return
+	}
+
+	var messages []string
+	for _, key := range []string{"message", "msg", "detail", "error", "err"} {
+		value, ok := obj[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		if len(messages) == 0 || !strings.Contains(messages[0], value) {
+			messages = append(messages, value)
+		}
+		delete(obj, key)
+	}
+	for _, key := range []string{"messageId", "status", "code", "traceID", "statusCode"} {
+		delete(obj, key)
+	}
+	if len(messages) > 0 {
+		apiErr.Message = strings.Join(messages, ": ")
+	}
+
+	collectFieldErrors(apiErr, nil, obj)
+
+	// Grafana validation messages can start with the path of the invalid field
+	if len(apiErr.FieldErrors) == 0 && len(messages) > 0 {
+		if matches := fieldPathMessageRegex.FindStringSubmatch(messages[0]); matches != nil && strings.ContainsAny(matches[1], ".[") {
+			apiErr.FieldErrors = append(apiErr.FieldErrors, APIFieldError{
+				Path:    fieldPathSegmentRegex.FindAllString(matches[1], -1),
+				Message: matches[2],
+			})
+		}
+	}
+}
+
+// collectFieldErrors walks a Django REST framework style error structure, where the leaves are lists of error messages.
+func collectFieldErrors(apiErr *APIError, path []string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if len(path) == 0 {
+			if apiErr.Message == "" {
+				apiErr.Message = v
+			}
+			return
+		}
+		apiErr.FieldErrors = append(apiErr.FieldErrors, APIFieldError{Path: path, Message: v})
+	case []interface{}:
+		// A list of messages for the same field, or a list of nested objects (one per item of a list field)
+		for i, elem := range v {
+			if _, isString := elem.(string); isString {
+				collectFieldErrors(apiErr, path, elem)
+			} else {
+				collectFieldErrors(apiErr, appendPath(path, strconv.Itoa(i)), elem)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "non_field_errors" {
+				collectFieldErrors(apiErr, path, v[key])
+				continue
+			}
+			collectFieldErrors(apiErr, appendPath(path, key), v[key])
+		}
+	}
+}
+
+func appendPath(path []string, segment string) []string {
+	newPath := make([]string, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, segment)
+}
+
+// statusCodeFromStatusLine reads the status code from errors whose message is the HTTP status line, ex: "404 Not Found"
+func statusCodeFromStatusLine(status string) int {
+	code, _ := strconv.Atoi(strings.SplitN(status, " ", 2)[0])
+	return code
+}
+
+func camelToSnakeCase(s string) string {
+	var sb strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Add an underscore before an uppercase letter, unless it's part of an acronym (ex: datasourceUID -> datasource_uid)
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
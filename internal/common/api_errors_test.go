@@ -0,0 +1,222 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/machine-learning-go-client/mlapi"
+	SMAPI "github.com/grafana/synthetic-monitoring-api-go-client"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAPIError(t *testing.T) {
+	t.Parallel()
+
+	smErr := &SMAPI.HTTPError{Code: http.StatusConflict, Status: "409 Conflict", Action: "check add request"}
+	smErr.Api.Msg = "check already exists"
+	smErr.Api.Error = "duplicate job"
+
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected *common.APIError
+	}{
+		{
+			name: "grafana typed error",
+			err: &provisioning.PutAlertRuleGroupBadRequest{
+				Payload: &models.ValidationError{Message: "rules[2].data[0].model: invalid query"},
+			},
+			expected: &common.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "rules[2].data[0].model: invalid query",
+				FieldErrors: []common.APIFieldError{
+					{Path: []string{"rules", "2", "data", "0", "model"}, Message: "invalid query"},
+				},
+			},
+		},
+		{
+			name: "grafana error response body",
+			err: fmt.Errorf("wrapped: %w", &dashboards.PostDashboardPreconditionFailed{
+				Payload: &models.ErrorResponseBody{Message: common.Ref("The dashboard has been changed by someone else"), Status: "version-mismatch"},
+			}),
+			expected: &common.APIError{
+				StatusCode: http.StatusPreconditionFailed,
+				Message:    "The dashboard has been changed by someone else",
+			},
+		},
+		{
+			name: "oncall field errors",
+			err: &onCallAPI.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusBadRequest},
+				Body:     []byte(`{"name":["This field is required."],"templates":{"slack":{"title":["Invalid template"]}},"non_field_errors":["Invalid integration"]}`),
+			},
+			expected: &common.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Invalid integration",
+				FieldErrors: []common.APIFieldError{
+					{Path: []string{"name"}, Message: "This field is required."},
+					{Path: []string{"templates", "slack", "title"}, Message: "Invalid template"},
+				},
+			},
+		},
+		{
+			name: "oncall detail",
+			err: &onCallAPI.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusForbidden},
+				Body:     []byte(`{"detail":"You do not have permission to perform this action."}`),
+			},
+			expected: &common.APIError{
+				StatusCode: http.StatusForbidden,
+				Message:    "You do not have permission to perform this action.",
+			},
+		},
+		{
+			name: "synthetic monitoring",
+			err:  smErr,
+			expected: &common.APIError{
+				StatusCode: http.StatusConflict,
+				Message:    "check already exists: duplicate job",
+			},
+		},
+		{
+			name: "machine learning",
+			err:  errors.New(`status: 400, body: {"message":"invalid datasourceType"}`),
+			expected: &common.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "invalid datasourceType",
+			},
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("connection refused"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiErr, ok := common.DecodeAPIError(tc.err)
+			require.Equal(t, tc.expected != nil, ok)
+			require.Equal(t, tc.expected, apiErr)
+		})
+	}
+}
+
+// The ML client returns the response body in the error message, so errors are decoded from a real client.
+func TestDecodeAPIError_machineLearning(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		status   int
+		body     string
+		expected *common.APIError
+	}{
+		{
+			name:     "json body",
+			status:   http.StatusBadRequest,
+			body:     `{"status":"error","error":"invalid datasourceType"}`,
+			expected: &common.APIError{StatusCode: http.StatusBadRequest, Message: "invalid datasourceType"},
+		},
+		{
+			name:   "field path",
+			status: http.StatusBadRequest,
+			body:   `{"message":"hyperParams.seasonality: must be positive"}`,
+			expected: &common.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "hyperParams.seasonality: must be positive",
+				FieldErrors: []common.APIFieldError{
+					{Path: []string{"hyperParams", "seasonality"}, Message: "must be positive"},
+				},
+			},
+		},
+		{
+			name:     "plain text body",
+			status:   http.StatusForbidden,
+			body:     "permission denied",
+			expected: &common.APIError{StatusCode: http.StatusForbidden, Message: "permission denied"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// http.Error adds a newline to the body
+				http.Error(w, tc.body, tc.status)
+			}))
+			defer server.Close()
+			client, err := mlapi.New(server.URL, mlapi.Config{})
+			require.NoError(t, err)
+
+			_, err = client.Job(context.Background(), "job-id")
+			require.Error(t, err)
+			apiErr, ok := common.DecodeAPIError(err)
+			require.True(t, ok)
+			require.Equal(t, tc.expected, apiErr)
+		})
+	}
+}
+
+func TestAPIErrorFrameworkDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New(`status: 400, body: {"message":"hyperParams.seasonality: must be positive"}`)
+	diags := common.APIErrorFrameworkDiagnostics("Unable to create resource", err, nil)
+	require.Len(t, diags, 2)
+	require.Equal(t, "Unable to create resource (status 400)", diags[0].Summary())
+	require.Equal(t, "hyperParams.seasonality: must be positive", diags[0].Detail())
+	withPath, ok := diags[1].(interface{ Path() path.Path })
+	require.True(t, ok)
+	require.Equal(t, path.Root("hyper_params").AtName("seasonality"), withPath.Path())
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	err := &provisioning.PutAlertRuleGroupBadRequest{
+		Payload: &models.ValidationError{Message: "rules[2].data[0].relativeTimeRange: invalid range"},
+	}
+	diags := common.APIErrorDiagnostics("error saving rule group", err, map[string]string{"rules": "rule"})
+	require.Len(t, diags, 2)
+	require.Equal(t, "error saving rule group (status 400)", diags[0].Summary)
+	require.Equal(t, "rules[2].data[0].relativeTimeRange: invalid range", diags[0].Detail)
+	require.Nil(t, diags[0].AttributePath)
+	require.Equal(t, cty.GetAttrPath("rule").IndexInt(2).GetAttr("data").IndexInt(0).GetAttr("relative_time_range"), diags[1].AttributePath)
+
+	forbidden := &dashboards.PostDashboardForbidden{Payload: &models.ErrorResponseBody{Message: common.Ref("Access denied")}}
+	diags = common.APIErrorDiagnostics("error creating dashboard", forbidden, nil)
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Detail, "Access denied")
+	require.Contains(t, diags[0].Detail, "missing a permission")
+
+	diags = common.APIErrorDiagnostics("error creating dashboard", errors.New("connection refused"), nil)
+	require.Equal(t, diag.Diagnostics{{Severity: diag.Error, Summary: "error creating dashboard: connection refused"}}, diags)
+
+	require.Nil(t, common.APIErrorDiagnostics("error creating dashboard", nil, nil))
+}
+
+func TestAPIFieldAttributePath(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		cty.GetAttrPath("rule").IndexInt(0).GetAttr("data").IndexInt(1).GetAttr("datasource_uid"),
+		common.APIFieldAttributePath([]string{"rules", "0", "data", "1", "datasourceUID"}, map[string]string{"rules": "rule"}),
+	)
+	require.Equal(t,
+		cty.GetAttrPath("no_data_state"),
+		common.APIFieldAttributePath([]string{"noDataState"}, nil),
+	)
+}
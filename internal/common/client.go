@@ -21,6 +21,8 @@ type Client struct {
 	GrafanaAPIURLParsed *url.URL
 	GrafanaAPI          *goapi.GrafanaHTTPAPI
 	GrafanaAPIConfig    *goapi.TransportConfig
+	// GrafanaServerInfo returns the version, edition and feature toggles of the Grafana server. It is only fetched once, when first needed.
+	GrafanaServerInfo func() (*GrafanaServerInfo, error)
 
 	GrafanaCloudAPI *gcom.APIClient
 	SMAPI           *SMAPI.Client
@@ -28,9 +30,21 @@ type Client struct {
 	OnCallClient    *onCallAPI.Client
 	SLOClient       *slo.APIClient
 
+	// GrafanaConnection returns a client of another Grafana instance, for resources that override the provider's connection with a `grafana_connection` block.
+	// The client has the provider's settings (retries, TLS, headers, etc.). It is created once per connection and kept for the lifetime of the provider.
+	GrafanaConnection func(ctx context.Context, connection GrafanaConnectionConfig) (*Client, error)
+
 	alertingMutex sync.Mutex
 }
 
+// GrafanaConnectionConfig is the Grafana instance a resource is managed in, when it isn't the provider's.
+// Either the URL and auth, or the slug of a Grafana Cloud stack, are set.
+type GrafanaConnectionConfig struct {
+	URL       string
+	Auth      string
+	StackSlug string
+}
+
 // WithAlertingMutex is a helper function that wraps a CRUD Terraform function with a mutex.
 func WithAlertingMutex[T schema.CreateContextFunc | schema.ReadContextFunc | schema.UpdateContextFunc | schema.DeleteContextFunc](f T) T {
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
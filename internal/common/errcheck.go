@@ -22,7 +22,7 @@ func CheckReadError(resourceType string, d *schema.ResourceData, err error) (ret
 	}
 
 	if !IsNotFoundError(err) {
-		return diag.Errorf("error reading %s with ID`%s`: %v", resourceType, d.Id(), err), true
+		return APIErrorDiagnostics(fmt.Sprintf("error reading %s with ID `%s`", resourceType, d.Id()), err, nil), true
 	}
 
 	return WarnMissing(resourceType, d), true
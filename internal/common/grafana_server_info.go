@@ -0,0 +1,177 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	goVersion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const grafanaEditionOSS = "Open Source"
+
+// GrafanaServerInfo is the version, edition and enabled feature toggles of the Grafana server.
+// It is fetched from the `/api/health` and `/api/frontend/settings` endpoints.
+type GrafanaServerInfo struct {
+	Version          string
+	Commit           string
+	Database         string
+	Edition          string
+	EnterpriseCommit string
+	FeatureToggles   []string
+}
+
+// FetchGrafanaServerInfo fetches the info of the Grafana server. See Client.GrafanaServerInfo to get it lazily.
+func FetchGrafanaServerInfo(client *goapi.GrafanaHTTPAPI) (*GrafanaServerInfo, error) {
+	health, err := client.Health.GetHealth()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Grafana health: %w", err)
+	}
+	info := &GrafanaServerInfo{
+		Version:          health.Payload.Version,
+		Commit:           health.Payload.Commit,
+		Database:         health.Payload.Database,
+		EnterpriseCommit: health.Payload.EnterpriseCommit,
+	}
+
+	// The frontend settings aren't part of the OpenAPI spec, the request is made with the client's transport so that it has the same auth and settings
+	var settings struct {
+		BuildInfo struct {
+			Version string `json:"version"`
+			Edition string `json:"edition"`
+		} `json:"buildInfo"`
+		FeatureToggles map[string]bool `json:"featureToggles"`
+	}
+	_, err = client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getFrontendSettings",
+		Method:             "GET",
+		PathPattern:        "/frontend/settings",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(runtime.ClientRequest, strfmt.Registry) error {
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			if response.Code() != 200 {
+				return nil, runtime.NewAPIError("[GET /frontend/settings] getFrontendSettings", response, response.Code())
+			}
+			body, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+			return nil, json.Unmarshal(body, &settings)
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Grafana frontend settings: %w", err)
+	}
+
+	info.Edition = settings.BuildInfo.Edition
+	if info.Version == "" {
+		info.Version = settings.BuildInfo.Version
+	}
+	for toggle, enabled := range settings.FeatureToggles {
+		if enabled {
+			info.FeatureToggles = append(info.FeatureToggles, toggle)
+		}
+	}
+	sort.Strings(info.FeatureToggles)
+
+	return info, nil
+}
+
+// GrafanaRequirements are the Grafana server version, edition and feature toggles needed by a resource or data source.
+// They are checked when planning, so that unsupported servers fail early with a clear message instead of an API error.
+type GrafanaRequirements struct {
+	// MinVersion is the minimum Grafana version, ex: `10.2.0`.
+	MinVersion string
+	// Enterprise is true if Grafana Enterprise (or Grafana Cloud) is required.
+	Enterprise bool
+	// FeatureToggles must all be enabled, ex: `nestedFolders`.
+	FeatureToggles []string
+}
+
+// Check returns an error if the server doesn't meet the requirements of the given resource or data source.
+func (r GrafanaRequirements) Check(name string, info *GrafanaServerInfo) error {
+	if r.MinVersion != "" && info.Version != "" {
+		minVersion := goVersion.Must(goVersion.NewVersion(r.MinVersion))
+		// Pre-releases and builds are considered to be the release they lead to, ex: `11.3.0-pre` is `11.3.0`
+		if version, err := goVersion.NewVersion(info.Version); err == nil && version.Core().LessThan(minVersion.Core()) {
+			return fmt.Errorf("%s requires Grafana %s or later, but the server is running Grafana %s", name, r.MinVersion, info.Version)
+		}
+	}
+	if r.Enterprise && info.Edition == grafanaEditionOSS {
+		return fmt.Errorf("%s requires Grafana Enterprise or Grafana Cloud, but the server is running the %s edition of Grafana", name, info.Edition)
+	}
+	var missingToggles []string
+	for _, toggle := range r.FeatureToggles {
+		if !info.HasFeatureToggle(toggle) {
+			missingToggles = append(missingToggles, "`"+toggle+"`")
+		}
+	}
+	if len(missingToggles) > 0 {
+		return fmt.Errorf("%s requires the %s feature toggle(s) to be enabled on the Grafana server", name, strings.Join(missingToggles, ", "))
+	}
+	return nil
+}
+
+// HasFeatureToggle returns true if the feature toggle is enabled on the server.
+func (i *GrafanaServerInfo) HasFeatureToggle(toggle string) bool {
+	return slices.Contains(i.FeatureToggles, toggle)
+}
+
+// checkGrafanaRequirements checks the requirements against the server of the client.
+// If the server info can't be fetched (ex: the provider isn't configured yet, or the API isn't reachable), no error is returned: the API will be the judge.
+func checkGrafanaRequirements(name string, requirements *GrafanaRequirements, meta interface{}) error {
+	client, ok := meta.(*Client)
+	if !ok || client == nil || client.GrafanaServerInfo == nil {
+		return nil
+	}
+	info, err := client.GrafanaServerInfo()
+	if err != nil {
+		return nil
+	}
+	return requirements.Check(name, info)
+}
+
+// addLegacySDKRequirements checks the requirements of an SDKv2 resource when planning, or before reading a data source.
+func addLegacySDKRequirements(name string, r *schema.Resource, requirements *GrafanaRequirements, dataSource bool) {
+	if r == nil {
+		return
+	}
+
+	if dataSource {
+		readFn := r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := checkGrafanaRequirements(name, requirements, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return readFn(ctx, d, meta)
+		}
+		return
+	}
+
+	checkFn := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// Resources with a `grafana_connection` block are managed in another Grafana instance than the provider's, the API will be the judge
+		if _, ok := d.GetOk("grafana_connection"); ok {
+			return nil
+		}
+		return checkGrafanaRequirements(name, requirements, meta)
+	}
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = checkFn
+	} else {
+		r.CustomizeDiff = customdiff.All(checkFn, r.CustomizeDiff)
+	}
+}
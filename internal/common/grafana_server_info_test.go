@@ -0,0 +1,109 @@
+package common_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchGrafanaServerInfo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/health":
+			w.Write([]byte(`{"version":"11.3.0","commit":"abc","database":"ok"}`))
+		case "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo":{"version":"11.3.0","edition":"Open Source"},"featureToggles":{"nestedFolders":true,"publicDashboards":true,"disabled":false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := goapi.NewHTTPClientWithConfig(strfmt.Default, &goapi.TransportConfig{
+		Host:     serverURL.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	})
+
+	info, err := common.FetchGrafanaServerInfo(client)
+	require.NoError(t, err)
+	require.Equal(t, &common.GrafanaServerInfo{
+		Version:        "11.3.0",
+		Commit:         "abc",
+		Database:       "ok",
+		Edition:        "Open Source",
+		FeatureToggles: []string{"nestedFolders", "publicDashboards"},
+	}, info)
+}
+
+func TestGrafanaRequirementsCheck(t *testing.T) {
+	t.Parallel()
+
+	oss := &common.GrafanaServerInfo{Version: "10.4.1", Edition: "Open Source", FeatureToggles: []string{"nestedFolders"}}
+	enterprise := &common.GrafanaServerInfo{Version: "11.3.0-pre", Edition: "Enterprise"}
+
+	for name, tc := range map[string]struct {
+		requirements  common.GrafanaRequirements
+		info          *common.GrafanaServerInfo
+		expectedError string
+	}{
+		"no requirements": {
+			info: oss,
+		},
+		"version met": {
+			requirements: common.GrafanaRequirements{MinVersion: "10.2.0"},
+			info:         oss,
+		},
+		"version too low": {
+			requirements:  common.GrafanaRequirements{MinVersion: "11.0.0"},
+			info:          oss,
+			expectedError: "grafana_test requires Grafana 11.0.0 or later, but the server is running Grafana 10.4.1",
+		},
+		"pre-release counts as its release": {
+			requirements: common.GrafanaRequirements{MinVersion: "11.3.0"},
+			info:         enterprise,
+		},
+		"unknown version": {
+			requirements: common.GrafanaRequirements{MinVersion: "11.0.0"},
+			info:         &common.GrafanaServerInfo{},
+		},
+		"enterprise required on OSS": {
+			requirements:  common.GrafanaRequirements{Enterprise: true},
+			info:          oss,
+			expectedError: "grafana_test requires Grafana Enterprise or Grafana Cloud, but the server is running the Open Source edition of Grafana",
+		},
+		"enterprise required on enterprise": {
+			requirements: common.GrafanaRequirements{Enterprise: true},
+			info:         enterprise,
+		},
+		"feature toggle enabled": {
+			requirements: common.GrafanaRequirements{FeatureToggles: []string{"nestedFolders"}},
+			info:         oss,
+		},
+		"feature toggles missing": {
+			requirements:  common.GrafanaRequirements{FeatureToggles: []string{"nestedFolders", "kubernetesDashboards", "provisioning"}},
+			info:          oss,
+			expectedError: "grafana_test requires the `kubernetesDashboards`, `provisioning` feature toggle(s) to be enabled on the Grafana server",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.requirements.Check("grafana_test", tc.info)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// JSONChanges compares two decoded JSON documents (as returned by json.Unmarshal into an interface{})
+// and returns a human-readable list of the paths that differ between them.
+//
+// Paths use dots for object keys and brackets for array elements. Elements of arrays of objects
+// are identified by their `id` (`panels[id=4]`) or `name` (`templating.list[env]`) when every element has
+// a unique one, so that reordering or inserting elements doesn't report every following element as changed.
+// Otherwise, the array index is used (`targets[0]`).
+//
+// Example output: `panels[id=4].targets[0].expr changed`, `templating.list[env] added`.
+func JSONChanges(oldValue, newValue interface{}) []string {
+	var changes []string
+	jsonChanges("", oldValue, newValue, &changes)
+	return changes
+}
+
+// SummarizeJSONChanges formats the result of JSONChanges as a bullet list, truncated to maxChanges entries.
+func SummarizeJSONChanges(changes []string, maxChanges int) string {
+	var sb strings.Builder
+	for i, change := range changes {
+		if maxChanges > 0 && i >= maxChanges {
+			sb.WriteString(fmt.Sprintf("  - ... and %d more\n", len(changes)-maxChanges))
+			break
+		}
+		sb.WriteString("  - " + change + "\n")
+	}
+	return sb.String()
+}
+
+func jsonChanges(path string, oldValue, newValue interface{}, changes *[]string) {
+	switch o := oldValue.(type) {
+	case map[string]interface{}:
+		n, ok := newValue.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(o)+len(n))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		for k := range n {
+			if _, ok := o[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			oldChild, inOld := o[k]
+			newChild, inNew := n[k]
+			childPath := joinJSONPath(path, k)
+			switch {
+			case !inOld:
+				*changes = append(*changes, childPath+" added")
+			case !inNew:
+				*changes = append(*changes, childPath+" removed")
+			default:
+				jsonChanges(childPath, oldChild, newChild, changes)
+			}
+		}
+		return
+	case []interface{}:
+		n, ok := newValue.([]interface{})
+		if !ok {
+			break
+		}
+		jsonArrayChanges(path, o, n, changes)
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		if path == "" {
+			path = "(root)"
+		}
+		*changes = append(*changes, path+" changed")
+	}
+}
+
+func jsonArrayChanges(path string, oldValue, newValue []interface{}, changes *[]string) {
+	for _, keyField := range []string{"id", "name"} {
+		oldKeys, oldOK := jsonArrayKeys(oldValue, keyField)
+		newKeys, newOK := jsonArrayKeys(newValue, keyField)
+		if !oldOK || !newOK {
+			continue
+		}
+
+		newByKey := make(map[string]interface{}, len(newValue))
+		for i, key := range newKeys {
+			newByKey[key] = newValue[i]
+		}
+		oldByKey := make(map[string]bool, len(oldValue))
+		for i, key := range oldKeys {
+			oldByKey[key] = true
+			childPath := fmt.Sprintf("%s[%s]", path, key)
+			if newElem, ok := newByKey[key]; ok {
+				jsonChanges(childPath, oldValue[i], newElem, changes)
+			} else {
+				*changes = append(*changes, childPath+" removed")
+			}
+		}
+		for _, key := range newKeys {
+			if !oldByKey[key] {
+				*changes = append(*changes, fmt.Sprintf("%s[%s] added", path, key))
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(oldValue) || i < len(newValue); i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(newValue):
+			*changes = append(*changes, childPath+" removed")
+		case i >= len(oldValue):
+			*changes = append(*changes, childPath+" added")
+		default:
+			jsonChanges(childPath, oldValue[i], newValue[i], changes)
+		}
+	}
+}
+
+// jsonArrayKeys returns the identifying key of each element of the array, if every element is an object with a unique, non-empty value for the given field.
+func jsonArrayKeys(array []interface{}, field string) ([]string, bool) {
+	if len(array) == 0 {
+		return nil, true
+	}
+	keys := make([]string, 0, len(array))
+	seen := make(map[string]bool, len(array))
+	for _, elem := range array {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok := obj[field]
+		if !ok || value == nil || value == "" {
+			return nil, false
+		}
+		key := fmt.Sprint(value)
+		if field != "name" {
+			key = field + "=" + key
+		}
+		if seen[key] {
+			return nil, false
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys, true
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,80 @@
+package common_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONChanges(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		old      string
+		new      string
+		expected []string
+	}{
+		{
+			name: "no changes",
+			old:  `{"title":"test","panels":[{"id":1}]}`,
+			new:  `{"panels":[{"id":1}],"title":"test"}`,
+		},
+		{
+			name:     "scalar changes",
+			old:      `{"title":"test","refresh":"5s","editable":true}`,
+			new:      `{"title":"test2","refresh":"5s","editable":false}`,
+			expected: []string{"editable changed", "title changed"},
+		},
+		{
+			name:     "added and removed keys",
+			old:      `{"title":"test","time":{"from":"now-6h"}}`,
+			new:      `{"title":"test","refresh":"5s"}`,
+			expected: []string{"refresh added", "time removed"},
+		},
+		{
+			name:     "arrays matched by id",
+			old:      `{"panels":[{"id":4,"targets":[{"expr":"up"}]},{"id":5}]}`,
+			new:      `{"panels":[{"id":6},{"id":4,"targets":[{"expr":"down"}]}]}`,
+			expected: []string{"panels[id=4].targets[0].expr changed", "panels[id=5] removed", "panels[id=6] added"},
+		},
+		{
+			name:     "arrays matched by name",
+			old:      `{"templating":{"list":[{"name":"env","current":"prod"}]}}`,
+			new:      `{"templating":{"list":[{"name":"env","current":"dev"},{"name":"region"}]}}`,
+			expected: []string{"templating.list[env].current changed", "templating.list[region] added"},
+		},
+		{
+			name:     "arrays matched by index",
+			old:      `{"tags":["a","b","c"]}`,
+			new:      `{"tags":["a","d"]}`,
+			expected: []string{"tags[1] changed", "tags[2] removed"},
+		},
+		{
+			name:     "type change",
+			old:      `{"panels":[]}`,
+			new:      `{"panels":{}}`,
+			expected: []string{"panels changed"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var oldValue, newValue interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.old), &oldValue))
+			require.NoError(t, json.Unmarshal([]byte(tc.new), &newValue))
+			require.Equal(t, tc.expected, common.JSONChanges(oldValue, newValue))
+		})
+	}
+}
+
+func TestSummarizeJSONChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := []string{"a changed", "b added", "c removed"}
+	require.Equal(t, "  - a changed\n  - b added\n  - c removed\n", common.SummarizeJSONChanges(changes, 0))
+	require.Equal(t, "  - a changed\n  - ... and 2 more\n", common.SummarizeJSONChanges(changes, 1))
+}
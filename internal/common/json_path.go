@@ -0,0 +1,131 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonPathRegexp matches the paths accepted by RemoveJSONPath and CopyJSONPath: object keys separated by dots,
+// each optionally followed by array indexes or `[*]` for all the elements of the array. Ex: `templating.list[*].current`.
+var jsonPathRegexp = regexp.MustCompile(`^[^.\[\]]+(\[(\*|\d+)\])*(\.[^.\[\]]+(\[(\*|\d+)\])*)*$`)
+
+// ValidateJSONPath checks the syntax of a JSON path. The path must end with an object key.
+func ValidateJSONPath(path string) error {
+	if !jsonPathRegexp.MatchString(path) || strings.HasSuffix(path, "]") {
+		return fmt.Errorf("invalid JSON path %q: expected object keys separated by dots, with optional array indexes or [*], and ending with a key, ex: templating.list[*].current", path)
+	}
+	return nil
+}
+
+// splitJSONPath splits a path into object keys and array indexes (`[*]`, `[0]`).
+func splitJSONPath(path string) []string {
+	var steps []string
+	for _, part := range strings.Split(path, ".") {
+		key, indexes, _ := strings.Cut(part, "[")
+		steps = append(steps, key)
+		if indexes != "" {
+			for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+				steps = append(steps, "["+index+"]")
+			}
+		}
+	}
+	return steps
+}
+
+// RemoveJSONPath removes the values at the path from a decoded JSON document, in place. Missing values are ignored.
+func RemoveJSONPath(document interface{}, path string) {
+	removeJSONPath(document, splitJSONPath(path))
+}
+
+func removeJSONPath(value interface{}, steps []string) {
+	if len(steps) == 0 {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(steps) == 1 {
+			delete(v, steps[0])
+			return
+		}
+		removeJSONPath(v[steps[0]], steps[1:])
+	case []interface{}:
+		for i, elem := range v {
+			if matchesJSONIndex(steps[0], i) {
+				removeJSONPath(elem, steps[1:])
+			}
+		}
+	}
+}
+
+// CopyJSONPath copies the values at the path from the src document to the dst document, in place.
+// Values that are missing from src are left unchanged in dst. Elements of arrays are matched by their `name` or `id`, or by their index.
+func CopyJSONPath(dst, src interface{}, path string) {
+	copyJSONPath(dst, src, splitJSONPath(path))
+}
+
+func copyJSONPath(dst, src interface{}, steps []string) {
+	if len(steps) == 0 {
+		return
+	}
+	switch d := dst.(type) {
+	case map[string]interface{}:
+		s, ok := src.(map[string]interface{})
+		if !ok {
+			return
+		}
+		srcValue, ok := s[steps[0]]
+		if !ok {
+			return
+		}
+		if len(steps) == 1 {
+			d[steps[0]] = srcValue
+			return
+		}
+		copyJSONPath(d[steps[0]], srcValue, steps[1:])
+	case []interface{}:
+		s, ok := src.([]interface{})
+		if !ok {
+			return
+		}
+		for i, elem := range d {
+			if !matchesJSONIndex(steps[0], i) {
+				continue
+			}
+			if srcElem, ok := matchingJSONElement(elem, i, s); ok {
+				copyJSONPath(elem, srcElem, steps[1:])
+			}
+		}
+	}
+}
+
+// matchingJSONElement finds the element of the array matching the given element, by `name` or `id`, or at the same index.
+func matchingJSONElement(elem interface{}, index int, array []interface{}) (interface{}, bool) {
+	if obj, ok := elem.(map[string]interface{}); ok {
+		for _, field := range []string{"name", "id"} {
+			key, ok := obj[field]
+			if !ok || key == nil || key == "" {
+				continue
+			}
+			for _, candidate := range array {
+				if candidateObj, ok := candidate.(map[string]interface{}); ok && fmt.Sprint(candidateObj[field]) == fmt.Sprint(key) {
+					return candidate, true
+				}
+			}
+			return nil, false
+		}
+	}
+	if index < len(array) {
+		return array[index], true
+	}
+	return nil, false
+}
+
+func matchesJSONIndex(step string, index int) bool {
+	if step == "[*]" {
+		return true
+	}
+	i, err := strconv.Atoi(strings.Trim(step, "[]"))
+	return err == nil && i == index
+}
@@ -0,0 +1,71 @@
+package common_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJSONPath(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"time", "templating.list[*].current", "panels[0].options", "a[1][*].b"} {
+		require.NoError(t, common.ValidateJSONPath(path), path)
+	}
+	for _, path := range []string{"", ".time", "time.", "panels[*]", "panels[a].id", "a..b"} {
+		require.Error(t, common.ValidateJSONPath(path), path)
+	}
+}
+
+func TestRemoveJSONPath(t *testing.T) {
+	t.Parallel()
+
+	var document interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"time": {"from": "now-6h"},
+		"refresh": "5s",
+		"templating": {"list": [{"name": "env", "current": {"value": "prod"}}, {"name": "region"}]},
+		"panels": [{"collapsed": true}, {"collapsed": false}]
+	}`), &document))
+
+	for _, path := range []string{"time", "refresh", "templating.list[*].current", "panels[1].collapsed", "missing.key"} {
+		common.RemoveJSONPath(document, path)
+	}
+	result, err := json.Marshal(document)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"templating": {"list": [{"name": "env"}, {"name": "region"}]},
+		"panels": [{"collapsed": true}, {}]
+	}`, string(result))
+}
+
+func TestCopyJSONPath(t *testing.T) {
+	t.Parallel()
+
+	var dst, src interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"time": {"from": "now-6h"},
+		"templating": {"list": [{"name": "env", "current": {"value": "prod"}}, {"name": "region"}]},
+		"panels": [{"title": "a"}, {"title": "b"}]
+	}`), &dst))
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"time": {"from": "now-1h"},
+		"refresh": "1m",
+		"templating": {"list": [{"name": "region", "current": {"value": "eu"}}, {"name": "env", "current": {"value": "dev"}}]},
+		"panels": [{"title": "a", "collapsed": true}]
+	}`), &src))
+
+	for _, path := range []string{"time", "refresh", "templating.list[*].current", "panels[*].collapsed", "missing"} {
+		common.CopyJSONPath(dst, src, path)
+	}
+	result, err := json.Marshal(dst)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"time": {"from": "now-1h"},
+		"refresh": "1m",
+		"templating": {"list": [{"name": "env", "current": {"value": "dev"}}, {"name": "region", "current": {"value": "eu"}}]},
+		"panels": [{"title": "a", "collapsed": true}, {"title": "b"}]
+	}`, string(result))
+}
@@ -0,0 +1,294 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ListFilters are the filters of a `list` block. Only the filters supported by the resource are available in its list schema.
+type ListFilters struct {
+	// OrgID only lists resources from this Grafana organization.
+	OrgID *int64
+	// OrgSlug is the Grafana Cloud organization to list resources from.
+	OrgSlug string
+	// FolderUID only lists resources in this folder.
+	FolderUID string
+	// Tags only lists resources with all of these tags.
+	Tags []string
+}
+
+// ListerDataFunc returns the data arg passed to the lister of a list resource (See ResourceListIDsFunc).
+type ListerDataFunc func(client *Client, filters ListFilters) any
+
+// ListResource exposes the lister of a resource as a Terraform list resource, to be used in `list` blocks and `terraform query`.
+type ListResource struct {
+	resource   *Resource
+	listerData ListerDataFunc
+	client     *Client
+}
+
+var (
+	_ list.ListResourceWithConfigure    = (*ListResource)(nil)
+	_ list.ListResourceWithRawV5Schemas = (*ListResource)(nil)
+)
+
+func NewListResource(r *Resource, listerData ListerDataFunc) *ListResource {
+	return &ListResource{
+		resource:   r,
+		listerData: listerData,
+	}
+}
+
+func (r *ListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.resource.Name
+}
+
+func (r *ListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Configure is called before the provider is configured, ex: when validating the config
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *common.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+
+	// Framework resources are read with their own implementation, which needs to be configured as well
+	if fwResource := r.resource.PluginFrameworkSchema; fwResource != nil {
+		fwResource.Configure(ctx, req, resp)
+	}
+}
+
+func (r *ListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := map[string]listschema.Attribute{}
+	if r.resource.Category == CategoryCloud {
+		attributes["org_slug"] = listschema.StringAttribute{
+			Required:    true,
+			Description: "The slug of the Grafana Cloud organization to list resources from.",
+		}
+	}
+	if r.hasOrgIDField() {
+		attributes["org_id"] = listschema.Int64Attribute{
+			Optional:    true,
+			Description: "Only list resources from this organization. By default, resources are listed from all organizations if the provider uses basic auth, otherwise from the organization of the token.",
+		}
+	}
+	if r.folderAttribute() != "" {
+		attributes["folder_uid"] = listschema.StringAttribute{
+			Optional:    true,
+			Description: "Only list resources in the folder with this UID.",
+		}
+	}
+	if r.hasTags() {
+		attributes["tags"] = listschema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Only list resources with all of these tags.",
+		}
+	}
+
+	resp.Schema = listschema.Schema{
+		Description: fmt.Sprintf("Lists existing `%s` resources.", r.resource.Name),
+		Attributes:  attributes,
+	}
+}
+
+// RawV5Schemas returns the schemas of SDKv2 resources, which aren't known by the framework.
+func (r *ListResource) RawV5Schemas(ctx context.Context, req list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	if r.resource.Schema == nil {
+		return
+	}
+	resp.ProtoV5Schema = r.resource.Schema.ProtoSchema(ctx)()
+	resp.ProtoV5IdentitySchema = r.resource.Schema.ProtoIdentitySchema(ctx)()
+}
+
+func (r *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic("Unconfigured client", "The provider must be configured to list resources"),
+		})
+		return
+	}
+
+	filters, diags := r.filters(ctx, req.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	ids, err := r.resource.ListIDsFunc(ctx, r.client, r.listerData(r.client, filters))
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic(fmt.Sprintf("Failed to list %s resources", r.resource.Name), err.Error()),
+		})
+		return
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, id := range ids {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			values, err := r.resource.IDType.identityValues(id)
+			if err != nil {
+				// The lister returned an ID that can't be imported, skip it
+				continue
+			}
+			if filters.OrgID != nil && values["org_id"] != *filters.OrgID {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = id
+			for k, v := range values {
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(k), v)...)
+			}
+
+			if req.IncludeResource || filters.FolderUID != "" || len(filters.Tags) > 0 {
+				if !r.read(ctx, req, id, filters, &result) {
+					continue
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// read reads the resource to filter it and to include it in the result if requested.
+// It returns false if the resource doesn't exist anymore or if it doesn't match the filters.
+// Errors are returned in the result, so that they are reported to the user.
+func (r *ListResource) read(ctx context.Context, req list.ListRequest, id string, filters ListFilters, result *list.ListResult) bool {
+	if fwResource := r.resource.PluginFrameworkSchema; fwResource != nil {
+		state := tfsdk.State{Schema: req.ResourceSchema, Raw: result.Resource.Raw.Copy()}
+		if result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), id)...); result.Diagnostics.HasError() {
+			return true
+		}
+		resp := resource.ReadResponse{State: state, Identity: result.Identity}
+		fwResource.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		result.Diagnostics.Append(resp.Diagnostics...)
+		if resp.Diagnostics.HasError() {
+			return true
+		}
+		if resp.State.Raw.IsNull() {
+			return false
+		}
+		*result.Resource = tfsdk.Resource(resp.State)
+		return true
+	}
+
+	d := r.resource.Schema.Data(nil)
+	d.SetId(id)
+	for _, readDiag := range r.resource.Schema.ReadContext(ctx, d, r.client) {
+		if readDiag.Severity == sdkdiag.Error {
+			result.Diagnostics.AddError(readDiag.Summary, readDiag.Detail)
+		} else {
+			result.Diagnostics.AddWarning(readDiag.Summary, readDiag.Detail)
+		}
+	}
+	if result.Diagnostics.HasError() {
+		return true
+	}
+	if d.Id() == "" {
+		return false
+	}
+
+	if filters.FolderUID != "" && d.Get(r.folderAttribute()).(string) != filters.FolderUID {
+		return false
+	}
+	if len(filters.Tags) > 0 {
+		tags := r.resource.ListTagsFunc(d)
+		for _, tag := range filters.Tags {
+			if !slices.Contains(tags, tag) {
+				return false
+			}
+		}
+	}
+
+	if req.IncludeResource {
+		value, err := d.TfTypeResourceState()
+		if err != nil {
+			result.Diagnostics.AddError("Failed to convert resource state", err.Error())
+			return true
+		}
+		result.Resource.Raw = *value
+	}
+	return true
+}
+
+func (r *ListResource) filters(ctx context.Context, config tfsdk.Config) (ListFilters, diag.Diagnostics) {
+	var filters ListFilters
+	var diags diag.Diagnostics
+
+	if r.resource.Category == CategoryCloud {
+		var orgSlug types.String
+		diags.Append(config.GetAttribute(ctx, path.Root("org_slug"), &orgSlug)...)
+		filters.OrgSlug = orgSlug.ValueString()
+	}
+	if r.hasOrgIDField() {
+		var orgID types.Int64
+		diags.Append(config.GetAttribute(ctx, path.Root("org_id"), &orgID)...)
+		filters.OrgID = orgID.ValueInt64Pointer()
+	}
+	if r.folderAttribute() != "" {
+		var folderUID types.String
+		diags.Append(config.GetAttribute(ctx, path.Root("folder_uid"), &folderUID)...)
+		filters.FolderUID = folderUID.ValueString()
+	}
+	if r.hasTags() {
+		diags.Append(config.GetAttribute(ctx, path.Root("tags"), &filters.Tags)...)
+	}
+
+	return filters, diags
+}
+
+func (r *ListResource) hasOrgIDField() bool {
+	for _, f := range r.resource.IDType.Fields() {
+		if f.IdentityAttributeName() == "org_id" {
+			return true
+		}
+	}
+	return false
+}
+
+// folderAttribute returns the attribute holding the folder UID of the resource, if any.
+// Folder and tag filters are only supported for SDKv2 resources, since their state can be read without a prior state.
+func (r *ListResource) folderAttribute() string {
+	if r.resource.Schema == nil {
+		return ""
+	}
+	for _, name := range []string{"folder", "folder_uid"} {
+		if s, ok := r.resource.Schema.Schema[name]; ok && s.Type == schema.TypeString {
+			return name
+		}
+	}
+	return ""
+}
+
+func (r *ListResource) hasTags() bool {
+	return r.resource.Schema != nil && r.resource.ListTagsFunc != nil
+}
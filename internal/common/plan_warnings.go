@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+type planWarningsKey struct{}
+
+// WithPlanWarnings returns a context in which AddPlanWarning collects warnings, and the collected warnings.
+// SDKv2 CustomizeDiff functions can only return errors, so the provider server uses this to add their warnings to the plan.
+func WithPlanWarnings(ctx context.Context) (context.Context, *diag.Diagnostics) {
+	warnings := &diag.Diagnostics{}
+	return context.WithValue(ctx, planWarningsKey{}, warnings), warnings
+}
+
+// AddPlanWarning adds a warning to the plan of a resource, from its CustomizeDiff function.
+// It does nothing if the context doesn't come from WithPlanWarnings.
+func AddPlanWarning(ctx context.Context, summary, detail string, path cty.Path) {
+	warnings, ok := ctx.Value(planWarningsKey{}).(*diag.Diagnostics)
+	if !ok {
+		return
+	}
+	*warnings = append(*warnings, diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: path,
+	})
+}
+
+// PlanWarningsServer adds the warnings of CustomizeDiff functions (see AddPlanWarning) to the plans of an SDKv2 provider server.
+type PlanWarningsServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s PlanWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	ctx, warnings := WithPlanWarnings(ctx)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	for _, warning := range *warnings {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityWarning,
+			Summary:   warning.Summary,
+			Detail:    warning.Detail,
+			Attribute: attributePath(warning.AttributePath),
+		})
+	}
+	return resp, nil
+}
+
+func attributePath(path cty.Path) *tftypes.AttributePath {
+	if len(path) == 0 {
+		return nil
+	}
+	result := tftypes.NewAttributePath()
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			result = result.WithAttributeName(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.String {
+				result = result.WithElementKeyString(s.Key.AsString())
+			} else {
+				i, _ := s.Key.AsBigFloat().Int64()
+				result = result.WithElementKeyInt(int(i))
+			}
+		}
+	}
+	return result
+}
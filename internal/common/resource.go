@@ -27,6 +27,9 @@ type ResourceCommon struct {
 	Name     string
 	Schema   *schema.Resource // Legacy SDKv2 schema
 	Category ResourceCategory
+
+	// Requirements are the Grafana server version, edition and feature toggles needed by the resource or data source.
+	Requirements *GrafanaRequirements
 }
 
 // DataSource represents a Terraform data source, implemented either with the SDKv2 or Terraform Plugin Framework.
@@ -57,6 +60,13 @@ func NewDataSource(category ResourceCategory, name string, schema datasource.Dat
 	return d
 }
 
+// WithRequirements sets the Grafana server requirements of the data source. They are checked before reading it.
+func (d *DataSource) WithRequirements(requirements GrafanaRequirements) *DataSource {
+	d.Requirements = &requirements
+	addLegacySDKRequirements(d.Name, d.Schema, d.Requirements, true)
+	return d
+}
+
 // ResourceListIDsFunc is a function that returns a list of resource IDs.
 // This is used to generate TF config from existing resources.
 // The data arg can be used to pass information between different listers. For example, the list of stacks will be used when listing stack plugins.
@@ -68,6 +78,13 @@ type Resource struct {
 	IDType                *ResourceID
 	ListIDsFunc           ResourceListIDsFunc
 	PluginFrameworkSchema resource.ResourceWithConfigure
+
+	// ListTagsFunc returns the tags of the resource, used to filter `list` blocks by tags.
+	ListTagsFunc func(d *schema.ResourceData) []string
+
+	// ImportLookups are the human-friendly import ID formats accepted by the resource, in addition to its ID (ex: `team:{{ name }}`).
+	// They are only used for documentation, the resolution is done by the resource's importer.
+	ImportLookups []string
 }
 
 func NewLegacySDKResource(category ResourceCategory, name string, idType *ResourceID, schema *schema.Resource) *Resource {
@@ -79,10 +96,14 @@ func NewLegacySDKResource(category ResourceCategory, name string, idType *Resour
 		},
 		IDType: idType,
 	}
+	addLegacySDKIdentity(schema, idType)
 	return r
 }
 
 func NewResource(category ResourceCategory, name string, idType *ResourceID, schema resource.ResourceWithConfigure) *Resource {
+	if idType != nil {
+		schema = &frameworkResource{ResourceWithConfigure: schema, name: name, idType: idType}
+	}
 	r := &Resource{
 		ResourceCommon: ResourceCommon{
 			Name:     name,
@@ -99,6 +120,26 @@ func (r *Resource) WithLister(lister ResourceListIDsFunc) *Resource {
 	return r
 }
 
+// WithRequirements sets the Grafana server requirements of the resource. They are checked when planning.
+func (r *Resource) WithRequirements(requirements GrafanaRequirements) *Resource {
+	r.Requirements = &requirements
+	addLegacySDKRequirements(r.Name, r.Schema, r.Requirements, false)
+	if fwResource, ok := r.PluginFrameworkSchema.(*frameworkResource); ok {
+		fwResource.requirements = r.Requirements
+	}
+	return r
+}
+
+func (r *Resource) WithListTags(tags func(d *schema.ResourceData) []string) *Resource {
+	r.ListTagsFunc = tags
+	return r
+}
+
+func (r *Resource) WithImportLookups(formats ...string) *Resource {
+	r.ImportLookups = formats
+	return r
+}
+
 func (r *Resource) ImportExample() string {
 	exampleFromFields := func(fields []ResourceIDField) string {
 		fieldTemplates := make([]string, len(fields))
@@ -114,6 +155,10 @@ func (r *Resource) ImportExample() string {
 	if len(id.expectedFields) != len(id.RequiredFields()) {
 		example += exampleFromFields(id.expectedFields)
 	}
+	for _, lookup := range r.ImportLookups {
+		example += fmt.Sprintf(`terraform import %s.name %q
+`, r.Name, lookup)
+	}
 
 	return example
 }
@@ -100,13 +100,55 @@ func (id *ResourceID) Make(parts ...any) string {
 			if !ok {
 				panic(fmt.Sprintf("expected string for field %q, got %T", expectedField.Name, part)) // This is a coding error, so panic is appropriate
 			}
-			stringParts[i] = asString
+			stringParts[i] = EscapeResourceIDPart(asString)
 		}
 	}
 
 	return strings.Join(stringParts, ResourceIDSeparator)
 }
 
+// EscapeResourceIDPart escapes a string field of a resource ID, so that it can contain the separator.
+// When the part contains the separator, `\` is escaped as `\\` and `:` as `\:`.
+// Other strings are unchanged, so existing IDs stay the same.
+func EscapeResourceIDPart(part string) string {
+	if !strings.Contains(part, ResourceIDSeparator) {
+		return part
+	}
+	part = strings.ReplaceAll(part, `\`, `\\`)
+	return strings.ReplaceAll(part, ResourceIDSeparator, `\`+ResourceIDSeparator)
+}
+
+// UnescapeResourceIDPart reverses EscapeResourceIDPart.
+// Parts without an escaped separator are returned as is, so that IDs made before escaping are parsed as before.
+func UnescapeResourceIDPart(part string) string {
+	if !strings.Contains(part, ResourceIDSeparator) {
+		return part
+	}
+	parts := splitResourceID(part)
+	return strings.Join(parts, ResourceIDSeparator)
+}
+
+// splitResourceID splits a resource ID on the separators that aren't escaped, and unescapes the parts.
+func splitResourceID(resourceID string) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(resourceID); i++ {
+		c := resourceID[i]
+		if c == '\\' && i+1 < len(resourceID) && (resourceID[i+1] == '\\' || resourceID[i+1] == ResourceIDSeparator[0]) {
+			current.WriteByte(resourceID[i+1])
+			i++
+			continue
+		}
+		if c == ResourceIDSeparator[0] {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	return append(parts, current.String())
+}
+
 // Single parses a resource ID into a single value
 func (id *ResourceID) Single(resourceID string) (any, error) {
 	parts, err := id.Split(resourceID)
@@ -141,7 +183,15 @@ func (id *ResourceID) Split(resourceID string) ([]any, error) {
 // Split parses a resource ID into its parts
 // The parts will be cast to the expected types
 func split(resourceID string, expectedFields []ResourceIDField) ([]any, error) {
+	// Only IDs with more separators than fields can contain escaped separators
 	parts := strings.Split(resourceID, ResourceIDSeparator)
+	if len(parts) > len(expectedFields) {
+		parts = splitResourceID(resourceID)
+	}
+	// IDs made before escaping can have separators in their last string field, ex: `1:name:with:colons`
+	if last := len(expectedFields) - 1; last >= 0 && len(parts) > len(expectedFields) && expectedFields[last].Type == ResourceIDFieldTypeString {
+		parts = append(parts[:last], strings.Join(parts[last:], ResourceIDSeparator))
+	}
 	if len(parts) == len(expectedFields) {
 		partsAsAny := make([]any, len(parts))
 		for i, part := range parts {
@@ -0,0 +1,53 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceIDEscaping(t *testing.T) {
+	t.Parallel()
+
+	idType := common.NewResourceID(common.OptionalIntIDField("orgID"), common.StringIDField("name"))
+
+	for _, tc := range []struct {
+		name       string
+		parts      []any
+		expectedID string
+	}{
+		{name: "without separator", parts: []any{int64(1), "my-contact-point"}, expectedID: "1:my-contact-point"},
+		{name: "with separator", parts: []any{int64(1), "team:alerts"}, expectedID: `1:team\:alerts`},
+		{name: "with backslash", parts: []any{int64(1), `a\:b\`}, expectedID: `1:a\\\:b\\`},
+		{name: "with backslash but no separator", parts: []any{int64(1), `a\\b`}, expectedID: `1:a\\b`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := idType.Make(tc.parts...)
+			require.Equal(t, tc.expectedID, id)
+			parts, err := idType.Split(id)
+			require.NoError(t, err)
+			require.Equal(t, tc.parts, parts)
+		})
+	}
+
+	// IDs in existing state weren't escaped
+	for _, tc := range []struct {
+		id            string
+		expectedParts []any
+	}{
+		{id: "1:team:alerts", expectedParts: []any{int64(1), "team:alerts"}},
+		{id: "team:alerts", expectedParts: []any{"team:alerts"}},
+		{id: `1:C:\path`, expectedParts: []any{int64(1), `C:\path`}},
+		{id: `1:a\\b`, expectedParts: []any{int64(1), `a\\b`}},
+	} {
+		parts, err := idType.Split(tc.id)
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedParts, parts)
+	}
+
+	require.Equal(t, "team:alerts", common.UnescapeResourceIDPart(common.EscapeResourceIDPart("team:alerts")))
+}
@@ -0,0 +1,334 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	frameworkDiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource identities are derived from the ResourceID of each resource: each ID field becomes an identity attribute.
+// For example, `orgResourceIDString("uid")` gives the `org_id` (optional) and `uid` (required) identity attributes,
+// which can be used in `import` blocks instead of the colon-separated ID:
+//
+//	import {
+//	  to       = grafana_folder.my_folder
+//	  identity = { org_id = 2, uid = "abc" }
+//	}
+//
+// The identity is set from the resource ID after every create, read and update.
+// Since some resources change their ID when updated (ex: renaming a contact point), identities are always declared as mutable.
+
+// IdentityAttributeName returns the name of the identity attribute matching the ID field (ex: `orgID` -> `org_id`).
+func (f ResourceIDField) IdentityAttributeName() string {
+	name, _, _ := strings.Cut(f.Name, " (")
+	return camelToSnakeCase(name)
+}
+
+// legacySDKIdentity returns the SDKv2 identity schema of the resource ID.
+func (id *ResourceID) legacySDKIdentity() *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			identitySchema := make(map[string]*schema.Schema, len(id.expectedFields))
+			for _, f := range id.expectedFields {
+				s := &schema.Schema{
+					Type:              schema.TypeString,
+					Description:       fmt.Sprintf("The `%s` part of the resource ID.", f.Name),
+					RequiredForImport: !f.Optional,
+					OptionalForImport: f.Optional,
+				}
+				if f.Type == ResourceIDFieldTypeInt {
+					s.Type = schema.TypeInt
+				}
+				identitySchema[f.IdentityAttributeName()] = s
+			}
+			return identitySchema
+		},
+	}
+}
+
+// pluginFrameworkIdentity returns the framework identity schema of the resource ID.
+func (id *ResourceID) pluginFrameworkIdentity() identityschema.Schema {
+	attributes := make(map[string]identityschema.Attribute, len(id.expectedFields))
+	for _, f := range id.expectedFields {
+		description := fmt.Sprintf("The `%s` part of the resource ID.", f.Name)
+		if f.Type == ResourceIDFieldTypeInt {
+			attributes[f.IdentityAttributeName()] = identityschema.Int64Attribute{Description: description, RequiredForImport: !f.Optional, OptionalForImport: f.Optional}
+		} else {
+			attributes[f.IdentityAttributeName()] = identityschema.StringAttribute{Description: description, RequiredForImport: !f.Optional, OptionalForImport: f.Optional}
+		}
+	}
+	return identityschema.Schema{Attributes: attributes}
+}
+
+// identityValues splits the resource ID into identity attribute values. Optional fields that aren't part of the ID are omitted.
+func (id *ResourceID) identityValues(resourceID string) (map[string]any, error) {
+	parts, err := id.Split(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	fields := id.expectedFields
+	if len(parts) != len(fields) {
+		fields = id.RequiredFields()
+	}
+	values := make(map[string]any, len(parts))
+	for i, f := range fields {
+		values[f.IdentityAttributeName()] = parts[i]
+	}
+	return values, nil
+}
+
+// idFromIdentity builds the resource ID from identity attribute values, as returned by the given getter.
+// The getter returns false for attributes that aren't set.
+func (id *ResourceID) idFromIdentity(get func(f ResourceIDField) (any, bool)) (string, error) {
+	var parts []string
+	for _, f := range id.expectedFields {
+		value, ok := get(f)
+		if !ok {
+			if f.Optional {
+				continue
+			}
+			return "", fmt.Errorf("the `%s` identity attribute is required", f.IdentityAttributeName())
+		}
+		if f.Type == ResourceIDFieldTypeString {
+			value = EscapeResourceIDPart(fmt.Sprint(value))
+		}
+		parts = append(parts, fmt.Sprint(value))
+	}
+	return strings.Join(parts, ResourceIDSeparator), nil
+}
+
+// addLegacySDKIdentity adds an identity derived from the resource ID to an SDKv2 resource.
+func addLegacySDKIdentity(r *schema.Resource, idType *ResourceID) {
+	if r == nil || idType == nil || r.Identity != nil {
+		return
+	}
+	r.Identity = idType.legacySDKIdentity()
+	r.ResourceBehavior.MutableIdentity = true
+
+	setIdentity := func(d *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		values, err := idType.identityValues(d.Id())
+		if err != nil {
+			// The ID doesn't match the expected format, don't fail the operation. The identity will simply not be available.
+			return diags
+		}
+		identity, err := d.Identity()
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		for k, v := range values {
+			if err := identity.Set(k, v); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+		return diags
+	}
+	withIdentity := func(fn func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if fn == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return setIdentity(d, fn(ctx, d, meta))
+		}
+	}
+	r.CreateContext = withIdentity(r.CreateContext)
+	r.ReadContext = withIdentity(r.ReadContext)
+	r.UpdateContext = withIdentity(r.UpdateContext)
+
+	if r.Importer == nil || r.Importer.StateContext == nil {
+		return
+	}
+	importer := r.Importer.StateContext
+	r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		// Imported with an `identity` instead of an ID
+		if d.Id() == "" {
+			identity, err := d.Identity()
+			if err != nil {
+				return nil, err
+			}
+			resourceID, err := idType.idFromIdentity(func(f ResourceIDField) (any, bool) {
+				value, ok := identity.GetOk(f.IdentityAttributeName())
+				return value, ok
+			})
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(resourceID)
+		}
+		return importer(ctx, d, meta)
+	}
+}
+
+// frameworkResource wraps framework resources to add an identity derived from the resource ID, and to check their Grafana requirements.
+// The wrapped resource must store its ID in the `id` attribute. Its optional interfaces are forwarded, the wrapper implementing all of them.
+type frameworkResource struct {
+	resource.ResourceWithConfigure
+	name         string
+	idType       *ResourceID
+	requirements *GrafanaRequirements
+	client       *Client
+}
+
+var (
+	_ resource.ResourceWithIdentity         = (*frameworkResource)(nil)
+	_ resource.ResourceWithImportState      = (*frameworkResource)(nil)
+	_ resource.ResourceWithModifyPlan       = (*frameworkResource)(nil)
+	_ resource.ResourceWithValidateConfig   = (*frameworkResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*frameworkResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*frameworkResource)(nil)
+	_ resource.ResourceWithMoveState        = (*frameworkResource)(nil)
+)
+
+func (r *frameworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ResourceWithConfigure.Metadata(ctx, req, resp)
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *frameworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.ResourceWithConfigure.Configure(ctx, req, resp)
+	if client, ok := req.ProviderData.(*Client); ok {
+		r.client = client
+	}
+}
+
+func (r *frameworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if modifier, ok := r.ResourceWithConfigure.(resource.ResourceWithModifyPlan); ok {
+		modifier.ModifyPlan(ctx, req, resp)
+	}
+	if r.requirements == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	// Resources with a `grafana_connection` block aren't checked, see addLegacySDKRequirements
+	if _, ok := req.Plan.Schema.GetBlocks()["grafana_connection"]; ok {
+		var connection types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("grafana_connection"), &connection)...)
+		if resp.Diagnostics.HasError() || len(connection.Elements()) > 0 {
+			return
+		}
+	}
+	if err := checkGrafanaRequirements(r.name, r.requirements, r.client); err != nil {
+		resp.Diagnostics.AddError("Unsupported Grafana server", err.Error())
+	}
+}
+
+func (r *frameworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if validator, ok := r.ResourceWithConfigure.(resource.ResourceWithValidateConfig); ok {
+		validator.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *frameworkResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if validators, ok := r.ResourceWithConfigure.(resource.ResourceWithConfigValidators); ok {
+		return validators.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// UpgradeState returns no upgraders for resources without state versions, which the framework reports like a missing implementation.
+func (r *frameworkResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if upgrader, ok := r.ResourceWithConfigure.(resource.ResourceWithUpgradeState); ok {
+		return upgrader.UpgradeState(ctx)
+	}
+	return nil
+}
+
+// MoveState returns no movers for resources that can't be moved to, which the framework reports like a missing implementation.
+func (r *frameworkResource) MoveState(ctx context.Context) []resource.StateMover {
+	if mover, ok := r.ResourceWithConfigure.(resource.ResourceWithMoveState); ok {
+		return mover.MoveState(ctx)
+	}
+	return nil
+}
+
+func (r *frameworkResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = r.idType.pluginFrameworkIdentity()
+}
+
+func (r *frameworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.ResourceWithConfigure.Create(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.setIdentity(ctx, resp.State, resp.Identity)...)
+	}
+}
+
+func (r *frameworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	r.ResourceWithConfigure.Read(ctx, req, resp)
+	if !resp.Diagnostics.HasError() && !resp.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.setIdentity(ctx, resp.State, resp.Identity)...)
+	}
+}
+
+func (r *frameworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.ResourceWithConfigure.Update(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.setIdentity(ctx, resp.State, resp.Identity)...)
+	}
+}
+
+func (r *frameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importer, ok := r.ResourceWithConfigure.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError("Resource Import Not Implemented", "This resource does not support import.")
+		return
+	}
+
+	// Imported with an `identity` instead of an ID
+	if req.ID == "" && req.Identity != nil {
+		resourceID, err := r.idType.idFromIdentity(func(f ResourceIDField) (any, bool) {
+			var value any
+			if f.Type == ResourceIDFieldTypeInt {
+				var v types.Int64
+				resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(f.IdentityAttributeName()), &v)...)
+				value = v.ValueInt64()
+				return value, !v.IsNull() && !v.IsUnknown()
+			}
+			var v types.String
+			resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(f.IdentityAttributeName()), &v)...)
+			value = v.ValueString()
+			return value, !v.IsNull() && !v.IsUnknown()
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import identity", err.Error())
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		req.ID = resourceID
+	}
+
+	importer.ImportState(ctx, req, resp)
+}
+
+// setIdentity sets the identity from the `id` attribute of the state.
+// Unlike SDKv2, the framework requires an identity after create, read and update, so an invalid ID is an error.
+func (r *frameworkResource) setIdentity(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity) frameworkDiag.Diagnostics {
+	var diags frameworkDiag.Diagnostics
+	if identity == nil {
+		return diags
+	}
+
+	var id types.String
+	if diags.Append(state.GetAttribute(ctx, path.Root("id"), &id)...); diags.HasError() || id.ValueString() == "" {
+		return diags
+	}
+	values, err := r.idType.identityValues(id.ValueString())
+	if err != nil {
+		diags.AddError("Failed to set resource identity", err.Error())
+		return diags
+	}
+	for k, v := range values {
+		diags.Append(identity.SetAttribute(ctx, path.Root(k), v)...)
+	}
+	return diags
+}
@@ -0,0 +1,135 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacySDKResourceIdentity(t *testing.T) {
+	t.Parallel()
+
+	idType := common.NewResourceID(common.OptionalIntIDField("orgID"), common.StringIDField("folderUID"), common.StringIDField("type (role, team, or user)"))
+	r := common.NewLegacySDKResource(common.CategoryGrafanaOSS, "grafana_test", idType, &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil },
+		Importer:    &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
+		Schema:      map[string]*schema.Schema{},
+	})
+	identitySchema := r.Schema.Identity.SchemaFunc()
+	require.Len(t, identitySchema, 3)
+	require.Contains(t, identitySchema, "type")
+	require.Equal(t, schema.TypeInt, identitySchema["org_id"].Type)
+	require.True(t, identitySchema["org_id"].OptionalForImport)
+	require.True(t, identitySchema["folder_uid"].RequiredForImport)
+
+	t.Run("set on read", func(t *testing.T) {
+		d := schema.TestResourceDataWithIdentityRaw(t, r.Schema.Schema, identitySchema, map[string]string{})
+		d.SetId("2:abc:team")
+		require.False(t, r.Schema.ReadContext(context.Background(), d, nil).HasError())
+
+		identity, err := d.Identity()
+		require.NoError(t, err)
+		require.Equal(t, 2, identity.Get("org_id"))
+		require.Equal(t, "abc", identity.Get("folder_uid"))
+		require.Equal(t, "team", identity.Get("type"))
+	})
+
+	t.Run("not set on failed create", func(t *testing.T) {
+		failing := common.NewLegacySDKResource(common.CategoryGrafanaOSS, "grafana_test", idType, &schema.Resource{
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				d.SetId("2:abc:team")
+				return diag.Errorf("failed to read the created resource")
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil },
+			Schema:      map[string]*schema.Schema{},
+		})
+		d := schema.TestResourceDataWithIdentityRaw(t, failing.Schema.Schema, identitySchema, map[string]string{})
+		diags := failing.Schema.CreateContext(context.Background(), d, nil)
+		require.Len(t, diags, 1)
+		require.Equal(t, "failed to read the created resource", diags[0].Summary)
+
+		identity, err := d.Identity()
+		require.NoError(t, err)
+		_, ok := identity.GetOk("folder_uid")
+		require.False(t, ok)
+	})
+
+	for _, tc := range []struct {
+		name       string
+		identity   map[string]string
+		expectedID string
+		expectErr  bool
+	}{
+		{name: "import with all attributes", identity: map[string]string{"org_id": "2", "folder_uid": "abc", "type": "team"}, expectedID: "2:abc:team"},
+		{name: "import without optional attributes", identity: map[string]string{"folder_uid": "abc", "type": "team"}, expectedID: "abc:team"},
+		{name: "import without required attributes", identity: map[string]string{"org_id": "2", "type": "team"}, expectErr: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataWithIdentityRaw(t, r.Schema.Schema, identitySchema, tc.identity)
+			_, err := r.Schema.Importer.StateContext(context.Background(), d, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedID, d.Id())
+		})
+	}
+}
+
+// testFrameworkResource implements the optional framework resource interfaces, to check that they are forwarded.
+type testFrameworkResource struct {
+	resource.ResourceWithConfigure
+	calls []string
+}
+
+func (r *testFrameworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.calls = append(r.calls, "ModifyPlan")
+}
+
+func (r *testFrameworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	r.calls = append(r.calls, "ValidateConfig")
+}
+
+func (r *testFrameworkResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return make([]resource.ConfigValidator, 1)
+}
+
+func (r *testFrameworkResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{0: {}}
+}
+
+func (r *testFrameworkResource) MoveState(ctx context.Context) []resource.StateMover {
+	return make([]resource.StateMover, 1)
+}
+
+func TestFrameworkResourceForwarding(t *testing.T) {
+	t.Parallel()
+
+	idType := common.NewResourceID(common.StringIDField("uid"))
+	inner := &testFrameworkResource{}
+	r := common.NewResource(common.CategoryGrafanaOSS, "grafana_test", idType, inner).PluginFrameworkSchema
+	ctx := context.Background()
+
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{}, &resource.ModifyPlanResponse{})
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{}, &resource.ValidateConfigResponse{})
+	require.Equal(t, []string{"ModifyPlan", "ValidateConfig"}, inner.calls)
+	require.Len(t, r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx), 1)
+	require.Contains(t, r.(resource.ResourceWithUpgradeState).UpgradeState(ctx), int64(0))
+	require.Len(t, r.(resource.ResourceWithMoveState).MoveState(ctx), 1)
+
+	// Resources without the optional interfaces get nothing forwarded
+	r = common.NewResource(common.CategoryGrafanaOSS, "grafana_test", idType, struct{ resource.ResourceWithConfigure }{}).PluginFrameworkSchema
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{}, &resource.ValidateConfigResponse{})
+	require.Nil(t, r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx))
+	require.Nil(t, r.(resource.ResourceWithUpgradeState).UpgradeState(ctx))
+	require.Nil(t, r.(resource.ResourceWithMoveState).MoveState(ctx))
+}
@@ -0,0 +1,204 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Tokens created by a rotation are named after the configured name, with the Unix time of the rotation as a suffix,
+// since the names of the tokens of a service account must be unique.
+var rotatedTokenNameRegex = regexp.MustCompile(`^(.*)-\d+$`)
+
+// TokenCreateFunc creates a token with the given name, and returns its ID and its key.
+type TokenCreateFunc func(name string) (id string, key string, err error)
+
+// TokenDeleteFunc deletes the token with the given ID.
+type TokenDeleteFunc func(id string) error
+
+// WithTokenRotation adds the `rotation` block to the schema of a token resource, as well as the attributes of the previous token.
+// Resources using it must set CustomizeTokenRotationDiff as their CustomizeDiff function, and call RotateToken in their update function.
+func WithTokenRotation(attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	attributes["rotation"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Rotates the token periodically. When the token is older than `rotate_after`, the next apply creates a new token, " +
+			"and the previous token is kept during the `overlap` period so that its consumers can switch to the new one. " +
+			"The previous token is deleted by the first apply after the end of the overlap period.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"rotate_after": {
+					Type:             schema.TypeString,
+					Required:         true,
+					Description:      "The age of the token after which it is rotated, ex: `720h`.",
+					ValidateDiagFunc: validatePositiveDuration,
+				},
+				"overlap": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "0s",
+					Description:      "How long the previous token is kept after a rotation, ex: `24h`. By default, the previous token is deleted as soon as the new one is created.",
+					ValidateDiagFunc: ValidateDuration,
+				},
+			},
+		},
+	}
+	attributes["created_at"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The creation date of the current token.",
+	}
+	attributes["previous_key"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The key of the previous token, during the overlap period of a rotation. Empty otherwise.",
+	}
+	attributes["previous_token_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the previous token, during the overlap period of a rotation. Empty otherwise.",
+	}
+	return attributes
+}
+
+func validatePositiveDuration(i interface{}, p cty.Path) diag.Diagnostics {
+	if diags := ValidateDuration(i, p); diags != nil {
+		return diags
+	}
+	if d, _ := time.ParseDuration(i.(string)); d <= 0 {
+		return diag.Errorf("%q must be a positive duration", i.(string))
+	}
+	return nil
+}
+
+// CustomizeTokenRotationDiff plans the rotation of the token, and the deletion of the previous token, when they are due.
+func CustomizeTokenRotationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	rotate, deletePrevious := tokenRotationDue(d, time.Now())
+	if rotate {
+		for _, attr := range []string{"key", "created_at", "expiration", "has_expired", "previous_key", "previous_token_id"} {
+			if err := d.SetNewComputed(attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if deletePrevious {
+		// Setting them to an empty string wouldn't be planned as a change, since they are computed
+		if err := d.SetNewComputed("previous_key"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("previous_token_id")
+	}
+	return nil
+}
+
+// tokenRotationDue returns whether the token must be rotated, and whether the previous token must be deleted.
+// The previous token is deleted at the end of the overlap period, or as soon as the rotation is disabled.
+func tokenRotationDue(d interface{ Get(string) interface{} }, now time.Time) (rotate bool, deletePrevious bool) {
+	hasPrevious := d.Get("previous_token_id").(string) != ""
+	rotation := d.Get("rotation").([]interface{})
+	if len(rotation) == 0 || rotation[0] == nil {
+		return false, hasPrevious
+	}
+	createdAt, err := time.Parse(time.RFC3339, d.Get("created_at").(string))
+	if err != nil {
+		// The creation date of tokens created with an older version of the provider is set by the next refresh
+		return false, false
+	}
+
+	rotateAfter, overlap := tokenRotationDurations(rotation[0].(map[string]interface{}))
+	rotate = !now.Before(createdAt.Add(rotateAfter))
+	deletePrevious = hasPrevious && !now.Before(createdAt.Add(overlap))
+	return rotate, deletePrevious
+}
+
+func tokenRotationDurations(rotation map[string]interface{}) (rotateAfter time.Duration, overlap time.Duration) {
+	rotateAfter, _ = time.ParseDuration(rotation["rotate_after"].(string))
+	overlap, _ = time.ParseDuration(rotation["overlap"].(string))
+	return rotateAfter, overlap
+}
+
+// RotateToken applies the plan of CustomizeTokenRotationDiff: it creates a new token when the token is rotated,
+// and deletes the previous token at the end of the overlap period.
+// At most two tokens are kept: if a previous token still exists when the token is rotated, it is deleted.
+func RotateToken(d *schema.ResourceData, createToken TokenCreateFunc, deleteToken TokenDeleteFunc) error {
+	// The attributes of the tokens that are replaced are unknown in the plan
+	plan := d.GetRawPlan()
+	rotate := !plan.GetAttr("created_at").IsKnown()
+	deletePrevious := !plan.GetAttr("previous_token_id").IsKnown()
+	if !rotate && !deletePrevious {
+		return nil
+	}
+
+	oldPreviousID, _ := d.GetChange("previous_token_id")
+	if oldPreviousID.(string) != "" {
+		if err := deleteTokenIfExists(deleteToken, oldPreviousID.(string)); err != nil {
+			return err
+		}
+	}
+	d.Set("previous_key", "")
+	d.Set("previous_token_id", "")
+	if !rotate {
+		return nil
+	}
+
+	oldKey, _ := d.GetChange("key")
+	oldID := d.Id()
+
+	now := time.Now()
+	id, key, err := createToken(RotatedTokenName(d.Get("name").(string), now))
+	if err != nil {
+		return fmt.Errorf("failed to create the rotated token: %w", err)
+	}
+	d.SetId(id)
+	d.Set("key", key)
+	d.Set("created_at", now.UTC().Format(time.RFC3339))
+
+	_, overlap := tokenRotationDurations(d.Get("rotation.0").(map[string]interface{}))
+	if overlap == 0 {
+		return deleteTokenIfExists(deleteToken, oldID)
+	}
+	d.Set("previous_key", oldKey)
+	d.Set("previous_token_id", oldID)
+	return nil
+}
+
+// DeletePreviousToken deletes the previous token of a rotated token resource, if there is one. It is meant to be called when the resource is deleted.
+func DeletePreviousToken(d *schema.ResourceData, deleteToken TokenDeleteFunc) error {
+	if previousID := d.Get("previous_token_id").(string); previousID != "" {
+		return deleteTokenIfExists(deleteToken, previousID)
+	}
+	return nil
+}
+
+func deleteTokenIfExists(deleteToken TokenDeleteFunc, id string) error {
+	if err := deleteToken(id); err != nil && !IsNotFoundError(err) {
+		return fmt.Errorf("failed to delete the previous token %s: %w", id, err)
+	}
+	return nil
+}
+
+// RotatedTokenName returns the name of a token created by a rotation.
+func RotatedTokenName(name string, now time.Time) string {
+	return fmt.Sprintf("%s-%d", name, now.Unix())
+}
+
+// TokenName returns the name to set in the state of a token resource, given the configured name and the name returned by the API.
+// The suffix of tokens created by a rotation is ignored, so that they don't conflict with the configuration.
+func TokenName(configured, actual string) string {
+	if match := rotatedTokenNameRegex.FindStringSubmatch(actual); match != nil && match[1] == configured {
+		return configured
+	}
+	return actual
+}
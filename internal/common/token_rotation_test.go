@@ -0,0 +1,30 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenName(t *testing.T) {
+	t.Parallel()
+
+	rotated := common.RotatedTokenName("my-token", time.Unix(1700000000, 0))
+	require.Equal(t, "my-token-1700000000", rotated)
+
+	for _, tc := range []struct {
+		configured string
+		actual     string
+		expected   string
+	}{
+		{configured: "my-token", actual: "my-token", expected: "my-token"},
+		{configured: "my-token", actual: rotated, expected: "my-token"},
+		{configured: "my-token", actual: "other-token-1700000000", expected: "other-token-1700000000"},
+		{configured: "my-token", actual: "my-token-renamed", expected: "my-token-renamed"},
+		{configured: "my", actual: "my-token-1700000000", expected: "my-token-1700000000"},
+	} {
+		require.Equal(t, tc.expected, common.TokenName(tc.configured, tc.actual), "configured: %s, actual: %s", tc.configured, tc.actual)
+	}
+}
@@ -2,13 +2,16 @@ package cloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
 	gcom "github.com/grafana/grafana-com-public-clients/go"
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -210,3 +213,102 @@ func CreateTemporaryStackGrafanaClient(ctx context.Context, cloudClient *gcom.AP
 
 	return client, cleanup, nil
 }
+
+// CreateStackServiceAccountToken creates a token for the admin service account with the given name in the stack, creating the service account
+// if it doesn't exist. The service account is reused, ex: by every provider connecting to the stack, so only its tokens are rotated:
+// its expired tokens are deleted. It returns the URL of the stack, the token, and a function deleting the token, which works once the token has expired.
+func CreateStackServiceAccountToken(ctx context.Context, cloudClient *gcom.APIClient, stackSlug, name string, secondsToLive int32) (string, string, func() error, error) {
+	stack, _, err := cloudClient.InstancesAPI.GetInstance(ctx, stackSlug).Execute()
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	saID, err := stackServiceAccountID(ctx, cloudClient, stackSlug, name)
+	if err != nil {
+		return "", "", nil, err
+	}
+	id := strconv.FormatInt(saID, 10)
+
+	tokens, _, err := cloudClient.InstancesAPI.GetInstanceServiceAccountTokens(ctx, stackSlug, id).Execute()
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to list the tokens of service account %s: %w", name, err)
+	}
+	for _, token := range tokens {
+		if token.HasExpired == nil || !*token.HasExpired {
+			continue
+		}
+		if _, err := cloudClient.InstancesAPI.DeleteInstanceServiceAccountToken(ctx, stackSlug, id, strconv.FormatInt(*token.Id, 10)).
+			XRequestId(ClientRequestID()).
+			Execute(); err != nil {
+			return "", "", nil, fmt.Errorf("failed to delete the expired token %d of service account %s: %w", *token.Id, name, err)
+		}
+	}
+
+	tokenRequest := gcom.PostInstanceServiceAccountTokensRequest{
+		Name:          fmt.Sprintf("%s-%d", name, time.Now().UnixNano()),
+		SecondsToLive: common.Ref(secondsToLive),
+	}
+	token, _, err := cloudClient.InstancesAPI.PostInstanceServiceAccountTokens(ctx, stackSlug, id).
+		PostInstanceServiceAccountTokensRequest(tokenRequest).
+		XRequestId(ClientRequestID()).
+		Execute()
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	cleanup := func() error {
+		// The cleanup can happen after the context of the request that created the token is done
+		_, err := cloudClient.InstancesAPI.DeleteInstanceServiceAccountToken(context.Background(), stackSlug, id, strconv.FormatInt(int64(*token.Id), 10)).
+			XRequestId(ClientRequestID()).
+			Execute()
+		return err
+	}
+
+	return stack.Url, *token.Key, cleanup, nil
+}
+
+// stackServiceAccountID returns the ID of the admin service account with the given name in the stack, creating it if it doesn't exist.
+// The Cloud API can't look up service accounts by name, so an existing one is looked up through the Grafana API,
+// with a temporary service account that is deleted right after.
+func stackServiceAccountID(ctx context.Context, cloudClient *gcom.APIClient, stackSlug, name string) (int64, error) {
+	sa, resp, err := cloudClient.InstancesAPI.PostInstanceServiceAccounts(ctx, stackSlug).
+		PostInstanceServiceAccountsRequest(gcom.PostInstanceServiceAccountsRequest{Name: name, Role: "Admin"}).
+		XRequestId(ClientRequestID()).
+		Execute()
+	if err == nil {
+		return int64(*sa.Id), nil
+	}
+	// Grafana rejects service accounts with the name of an existing one
+	if resp == nil || (resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusConflict) {
+		return 0, fmt.Errorf("failed to create service account %s: %w", name, err)
+	}
+
+	client, cleanup, err := CreateTemporaryStackGrafanaClient(ctx, cloudClient, stackSlug, name+"-lookup-")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create a temporary service account to look up service account %s: %w", name, err)
+	}
+	id, err := findServiceAccount(client, name)
+	return id, errors.Join(err, cleanup())
+}
+
+// findServiceAccount returns the ID of the service account with the given name.
+func findServiceAccount(client *goapi.GrafanaHTTPAPI, name string) (int64, error) {
+	var page, seen int64 = 1, 0
+	for {
+		params := service_accounts.NewSearchOrgServiceAccountsWithPagingParams().WithQuery(&name).WithPage(&page)
+		resp, err := client.ServiceAccounts.SearchOrgServiceAccountsWithPaging(params)
+		if err != nil {
+			return 0, err
+		}
+		for _, sa := range resp.Payload.ServiceAccounts {
+			if sa.Name == name {
+				return sa.ID, nil
+			}
+		}
+		seen += int64(len(resp.Payload.ServiceAccounts))
+		if len(resp.Payload.ServiceAccounts) == 0 || seen >= resp.Payload.TotalCount {
+			return 0, fmt.Errorf("service account %s not found", name)
+		}
+		page++
+	}
+}
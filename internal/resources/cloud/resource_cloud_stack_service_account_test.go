@@ -2,16 +2,26 @@ package cloud_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	gcom "github.com/grafana/grafana-com-public-clients/go"
 	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/cloud"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccGrafanaServiceAccountFromCloud(t *testing.T) {
@@ -233,3 +243,70 @@ func testAccGrafanaAuthCheckServiceAccounts(stack *gcom.FormattedApiInstance, ex
 		return nil
 	}
 }
+
+func TestCreateStackServiceAccountToken(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	cloudClient := fakeStackCloudClient(t, "mystack", grafana.URL())
+	ctx := context.Background()
+
+	serviceAccounts := func() []*models.ServiceAccountDTO {
+		resp, err := client.ServiceAccounts.SearchOrgServiceAccountsWithPaging(service_accounts.NewSearchOrgServiceAccountsWithPagingParams())
+		require.NoError(t, err)
+		return resp.Payload.ServiceAccounts
+	}
+
+	// The first provider creates the service account
+	stackURL, token, _, err := cloud.CreateStackServiceAccountToken(ctx, cloudClient, "mystack", "terraform-connection", 1)
+	require.NoError(t, err)
+	require.Equal(t, grafana.URL(), stackURL)
+	require.NotEmpty(t, token)
+	created := serviceAccounts()
+	require.Len(t, created, 1)
+	require.Equal(t, "terraform-connection", created[0].Name)
+
+	// The next providers reuse it, and delete its expired tokens. The service account used to look it up is deleted.
+	time.Sleep(1100 * time.Millisecond)
+	_, _, cleanup, err := cloud.CreateStackServiceAccountToken(ctx, cloudClient, "mystack", "terraform-connection", 24*60*60)
+	require.NoError(t, err)
+	require.Equal(t, created, serviceAccounts())
+	tokens, err := client.ServiceAccounts.ListTokens(created[0].ID)
+	require.NoError(t, err)
+	require.Len(t, tokens.Payload, 1)
+	require.False(t, tokens.Payload[0].HasExpired)
+
+	// The service account is kept when the provider stops, only its token is deleted
+	require.NoError(t, cleanup())
+	require.Equal(t, created, serviceAccounts())
+	tokens, err = client.ServiceAccounts.ListTokens(created[0].ID)
+	require.NoError(t, err)
+	require.Empty(t, tokens.Payload)
+}
+
+// fakeStackCloudClient returns a Cloud API client for a stack served by the given Grafana server.
+// The service account requests of the Cloud API are forwarded to the Grafana API of the stack.
+func fakeStackCloudClient(t *testing.T, stackSlug, grafanaURL string) *gcom.APIClient {
+	t.Helper()
+
+	target, err := url.Parse(grafanaURL)
+	require.NoError(t, err)
+	stackPath := "/api/instances/" + stackSlug
+	proxy := &httputil.ReverseProxy{Rewrite: func(r *httputil.ProxyRequest) {
+		r.SetURL(target)
+		r.Out.URL.Path = strings.TrimPrefix(r.In.URL.Path, stackPath)
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == stackPath {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"id": 1, "slug": stackSlug, "url": grafanaURL})
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := gcom.NewConfiguration()
+	cfg.Host = strings.TrimPrefix(server.URL, "http://")
+	cfg.Scheme = "http"
+	return gcom.NewAPIClient(cfg)
+}
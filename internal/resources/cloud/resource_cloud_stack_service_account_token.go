@@ -18,6 +18,8 @@ func resourceStackServiceAccountToken() *common.Resource {
 Manages service account tokens of a Grafana Cloud stack using the Cloud API
 This can be used to bootstrap a management service account token for a new stack
 
+The token can be rotated periodically with the ` + "`rotation`" + ` block. During the overlap period of a rotation, both the new key (` + "`key`" + `) and the previous key (` + "`previous_key`" + `) are valid, so that consumers can switch to the new key without downtime.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api)
 
@@ -28,9 +30,11 @@ Required access policy scopes:
 
 		CreateContext: withClient[schema.CreateContextFunc](stackServiceAccountTokenCreate),
 		ReadContext:   withClient[schema.ReadContextFunc](stackServiceAccountTokenRead),
+		UpdateContext: withClient[schema.UpdateContextFunc](stackServiceAccountTokenUpdate),
 		DeleteContext: withClient[schema.DeleteContextFunc](stackServiceAccountTokenDelete),
+		CustomizeDiff: common.CustomizeTokenRotationDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: common.WithTokenRotation(map[string]*schema.Schema{
 			"stack_slug": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -70,7 +74,7 @@ Required access policy scopes:
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
-		},
+		}),
 	}
 
 	return common.NewLegacySDKResource(
@@ -92,24 +96,18 @@ func stackServiceAccountTokenCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	req := gcom.PostInstanceServiceAccountTokensRequest{
-		Name:          d.Get("name").(string),
-		SecondsToLive: common.Ref(int32(d.Get("seconds_to_live").(int))),
-	}
-
-	resp, _, err := cloudClient.InstancesAPI.PostInstanceServiceAccountTokens(ctx, stackSlug, strconv.FormatInt(serviceAccountID, 10)).
-		PostInstanceServiceAccountTokensRequest(req).
-		XRequestId(ClientRequestID()).
-		Execute()
+	createToken := stackServiceAccountTokenCreateFunc(ctx, cloudClient, stackSlug, serviceAccountID, d.Get("seconds_to_live").(int))
+	id, key, err := createToken(d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(*resp.Id, 10))
-	err = d.Set("key", resp.Key)
+	d.SetId(id)
+	err = d.Set("key", key)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	// Fill the true resource's state by performing a read
 	return stackServiceAccountTokenRead(ctx, d, cloudClient)
@@ -135,26 +133,62 @@ func stackServiceAccountTokenRead(ctx context.Context, d *schema.ResourceData, c
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for _, key := range response {
+	previousID := d.Get("previous_token_id").(string)
+	previousFound := false
+	var current *gcom.GrafanaTokenDTO
+	for i, key := range response {
 		if id == *key.Id {
-			d.SetId(strconv.FormatInt(*key.Id, 10))
-			err = d.Set("name", key.Name)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			if key.Expiration != nil && !key.Expiration.IsZero() {
-				err = d.Set("expiration", key.Expiration.String())
-				if err != nil {
-					return diag.FromErr(err)
-				}
-			}
-			err = d.Set("has_expired", key.HasExpired)
+			current = &response[i]
+		}
+		if previousID == strconv.FormatInt(*key.Id, 10) {
+			previousFound = true
+		}
+	}
+	if current == nil {
+		return common.WarnMissing("stack service account token", d)
+	}
 
+	d.SetId(strconv.FormatInt(*current.Id, 10))
+	err = d.Set("name", common.TokenName(d.Get("name").(string), current.GetName()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if current.Expiration != nil && !current.Expiration.IsZero() {
+		err = d.Set("expiration", current.Expiration.String())
+		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
+	if current.Created != nil && !current.Created.IsZero() {
+		d.Set("created_at", current.Created.UTC().Format(time.RFC3339))
+	}
+	if !previousFound {
+		d.Set("previous_key", "")
+		d.Set("previous_token_id", "")
+	}
+	err = d.Set("has_expired", current.HasExpired)
 
-	return common.WarnMissing("stack service account token", d)
+	return diag.FromErr(err)
+}
+
+func stackServiceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, cloudClient *gcom.APIClient) diag.Diagnostics {
+	if err := waitForStackReadinessFromSlug(ctx, 5*time.Minute, d.Get("stack_slug").(string), cloudClient); err != nil {
+		return err
+	}
+
+	stackSlug := d.Get("stack_slug").(string)
+	serviceAccountID, err := getStackServiceAccountID(d.Get("service_account_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createToken := stackServiceAccountTokenCreateFunc(ctx, cloudClient, stackSlug, serviceAccountID, d.Get("seconds_to_live").(int))
+	deleteToken := stackServiceAccountTokenDeleteFunc(ctx, cloudClient, stackSlug, serviceAccountID)
+	if err := common.RotateToken(d, createToken, deleteToken); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return stackServiceAccountTokenRead(ctx, d, cloudClient)
 }
 
 func stackServiceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, cloudClient *gcom.APIClient) diag.Diagnostics {
@@ -168,10 +202,39 @@ func stackServiceAccountTokenDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	_, err = cloudClient.InstancesAPI.DeleteInstanceServiceAccountToken(ctx, stackSlug, strconv.FormatInt(serviceAccountID, 10), d.Id()).
-		XRequestId(ClientRequestID()).
-		Execute()
-	return diag.FromErr(err)
+	deleteToken := stackServiceAccountTokenDeleteFunc(ctx, cloudClient, stackSlug, serviceAccountID)
+	if err := common.DeletePreviousToken(d, deleteToken); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(deleteToken(d.Id()))
+}
+
+func stackServiceAccountTokenCreateFunc(ctx context.Context, cloudClient *gcom.APIClient, stackSlug string, serviceAccountID int64, ttl int) common.TokenCreateFunc {
+	return func(name string) (string, string, error) {
+		req := gcom.PostInstanceServiceAccountTokensRequest{
+			Name:          name,
+			SecondsToLive: common.Ref(int32(ttl)),
+		}
+
+		resp, _, err := cloudClient.InstancesAPI.PostInstanceServiceAccountTokens(ctx, stackSlug, strconv.FormatInt(serviceAccountID, 10)).
+			PostInstanceServiceAccountTokensRequest(req).
+			XRequestId(ClientRequestID()).
+			Execute()
+		if err != nil {
+			return "", "", err
+		}
+		return strconv.FormatInt(*resp.Id, 10), resp.GetKey(), nil
+	}
+}
+
+func stackServiceAccountTokenDeleteFunc(ctx context.Context, cloudClient *gcom.APIClient, stackSlug string, serviceAccountID int64) common.TokenDeleteFunc {
+	return func(id string) error {
+		_, err := cloudClient.InstancesAPI.DeleteInstanceServiceAccountToken(ctx, stackSlug, strconv.FormatInt(serviceAccountID, 10), id).
+			XRequestId(ClientRequestID()).
+			Execute()
+		return err
+	}
 }
 
 func getStackServiceAccountID(id string) (int64, error) {
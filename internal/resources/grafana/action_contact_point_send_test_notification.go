@@ -0,0 +1,210 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionContactPointSendTestNotificationName = "grafana_contact_point_send_test_notification"
+
+func makeActionContactPointSendTestNotification() *common.Action {
+	return common.NewAction(
+		common.CategoryAlerting,
+		actionContactPointSendTestNotificationName,
+		&actionContactPointSendTestNotification{},
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
+}
+
+type actionContactPointSendTestNotificationModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	Name        types.String `tfsdk:"name"`
+	Labels      types.Map    `tfsdk:"labels"`
+	Annotations types.Map    `tfsdk:"annotations"`
+}
+
+type actionContactPointSendTestNotification struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionContactPointSendTestNotification) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionContactPointSendTestNotificationName
+}
+
+func (a *actionContactPointSendTestNotification) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Sends a test notification to every integration of a contact point, like the "Test" button of the Grafana UI.
+The integrations use the settings and secrets stored in Grafana.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/configure-notifications/manage-contact-points/#test-a-contact-point)
+`,
+		Attributes: map[string]schema.Attribute{
+			"org_id": pluginFrameworkActionOrgIDAttribute(),
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the contact point.",
+			},
+			"labels": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The labels of the test alert.",
+			},
+			"annotations": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The annotations of the test alert, ex: `summary`.",
+			},
+		},
+	}
+}
+
+// The test API accepts receivers in the format of the Alertmanager config, rather than the provisioning API format
+type testReceiversRequest struct {
+	Alert     *testReceiversAlert `json:"alert,omitempty"`
+	Receivers []testReceiver      `json:"receivers"`
+}
+
+type testReceiversAlert struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type testReceiver struct {
+	Name         string               `json:"name"`
+	Integrations []testReceiverConfig `json:"grafana_managed_receiver_configs"`
+}
+
+// redactedSetting is the value of secure settings returned by the provisioning API
+const redactedSetting = "[REDACTED]"
+
+// withoutRedactedSettings removes the redacted secure settings of an integration,
+// so that Grafana uses the secure settings stored for the integration UID instead of sending the redacted value.
+func withoutRedactedSettings(settings any) any {
+	m, ok := settings.(map[string]any)
+	if !ok {
+		return settings
+	}
+	result := make(map[string]any, len(m))
+	for k, v := range m {
+		if v != redactedSetting {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+type testReceiverConfig struct {
+	UID                   string `json:"uid"`
+	Name                  string `json:"name"`
+	Type                  string `json:"type,omitempty"`
+	DisableResolveMessage bool   `json:"disableResolveMessage"`
+	Settings              any    `json:"settings,omitempty"`
+	Status                string `json:"status,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+func (a *actionContactPointSendTestNotification) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionContactPointSendTestNotificationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, _ := strconv.ParseInt(data.OrgID.ValueString(), 10, 64)
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+
+	name := data.Name.ValueString()
+	points, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get the contact point", err.Error())
+		return
+	}
+	if len(points.Payload) == 0 {
+		resp.Diagnostics.AddError("Contact point not found", fmt.Sprintf("no contact point named %q was found", name))
+		return
+	}
+
+	receiver := testReceiver{Name: name}
+	integrationTypes := map[string]string{}
+	for _, p := range points.Payload {
+		integrationTypes[p.UID] = *p.Type
+		receiver.Integrations = append(receiver.Integrations, testReceiverConfig{
+			UID:                   p.UID,
+			Name:                  p.Name,
+			Type:                  *p.Type,
+			DisableResolveMessage: p.DisableResolveMessage,
+			Settings:              withoutRedactedSettings(p.Settings),
+		})
+	}
+	body := testReceiversRequest{Receivers: []testReceiver{receiver}}
+	if !data.Labels.IsNull() || !data.Annotations.IsNull() {
+		body.Alert = &testReceiversAlert{}
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &body.Alert.Labels, false)...)
+		resp.Diagnostics.Append(data.Annotations.ElementsAs(ctx, &body.Alert.Annotations, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var result testReceiversRequest
+	// The test API isn't part of the OpenAPI client, the request is made with the client's transport so that it has the same auth and settings
+	_, err = client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "testReceivers",
+		Method:             http.MethodPost,
+		PathPattern:        "/alertmanager/grafana/config/api/v1/receivers/test",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return r.SetBodyParam(body)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			respBody, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+			// 207 is returned when some of the integrations failed, their errors are in the body
+			if response.Code() != http.StatusOK && response.Code() != http.StatusMultiStatus {
+				return nil, fmt.Errorf("[POST /alertmanager/grafana/config/api/v1/receivers/test] testReceivers (status %d): %s", response.Code(), respBody)
+			}
+			return nil, json.Unmarshal(respBody, &result)
+		}),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to send the test notification", err.Error())
+		return
+	}
+
+	// The results only have the UIDs of the integrations, not their types
+	for _, r := range result.Receivers {
+		for _, integration := range r.Integrations {
+			integration.Type = integrationTypes[integration.UID]
+			if integration.Status == "failed" {
+				resp.Diagnostics.AddError(
+					"Failed to send the test notification",
+					fmt.Sprintf("the %s integration of contact point %q failed: %s", integration.Type, name, integration.Error),
+				)
+				continue
+			}
+			resp.SendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Sent a test notification with the %s integration of contact point %q", integration.Type, name),
+			})
+		}
+	}
+}
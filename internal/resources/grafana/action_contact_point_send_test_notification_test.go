@@ -0,0 +1,56 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeActionContactPointSendTestNotification(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	integrationType := "email"
+	_, err := client.Provisioning.PostContactpoints(provisioning.NewPostContactpointsParams().WithBody(&models.EmbeddedContactPoint{
+		Name:     "My Contact Point",
+		Type:     &integrationType,
+		Settings: map[string]any{"addresses": "one@company.org"},
+	}))
+	require.NoError(t, err)
+
+	server := fake.ProviderServer(t, grafana)
+	messages, diags := testutils.InvokeAction(t, server, "grafana_contact_point_send_test_notification", map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "My Contact Point"),
+		"annotations": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"summary": tftypes.NewValue(tftypes.String, "Test"),
+		}),
+	})
+	require.Empty(t, diags)
+	require.Equal(t, []string{`Sent a test notification with the email integration of contact point "My Contact Point"`}, messages)
+
+	// The secure settings are redacted by the provisioning API, Grafana must use the stored ones
+	slackType := "slack"
+	_, err = client.Provisioning.PostContactpoints(provisioning.NewPostContactpointsParams().WithBody(&models.EmbeddedContactPoint{
+		Name:     "Slack Contact Point",
+		Type:     &slackType,
+		Settings: map[string]any{"recipient": "#alerts", "token": "secret-token"},
+	}))
+	require.NoError(t, err)
+	messages, diags = testutils.InvokeAction(t, server, "grafana_contact_point_send_test_notification", map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Slack Contact Point"),
+	})
+	require.Empty(t, diags)
+	require.Equal(t, []string{`Sent a test notification with the slack integration of contact point "Slack Contact Point"`}, messages)
+
+	// Unknown contact point
+	_, diags = testutils.InvokeAction(t, server, "grafana_contact_point_send_test_notification", map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Other Contact Point"),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, "Contact point not found", diags[0].Summary)
+}
@@ -0,0 +1,89 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionDashboardVersionRestoreName = "grafana_dashboard_version_restore"
+
+func makeActionDashboardVersionRestore() *common.Action {
+	return common.NewAction(
+		common.CategoryGrafanaOSS,
+		actionDashboardVersionRestoreName,
+		&actionDashboardVersionRestore{},
+	)
+}
+
+type actionDashboardVersionRestoreModel struct {
+	OrgID        types.String `tfsdk:"org_id"`
+	DashboardUID types.String `tfsdk:"dashboard_uid"`
+	Version      types.Int64  `tfsdk:"version"`
+}
+
+type actionDashboardVersionRestore struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionDashboardVersionRestore) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionDashboardVersionRestoreName
+}
+
+func (a *actionDashboardVersionRestore) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Restores a dashboard to one of its previous versions, ex: to roll back a bad change during an incident. The restored model is saved as a new version.
+The versions of a dashboard can be listed with the ` + "`grafana_dashboard_versions`" + ` data source.
+
+If the dashboard is managed by a ` + "`grafana_dashboard`" + ` resource, its ` + "`config_json`" + ` will show a difference, and the next apply will overwrite the restored version, until the configuration is fixed.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/#restore-dashboard)
+`,
+		Attributes: map[string]schema.Attribute{
+			"org_id": pluginFrameworkActionOrgIDAttribute(),
+			"dashboard_uid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UID of the dashboard.",
+			},
+			"version": schema.Int64Attribute{
+				Required:    true,
+				Description: "The version to restore.",
+			},
+		},
+	}
+}
+
+func (a *actionDashboardVersionRestore) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionDashboardVersionRestoreModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, _ := strconv.ParseInt(data.OrgID.ValueString(), 10, 64)
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+
+	uid, version := data.DashboardUID.ValueString(), data.Version.ValueInt64()
+	restored, err := client.DashboardVersions.RestoreDashboardVersionByUID(uid, &models.RestoreDashboardVersionCommand{Version: version})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to restore the dashboard version", err.Error())
+		return
+	}
+
+	message := fmt.Sprintf("Restored dashboard %q to version %d", uid, version)
+	if newVersion := restored.Payload.Version; newVersion != nil {
+		message += fmt.Sprintf(", saved as version %d", *newVersion)
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
@@ -0,0 +1,46 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeActionDashboardVersionRestore(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	for _, title := range []string{"Good", "Bad"} {
+		_, err := client.Dashboards.PostDashboard(&models.SaveDashboardCommand{
+			Dashboard: map[string]any{"uid": "rollback", "title": title},
+			Overwrite: true,
+		})
+		require.NoError(t, err)
+	}
+
+	server := fake.ProviderServer(t, grafana)
+	messages, diags := testutils.InvokeAction(t, server, "grafana_dashboard_version_restore", map[string]tftypes.Value{
+		"dashboard_uid": tftypes.NewValue(tftypes.String, "rollback"),
+		"version":       tftypes.NewValue(tftypes.Number, 1),
+	})
+	require.Empty(t, diags)
+	require.Equal(t, []string{`Restored dashboard "rollback" to version 1, saved as version 3`}, messages)
+
+	dashboard, err := client.Dashboards.GetDashboardByUID("rollback")
+	require.NoError(t, err)
+	model := dashboard.Payload.Dashboard.(map[string]any)
+	require.Equal(t, "Good", model["title"])
+	require.EqualValues(t, 3, model["version"])
+
+	// Unknown version
+	_, diags = testutils.InvokeAction(t, server, "grafana_dashboard_version_restore", map[string]tftypes.Value{
+		"dashboard_uid": tftypes.NewValue(tftypes.String, "rollback"),
+		"version":       tftypes.NewValue(tftypes.Number, 10),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, "Failed to restore the dashboard version", diags[0].Summary)
+}
@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionReportSendName = "grafana_report_send"
+
+func makeActionReportSend() *common.Action {
+	return common.NewAction(
+		common.CategoryGrafanaEnterprise,
+		actionReportSendName,
+		&actionReportSend{},
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
+}
+
+type actionReportSendModel struct {
+	ReportID types.String `tfsdk:"report_id"`
+	Emails   types.List   `tfsdk:"emails"`
+}
+
+type actionReportSend struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionReportSend) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionReportSendName
+}
+
+func (a *actionReportSend) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Sends a report now, rather than waiting for its schedule.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/create-reports/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/reporting/#send-a-report)
+`,
+		Attributes: map[string]schema.Attribute{
+			"report_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the report, ex: the `id` attribute of a `grafana_report` resource.",
+			},
+			"emails": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The emails to send the report to. If not set, the report is sent to its recipients.",
+			},
+		},
+	}
+}
+
+func (a *actionReportSend) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionReportSendModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, reportID := SplitOrgResourceID(data.ReportID.ValueString())
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+
+	var emails []string
+	resp.Diagnostics.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := &models.ReportEmail{
+		ID:                  reportID,
+		Emails:              strings.Join(emails, ","),
+		UseEmailsFromReport: len(emails) == 0,
+	}
+	if _, err := client.Reports.SendReport(body); err != nil {
+		resp.Diagnostics.AddError("Failed to send the report", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sent report %s", reportID),
+	})
+}
@@ -0,0 +1,121 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionRuleGroupPauseName = "grafana_rule_group_pause"
+
+func makeActionRuleGroupPause() *common.Action {
+	return common.NewAction(
+		common.CategoryAlerting,
+		actionRuleGroupPauseName,
+		&actionRuleGroupPause{},
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
+}
+
+type actionRuleGroupPauseModel struct {
+	OrgID     types.String `tfsdk:"org_id"`
+	FolderUID types.String `tfsdk:"folder_uid"`
+	Name      types.String `tfsdk:"name"`
+	Paused    types.Bool   `tfsdk:"paused"`
+}
+
+type actionRuleGroupPause struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionRuleGroupPause) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionRuleGroupPauseName
+}
+
+func (a *actionRuleGroupPause) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Pauses or resumes the evaluation of all the alert rules of a rule group, ex: during a maintenance window.
+
+If the rule group is managed by a ` + "`grafana_rule_group`" + ` resource, its ` + "`is_paused`" + ` attributes will show a difference until the action is run again or the resource is applied.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+`,
+		Attributes: map[string]schema.Attribute{
+			"org_id": pluginFrameworkActionOrgIDAttribute(),
+			"folder_uid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UID of the folder of the rule group.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the rule group.",
+			},
+			"paused": schema.BoolAttribute{
+				Required:    true,
+				Description: "Set to `true` to pause the alert rules, or to `false` to resume them.",
+			},
+		},
+	}
+}
+
+func (a *actionRuleGroupPause) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionRuleGroupPauseModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, _ := strconv.ParseInt(data.OrgID.ValueString(), 10, 64)
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+
+	folderUID, name, paused := data.FolderUID.ValueString(), data.Name.ValueString(), data.Paused.ValueBool()
+	group, err := client.Provisioning.GetAlertRuleGroup(name, folderUID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get the rule group", err.Error())
+		return
+	}
+
+	// The provenance of the rules must be kept, or they wouldn't be editable in the UI anymore
+	disableProvenance := true
+	for _, r := range group.Payload.Rules {
+		rule, err := client.Provisioning.GetAlertRule(r.UID) // We need to get the rule through a separate API call to get the provenance.
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get the alert rule", err.Error())
+			return
+		}
+		if rule.Payload.Provenance != "" {
+			disableProvenance = false
+		}
+		r.IsPaused = paused
+	}
+
+	params := provisioning.NewPutAlertRuleGroupParams().
+		WithFolderUID(folderUID).
+		WithGroup(name).
+		WithBody(group.Payload)
+	if disableProvenance {
+		params.SetXDisableProvenance(&provenanceDisabled)
+	}
+	if _, err := client.Provisioning.PutAlertRuleGroup(params); err != nil {
+		resp.Diagnostics.AddError("Failed to update the rule group", err.Error())
+		return
+	}
+
+	verb := "Resumed"
+	if paused {
+		verb = "Paused"
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("%s the %d alert rules of rule group %q", verb, len(group.Payload.Rules), name),
+	})
+}
@@ -0,0 +1,55 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeActionRuleGroupPause(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	_, err := client.Folders.CreateFolder(&models.CreateFolderCommand{UID: "alerts", Title: "Alerts"})
+	require.NoError(t, err)
+	title := "My Rule"
+	_, err = client.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+		WithFolderUID("alerts").
+		WithGroup("my-group").
+		WithBody(&models.AlertRuleGroup{
+			Title:    "my-group",
+			Interval: 60,
+			Rules:    []*models.ProvisionedAlertRule{{Title: &title}},
+		}))
+	require.NoError(t, err)
+
+	server := fake.ProviderServer(t, grafana)
+	for _, paused := range []bool{true, false} {
+		messages, diags := testutils.InvokeAction(t, server, "grafana_rule_group_pause", map[string]tftypes.Value{
+			"folder_uid": tftypes.NewValue(tftypes.String, "alerts"),
+			"name":       tftypes.NewValue(tftypes.String, "my-group"),
+			"paused":     tftypes.NewValue(tftypes.Bool, paused),
+		})
+		require.Empty(t, diags)
+		require.Len(t, messages, 1)
+
+		group, err := client.Provisioning.GetAlertRuleGroup("my-group", "alerts")
+		require.NoError(t, err)
+		require.Len(t, group.Payload.Rules, 1)
+		require.Equal(t, paused, group.Payload.Rules[0].IsPaused)
+	}
+
+	// Unknown rule group
+	_, diags := testutils.InvokeAction(t, server, "grafana_rule_group_pause", map[string]tftypes.Value{
+		"folder_uid": tftypes.NewValue(tftypes.String, "alerts"),
+		"name":       tftypes.NewValue(tftypes.String, "other-group"),
+		"paused":     tftypes.NewValue(tftypes.Bool, true),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, "Failed to get the rule group", diags[0].Summary)
+}
@@ -0,0 +1,95 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionServiceAccountTokenRevokeName = "grafana_service_account_token_revoke"
+
+func makeActionServiceAccountTokenRevoke() *common.Action {
+	return common.NewAction(
+		common.CategoryGrafanaOSS,
+		actionServiceAccountTokenRevokeName,
+		&actionServiceAccountTokenRevoke{},
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
+}
+
+type actionServiceAccountTokenRevokeModel struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	TokenID          types.String `tfsdk:"token_id"`
+}
+
+type actionServiceAccountTokenRevoke struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionServiceAccountTokenRevoke) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionServiceAccountTokenRevokeName
+}
+
+func (a *actionServiceAccountTokenRevoke) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Revokes a service account token, ex: a leaked token managed by a ` + "`grafana_service_account_token`" + ` resource.
+
+The token is deleted immediately. Actions can't change the state of resources, so the token isn't replaced by this action:
+the next apply detects that the ` + "`grafana_service_account_token`" + ` resource is missing and creates it again with a new key.
+To rotate tokens without downtime, use the ` + "`rotation`" + ` block of the ` + "`grafana_service_account_token`" + ` resource instead,
+or the ` + "`grafana_service_account_token_rotate`" + ` action for tokens that aren't managed by Terraform.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#delete-service-account-tokens)
+`,
+		Attributes: map[string]schema.Attribute{
+			"service_account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service account to which the token belongs.",
+			},
+			"token_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the token to revoke, ex: the `id` attribute of a `grafana_service_account_token` resource.",
+			},
+		},
+	}
+}
+
+func (a *actionServiceAccountTokenRevoke) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionServiceAccountTokenRevokeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, serviceAccountIDStr := SplitOrgResourceID(data.ServiceAccountID.ValueString())
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid service account ID", err.Error())
+		return
+	}
+	tokenID, err := strconv.ParseInt(data.TokenID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid token ID", err.Error())
+		return
+	}
+
+	if _, err := client.ServiceAccounts.DeleteToken(tokenID, serviceAccountID); err != nil {
+		resp.Diagnostics.AddError("Failed to revoke the service account token", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Revoked token %d of service account %d, the next apply creates a new one", tokenID, serviceAccountID),
+	})
+}
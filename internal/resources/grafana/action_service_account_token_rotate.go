@@ -0,0 +1,137 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionServiceAccountTokenRotateName = "grafana_service_account_token_rotate"
+
+func makeActionServiceAccountTokenRotate() *common.Action {
+	return common.NewAction(
+		common.CategoryGrafanaOSS,
+		actionServiceAccountTokenRotateName,
+		&actionServiceAccountTokenRotate{},
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
+}
+
+type actionServiceAccountTokenRotateModel struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	TokenID          types.String `tfsdk:"token_id"`
+	Name             types.String `tfsdk:"name"`
+	SecondsToLive    types.Int64  `tfsdk:"seconds_to_live"`
+	KeyFile          types.String `tfsdk:"key_file"`
+}
+
+type actionServiceAccountTokenRotate struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionServiceAccountTokenRotate) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionServiceAccountTokenRotateName
+}
+
+func (a *actionServiceAccountTokenRotate) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Rotates a service account token that isn't managed by Terraform, ex: a token used by a script or a CI job.
+
+A new token is created, its key is written to ` + "`key_file`" + `, and the previous token is revoked once the file is written.
+If the file can't be written, the new token is deleted and the previous token is kept.
+Actions can't return values or change the state of resources, so the key is only available in this file.
+The file holds a live secret: keep it out of version control, and delete it once the key is stored elsewhere, ex: in the secrets of the CI.
+To rotate tokens managed by a ` + "`grafana_service_account_token`" + ` resource, use its ` + "`rotation`" + ` block instead.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#create-service-account-tokens)
+`,
+		Attributes: map[string]schema.Attribute{
+			"service_account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service account to which the token belongs.",
+			},
+			"token_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the token to revoke once the new token is created.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the new token. It must be different from the name of the previous token, which still exists when the new token is created.",
+			},
+			"seconds_to_live": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of seconds before the new token expires. If not set, the token doesn't expire.",
+			},
+			"key_file": schema.StringAttribute{
+				Required:    true,
+				Description: "The path of the file to write the key of the new token to. The file holds a live secret, which grants the permissions of the service account until the token expires or is revoked. It is overwritten if it exists, keeping its permissions, and created readable by its owner only otherwise.",
+			},
+		},
+	}
+}
+
+func (a *actionServiceAccountTokenRotate) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionServiceAccountTokenRotateModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, serviceAccountIDStr := SplitOrgResourceID(data.ServiceAccountID.ValueString())
+	client, err := a.clientFromOrgID(orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid service account ID", err.Error())
+		return
+	}
+	tokenID, err := strconv.ParseInt(data.TokenID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid token ID", err.Error())
+		return
+	}
+
+	params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&models.AddServiceAccountTokenCommand{
+		Name:          data.Name.ValueString(),
+		SecondsToLive: data.SecondsToLive.ValueInt64(),
+	})
+	created, err := client.ServiceAccounts.CreateToken(params)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create the new service account token", err.Error())
+		return
+	}
+	newToken := created.Payload
+
+	// The previous token is only revoked once the new key is safely stored
+	if err := os.WriteFile(data.KeyFile.ValueString(), []byte(newToken.Key), 0o600); err != nil {
+		resp.Diagnostics.AddError("Failed to write the key of the new service account token", err.Error())
+		if _, err := client.ServiceAccounts.DeleteToken(newToken.ID, serviceAccountID); err != nil {
+			resp.Diagnostics.AddError("Failed to delete the new service account token", fmt.Sprintf("token %d must be deleted manually: %s", newToken.ID, err))
+		}
+		return
+	}
+
+	if _, err := client.ServiceAccounts.DeleteToken(tokenID, serviceAccountID); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to revoke the previous service account token",
+			fmt.Sprintf("the new token %d was created and its key written to %s: %s", newToken.ID, data.KeyFile.ValueString(), err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Replaced token %d of service account %d with token %d, whose key was written to %s", tokenID, serviceAccountID, newToken.ID, data.KeyFile.ValueString()),
+	})
+}
@@ -0,0 +1,65 @@
+package grafana_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeActionServiceAccountTokenRotate(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	previous, err := client.ServiceAccounts.CreateToken(service_accounts.NewCreateTokenParams().
+		WithServiceAccountID(1).
+		WithBody(&models.AddServiceAccountTokenCommand{Name: "ci"}))
+	require.NoError(t, err)
+
+	server := fake.ProviderServer(t, grafana)
+	keyFile := filepath.Join(t.TempDir(), "ci-token.key")
+	messages, diags := testutils.InvokeAction(t, server, "grafana_service_account_token_rotate", map[string]tftypes.Value{
+		"service_account_id": tftypes.NewValue(tftypes.String, "1"),
+		"token_id":           tftypes.NewValue(tftypes.String, strconv.FormatInt(previous.Payload.ID, 10)),
+		"name":               tftypes.NewValue(tftypes.String, "ci-rotated"),
+		"key_file":           tftypes.NewValue(tftypes.String, keyFile),
+	})
+	require.Empty(t, diags)
+	require.Len(t, messages, 1)
+
+	key, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.Regexp(t, "^glsa_", string(key))
+	require.NotEqual(t, previous.Payload.Key, string(key))
+	// The key is a live secret, only readable by its owner
+	info, err := os.Stat(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	tokens, err := client.ServiceAccounts.ListTokens(1)
+	require.NoError(t, err)
+	require.Len(t, tokens.Payload, 1)
+	require.Equal(t, "ci-rotated", tokens.Payload[0].Name)
+
+	// The previous token is kept if the key can't be written
+	_, diags = testutils.InvokeAction(t, server, "grafana_service_account_token_rotate", map[string]tftypes.Value{
+		"service_account_id": tftypes.NewValue(tftypes.String, "1"),
+		"token_id":           tftypes.NewValue(tftypes.String, strconv.FormatInt(tokens.Payload[0].ID, 10)),
+		"name":               tftypes.NewValue(tftypes.String, "ci-rotated-again"),
+		"key_file":           tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing", "ci-token.key")),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, "Failed to write the key of the new service account token", diags[0].Summary)
+
+	tokens, err = client.ServiceAccounts.ListTokens(1)
+	require.NoError(t, err)
+	require.Len(t, tokens.Payload, 1)
+	require.Equal(t, "ci-rotated", tokens.Payload[0].Name)
+}
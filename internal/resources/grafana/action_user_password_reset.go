@@ -0,0 +1,88 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionUserPasswordResetName = "grafana_user_password_reset"
+
+func makeActionUserPasswordReset() *common.Action {
+	return common.NewAction(
+		common.CategoryGrafanaOSS,
+		actionUserPasswordResetName,
+		&actionUserPasswordReset{},
+	)
+}
+
+type actionUserPasswordResetModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	Password types.String `tfsdk:"password"`
+}
+
+type actionUserPasswordReset struct {
+	basePluginFrameworkAction
+}
+
+func (a *actionUserPasswordReset) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionUserPasswordResetName
+}
+
+func (a *actionUserPasswordReset) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Resets the password of a user.
+
+This action uses Grafana's admin APIs. It does not work with API tokens or service accounts which are org-scoped. You must use basic auth.
+Action attributes can't be marked as sensitive: set the password from a sensitive variable or an ephemeral value so that it isn't shown.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/admin/#change-password)
+`,
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The numerical ID of the Grafana user.",
+			},
+			"password": schema.StringAttribute{
+				Required:    true,
+				Description: "The new password of the Grafana user.",
+			},
+		},
+	}
+}
+
+func (a *actionUserPasswordReset) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionUserPasswordResetModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := a.clientFromOrgID(0)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create client", err.Error())
+		return
+	}
+	userID, err := strconv.ParseInt(data.UserID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid user ID", err.Error())
+		return
+	}
+
+	form := models.AdminUpdateUserPasswordForm{Password: models.Password(data.Password.ValueString())}
+	if _, err := client.AdminUsers.AdminUpdateUserPassword(userID, &form); err != nil {
+		resp.Diagnostics.AddError("Failed to reset the password", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Reset the password of user %d", userID),
+	})
+}
@@ -0,0 +1,67 @@
+package grafana
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var (
+	// AnnotateChanges is set by the `annotate_changes` attribute of the provider.
+	// When true, an annotation is created each time a dashboard, rule group or notification policy is changed.
+	AnnotateChanges bool
+	// ChangeMessage is set by the `change_message` attribute of the provider. It is the text of change annotations and the version message of dashboards.
+	ChangeMessage string
+)
+
+// ciCommitEnvVars are the environment variables holding the commit SHA in common CI systems.
+// They are the default change message when `change_message` isn't set.
+var ciCommitEnvVars = []string{
+	"GITHUB_SHA",          // GitHub Actions
+	"CI_COMMIT_SHA",       // GitLab CI
+	"BUILD_SOURCEVERSION", // Azure Pipelines
+	"CIRCLE_SHA1",         // CircleCI
+	"GIT_COMMIT",          // Jenkins
+}
+
+// DefaultChangeMessage returns the commit SHA of the CI run, if any.
+func DefaultChangeMessage() string {
+	for _, envVar := range ciCommitEnvVars {
+		if sha := os.Getenv(envVar); sha != "" {
+			return "Commit " + sha
+		}
+	}
+	return ""
+}
+
+// annotateChange creates an annotation for a change made by Terraform, when `annotate_changes` is enabled.
+// The annotation is on the dashboard if dashboardUID is set, otherwise it's an organization annotation. It's tagged with `terraform`, the resource type and the given tags.
+// Failing to create it doesn't fail the apply, since the change was already made, so a warning is returned instead.
+func annotateChange(client *goapi.GrafanaHTTPAPI, resourceType, dashboardUID, summary string, tags ...string) diag.Diagnostics {
+	if !AnnotateChanges {
+		return nil
+	}
+
+	text := "Terraform: " + summary
+	if ChangeMessage != "" {
+		text += "\n\n" + ChangeMessage
+	}
+	_, err := client.Annotations.PostAnnotation(&models.PostAnnotationsCmd{
+		DashboardUID: dashboardUID,
+		Text:         &text,
+		Tags:         append([]string{"terraform", resourceType}, tags...),
+		Time:         time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to annotate the change of %s", resourceType),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
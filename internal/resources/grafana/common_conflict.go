@@ -0,0 +1,79 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	conflictPolicyFail      = "fail"
+	conflictPolicyOverwrite = "overwrite"
+	conflictPolicyWarn      = "warn"
+
+	// maxConflictChanges is the maximum number of remote changes listed in a conflict diagnostic
+	maxConflictChanges = 20
+)
+
+var conflictPolicies = []string{conflictPolicyFail, conflictPolicyOverwrite, conflictPolicyWarn}
+
+// conflictPolicyAttribute returns the schema of the `conflict_policy` attribute.
+// The default policy is the one that matches how the resource behaved before the attribute was introduced.
+func conflictPolicyAttribute(defaultPolicy string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: common.AllowedValuesDescription(
+			"What to do when the resource was modified outside of Terraform (for example, in the Grafana UI) since it was last read. "+
+				"`fail` stops the apply with a summary of the remote changes, `warn` reports them and overwrites them, `overwrite` silently replaces them. "+
+				fmt.Sprintf("If not set, `%s` is used", defaultPolicy),
+			conflictPolicies,
+		),
+		ValidateFunc: validation.StringInSlice(conflictPolicies, false),
+	}
+}
+
+// getConflictPolicy returns the configured conflict policy, or the given default if it isn't set.
+func getConflictPolicy(d *schema.ResourceData, defaultPolicy string) string {
+	if policy := d.Get("conflict_policy").(string); policy != "" {
+		return policy
+	}
+	return defaultPolicy
+}
+
+// conflictDiagnostics builds the diagnostic returned when a resource was modified outside of Terraform.
+// With the `fail` policy, an error is returned. With the `warn` policy, a warning is returned. Otherwise, nothing is returned.
+// `remoteChanges` is the list of changes made remotely, as returned by common.JSONChanges. It can be nil if the changes are unknown.
+func conflictDiagnostics(policy, resourceType, id, versionSummary, attribute string, remoteChanges []string) diag.Diagnostics {
+	severity := diag.Error
+	switch policy {
+	case conflictPolicyWarn:
+		severity = diag.Warning
+	case conflictPolicyFail:
+	default:
+		return nil
+	}
+
+	detail := "The following changes were made outside of Terraform since the resource was last read:\n"
+	if remoteChanges == nil {
+		detail = "The remote changes could not be summarized.\n"
+	} else {
+		detail += common.SummarizeJSONChanges(remoteChanges, maxConflictChanges)
+	}
+	if severity == diag.Error {
+		detail += "\nRun `terraform apply -refresh-only` to review them, then update the configuration to keep them or set `conflict_policy = \"overwrite\"` to discard them."
+	} else {
+		detail += "\nThese changes were overwritten by Terraform."
+	}
+
+	return diag.Diagnostics{{
+		Severity:      severity,
+		Summary:       fmt.Sprintf("%s %q was modified outside of Terraform (%s)", resourceType, id, versionSummary),
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath(attribute),
+	}}
+}
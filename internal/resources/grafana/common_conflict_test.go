@@ -0,0 +1,53 @@
+package grafana_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/require"
+)
+
+// conflictPolicyTestCases are the cases of the conflict policy tests of dashboards, library panels and rule groups.
+// The resource is read, modified outside of Terraform (or not), then updated with the given policy.
+var conflictPolicyTestCases = []struct {
+	policy       string
+	editRemotely bool
+	// expectedSeverity is the severity of the conflict diagnostic, or DiagnosticSeverityInvalid if there must be none
+	expectedSeverity tfprotov5.DiagnosticSeverity
+	// expectOverwrite is true if the remote resource must match the configuration after the apply, false if it must keep the remote changes
+	expectOverwrite bool
+}{
+	{policy: "fail", editRemotely: true, expectedSeverity: tfprotov5.DiagnosticSeverityError, expectOverwrite: false},
+	{policy: "fail", editRemotely: false, expectedSeverity: tfprotov5.DiagnosticSeverityInvalid, expectOverwrite: true},
+	{policy: "warn", editRemotely: true, expectedSeverity: tfprotov5.DiagnosticSeverityWarning, expectOverwrite: true},
+	{policy: "overwrite", editRemotely: true, expectedSeverity: tfprotov5.DiagnosticSeverityInvalid, expectOverwrite: true},
+}
+
+func conflictPolicyTestName(policy string, editRemotely bool) string {
+	return fmt.Sprintf("policy=%s,editRemotely=%t", policy, editRemotely)
+}
+
+// checkConflictDiagnostics checks the conflict diagnostic returned by an apply, and that the apply didn't fail otherwise.
+// The detail of the diagnostic must list the given remote change.
+func checkConflictDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic, expectedSeverity tfprotov5.DiagnosticSeverity, expectedChange string) {
+	t.Helper()
+
+	var conflicts []*tfprotov5.Diagnostic
+	for _, d := range diags {
+		if strings.Contains(d.Summary, "was modified outside of Terraform") {
+			conflicts = append(conflicts, d)
+		} else {
+			require.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, "unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if expectedSeverity == tfprotov5.DiagnosticSeverityInvalid {
+		require.Empty(t, conflicts)
+		return
+	}
+	require.Len(t, conflicts, 1)
+	require.Equal(t, expectedSeverity, conflicts[0].Severity)
+	require.Contains(t, conflicts[0].Detail, expectedChange)
+}
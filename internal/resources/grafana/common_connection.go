@@ -0,0 +1,171 @@
+package grafana
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	frameworkValidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// connectionAttribute is the `grafana_connection` block of Grafana resources and data sources.
+// Provider blocks can't depend on resources created in the same apply, so this allows, for example,
+// creating a `grafana_cloud_stack` and managing resources in it without a second provider block and apply.
+func connectionAttribute() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The Grafana instance to manage the resource in, instead of the one of the provider. Either `url` and `auth`, or `stack_slug`, must be set.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Description:  "The root URL of the Grafana instance, ex: the `url` attribute of a `grafana_cloud_stack` resource.",
+					RequiredWith: []string{"grafana_connection.0.auth"},
+				},
+				"auth": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					Description:  "API token, basic auth in the `username:password` format or `anonymous` (string literal). Ex: the `key` attribute of a `grafana_cloud_stack_service_account_token` resource.",
+					RequiredWith: []string{"grafana_connection.0.url"},
+				},
+				"stack_slug": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ForceNew:      true,
+					Description:   "The slug of a Grafana Cloud stack. A token of the `terraform-connection` admin service account of the stack is created with the `cloud_access_policy_token` of the provider, and deleted when the provider stops. The service account is created if it doesn't exist, and reused by later providers. Tokens expire after 24 hours: if the provider is killed before deleting its token, the token is deleted by a later provider connecting to the stack.",
+					ConflictsWith: []string{"grafana_connection.0.url", "grafana_connection.0.auth"},
+					AtLeastOneOf:  []string{"grafana_connection.0.url", "grafana_connection.0.stack_slug"},
+				},
+			},
+		},
+	}
+}
+
+// withConnection wraps a CRUD function so that it's called with the client of the `grafana_connection` block, when it's set.
+func withConnection(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		connection, ok := connectionConfig(d)
+		if !ok {
+			return f(ctx, d, meta)
+		}
+
+		client, err := meta.(*common.Client).GrafanaConnection(ctx, connection)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, client)
+	}
+}
+
+// connectionImportPrefix prefixes the import IDs of resources managed in a Grafana Cloud stack with a `grafana_connection` block,
+// ex: `stack_slug=mystack/my-folder-uid`. The configuration of the resource isn't available on import, so the stack must be part of the ID.
+// Connections with an `url` can't be imported, since their `auth` can't be given in the ID.
+const connectionImportPrefix = "stack_slug="
+
+// withConnectionImporter wraps an importer so that the resource is imported with the client of the stack given in the ID, if any.
+// The connection is also set in the state, so that the read following the import uses it.
+func withConnectionImporter(f schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		connections, id := splitConnectionImportID(d.Id())
+		if len(connections) == 0 {
+			return f(ctx, d, meta)
+		}
+
+		stackSlug := connections[0].StackSlug.ValueString()
+		connection := common.GrafanaConnectionConfig{StackSlug: stackSlug}
+		client, err := meta.(*common.Client).GrafanaConnection(ctx, connection)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		if err := d.Set("grafana_connection", []interface{}{map[string]interface{}{"stack_slug": stackSlug}}); err != nil {
+			return nil, err
+		}
+		return f(ctx, d, client)
+	}
+}
+
+// connectionConfig returns the `grafana_connection` block of a resource, from its data or its diff.
+func connectionConfig(d interface{ Get(string) interface{} }) (common.GrafanaConnectionConfig, bool) {
+	list, ok := d.Get("grafana_connection").([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return common.GrafanaConnectionConfig{}, false
+	}
+	connection := list[0].(map[string]interface{})
+	return common.GrafanaConnectionConfig{
+		URL:       connection["url"].(string),
+		Auth:      connection["auth"].(string),
+		StackSlug: connection["stack_slug"].(string),
+	}, true
+}
+
+// connectionModel is the `grafana_connection` block of plugin framework resources.
+type connectionModel struct {
+	URL       types.String `tfsdk:"url"`
+	Auth      types.String `tfsdk:"auth"`
+	StackSlug types.String `tfsdk:"stack_slug"`
+}
+
+// frameworkConnectionBlock is the `grafana_connection` block of plugin framework resources, see connectionAttribute.
+// Resources that can't be updated are recreated when the credentials change.
+func frameworkConnectionBlock(updatable bool) frameworkSchema.ListNestedBlock {
+	connection := connectionAttribute()
+	fields := connection.Elem.(*schema.Resource).Schema
+	authPlanModifiers := []planmodifier.String{}
+	if !updatable {
+		authPlanModifiers = append(authPlanModifiers, stringplanmodifier.RequiresReplace())
+	}
+	return frameworkSchema.ListNestedBlock{
+		Description: connection.Description,
+		Validators:  []frameworkValidator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: frameworkSchema.NestedBlockObject{
+			Attributes: map[string]frameworkSchema.Attribute{
+				"url": frameworkSchema.StringAttribute{
+					Optional:      true,
+					Description:   fields["url"].Description,
+					Validators:    []frameworkValidator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("auth"))},
+					PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				},
+				"auth": frameworkSchema.StringAttribute{
+					Optional:      true,
+					Sensitive:     true,
+					Description:   fields["auth"].Description,
+					Validators:    []frameworkValidator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("url"))},
+					PlanModifiers: authPlanModifiers,
+				},
+				"stack_slug": frameworkSchema.StringAttribute{
+					Optional:    true,
+					Description: fields["stack_slug"].Description,
+					Validators: []frameworkValidator.String{
+						stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("url"), path.MatchRelative().AtParent().AtName("auth")),
+						stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("url")),
+					},
+					PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				},
+			},
+		},
+	}
+}
+
+// splitConnectionImportID returns the connection and the ID of the resource from an import ID, see connectionImportPrefix.
+func splitConnectionImportID(importID string) ([]connectionModel, string) {
+	stackSlug, id, ok := strings.Cut(strings.TrimPrefix(importID, connectionImportPrefix), "/")
+	if !strings.HasPrefix(importID, connectionImportPrefix) || !ok || stackSlug == "" {
+		return nil, importID
+	}
+	return []connectionModel{{URL: types.StringNull(), Auth: types.StringNull(), StackSlug: types.StringValue(stackSlug)}}, id
+}
@@ -0,0 +1,260 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+// resolveDatasourcesAttribute returns the schema of the `resolve_datasources` attribute.
+// `references` describes where the data sources are referenced in the resource.
+func resolveDatasourcesAttribute(references string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Set to true to resolve the data sources referenced in " + references + " by name, or by type when their UID doesn't exist in this Grafana instance " +
+			"(for example, when copied from another instance). They are replaced by the UIDs of the matching data sources when saving, and the configured references are kept in the state. " +
+			"The plan fails if a data source can't be found.",
+	}
+}
+
+// datasourceRefs resolves data source references with the data sources of a Grafana instance.
+type datasourceRefs struct {
+	byUID  map[string]*models.DataSourceListItemDTO
+	byName map[string]*models.DataSourceListItemDTO
+	byType map[string][]*models.DataSourceListItemDTO
+}
+
+func newDatasourceRefs(client *goapi.GrafanaHTTPAPI) (*datasourceRefs, error) {
+	resp, err := client.Datasources.GetDataSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the data sources to resolve references: %w", err)
+	}
+	refs := &datasourceRefs{
+		byUID:  map[string]*models.DataSourceListItemDTO{},
+		byName: map[string]*models.DataSourceListItemDTO{},
+		byType: map[string][]*models.DataSourceListItemDTO{},
+	}
+	for _, ds := range resp.Payload {
+		refs.byUID[ds.UID] = ds
+		refs.byName[ds.Name] = ds
+		refs.byType[ds.Type] = append(refs.byType[ds.Type], ds)
+	}
+	return refs, nil
+}
+
+// isBuiltinDatasourceRef returns true for references that aren't data sources of the instance:
+// variables (`${ds}`), built-in data sources (`-- Grafana --`, `-- Mixed --`, `-- Dashboard --`) and expressions (`__expr__`, or `-100` in rules).
+func isBuiltinDatasourceRef(uidOrName, dsType string) bool {
+	return uidOrName == "" ||
+		strings.HasPrefix(uidOrName, "$") ||
+		strings.HasPrefix(uidOrName, "-- ") ||
+		uidOrName == "grafana" || uidOrName == "__expr__" || uidOrName == "-100" ||
+		dsType == "datasource" || dsType == "__expr__"
+}
+
+// resolve returns the data source referenced by UID, name, or type. A reference by type only matches the default data source of the type, or the only one.
+func (r *datasourceRefs) resolve(uidOrName, dsType string) (*models.DataSourceListItemDTO, error) {
+	if ds, ok := r.byUID[uidOrName]; ok {
+		return ds, nil
+	}
+	if ds, ok := r.byName[uidOrName]; ok {
+		return ds, nil
+	}
+	if candidates := r.byType[dsType]; dsType != "" && len(candidates) > 0 {
+		if len(candidates) == 1 {
+			return candidates[0], nil
+		}
+		for _, ds := range candidates {
+			if ds.IsDefault {
+				return ds, nil
+			}
+		}
+		names := make([]string, 0, len(candidates))
+		for _, ds := range candidates {
+			names = append(names, ds.Name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("data source %q not found, and there are several %s data sources: %s", uidOrName, dsType, strings.Join(names, ", "))
+	}
+	if dsType != "" {
+		return nil, fmt.Errorf("data source %q (%s) not found", uidOrName, dsType)
+	}
+	return nil, fmt.Errorf("data source %q not found", uidOrName)
+}
+
+// resolveUID returns the UID of the data source referenced by a `datasource_uid` attribute, which can also be a name.
+func (r *datasourceRefs) resolveUID(uidOrName string) (string, error) {
+	if isBuiltinDatasourceRef(uidOrName, "") {
+		return uidOrName, nil
+	}
+	ds, err := r.resolve(uidOrName, "")
+	if err != nil {
+		return "", err
+	}
+	return ds.UID, nil
+}
+
+// resolveRef returns the reference to use in Grafana for a `datasource` field of a model: a `{"type", "uid"}` object.
+// Built-in references and references that aren't strings or objects are returned unchanged.
+func (r *datasourceRefs) resolveRef(ref interface{}) (interface{}, error) {
+	var uidOrName, dsType string
+	switch v := ref.(type) {
+	case string:
+		uidOrName = v
+	case map[string]interface{}:
+		uidOrName, _ = v["uid"].(string)
+		dsType, _ = v["type"].(string)
+	default:
+		return ref, nil
+	}
+	if isBuiltinDatasourceRef(uidOrName, dsType) {
+		return ref, nil
+	}
+
+	ds, err := r.resolve(uidOrName, dsType)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"type": ds.Type, "uid": ds.UID}, nil
+}
+
+// resolveModel replaces the `datasource` fields of a dashboard or panel model (in panels, targets, variables, annotations...) by the resolved references, in place.
+func (r *datasourceRefs) resolveModel(model interface{}) error {
+	var errs []error
+	switch v := model.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if key != "datasource" {
+				errs = append(errs, r.resolveModel(value))
+				continue
+			}
+			resolved, err := r.resolveRef(value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			v[key] = resolved
+		}
+	case []interface{}:
+		for _, elem := range v {
+			errs = append(errs, r.resolveModel(elem))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unresolveModel replaces the `datasource` fields of a model read from Grafana by the references of the configured model,
+// where they resolve to the same data source, in place. This keeps the configured references in the state.
+func (r *datasourceRefs) unresolveModel(model, configured interface{}) {
+	switch v := model.(type) {
+	case map[string]interface{}:
+		c, ok := configured.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range v {
+			configuredValue, ok := c[key]
+			if !ok {
+				continue
+			}
+			if key != "datasource" {
+				r.unresolveModel(value, configuredValue)
+				continue
+			}
+			if resolved, err := r.resolveRef(configuredValue); err == nil && datasourceRefUID(resolved) == datasourceRefUID(value) {
+				v[key] = configuredValue
+			}
+		}
+	case []interface{}:
+		c, ok := configured.([]interface{})
+		if !ok {
+			return
+		}
+		for i := range v {
+			if i < len(c) {
+				r.unresolveModel(v[i], c[i])
+			}
+		}
+	}
+}
+
+func datasourceRefUID(ref interface{}) string {
+	switch v := ref.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		uid, _ := v["uid"].(string)
+		return uid
+	}
+	return ""
+}
+
+// checkDatasourceRefs returns a CustomizeDiff function that fails the plan if the data sources referenced by a resource with `resolve_datasources` don't exist.
+// `check` resolves the references of the planned resource. It must skip the unknown ones, ex: the UIDs of data sources created in the same apply.
+func checkDatasourceRefs(check func(d *schema.ResourceDiff, refs *datasourceRefs) error) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.Get("resolve_datasources").(bool) {
+			return nil
+		}
+		// The data sources of another instance can't be listed with the client of the provider
+		if _, ok := d.GetOk("grafana_connection"); ok {
+			return nil
+		}
+		metaClient, ok := meta.(*common.Client)
+		if !ok || metaClient.GrafanaAPI == nil {
+			return nil
+		}
+
+		client := metaClient.GrafanaAPI.Clone()
+		if orgID, _ := strconv.ParseInt(d.Get("org_id").(string), 10, 64); orgID > 0 {
+			client = client.WithOrgID(orgID)
+		}
+		refs, err := newDatasourceRefs(client)
+		if err != nil {
+			return err
+		}
+		return check(d, refs)
+	}
+}
+
+// resolveModelDatasources resolves the data source references of a dashboard or panel model before saving it, when `resolve_datasources` is enabled.
+func resolveModelDatasources(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, model interface{}) error {
+	if !d.Get("resolve_datasources").(bool) {
+		return nil
+	}
+	refs, err := newDatasourceRefs(client)
+	if err != nil {
+		return err
+	}
+	return refs.resolveModel(model)
+}
+
+// unresolveModelDatasources keeps the configured data source references of a model read from Grafana, when `resolve_datasources` is enabled.
+// The configured model is read from the given attribute. If it isn't known, ex: when only its hash is stored, the model is left unchanged.
+func unresolveModelDatasources(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, attribute string, model interface{}) error {
+	// Data sources sharing the read function don't have the attribute
+	if resolve, _ := d.Get("resolve_datasources").(bool); !resolve {
+		return nil
+	}
+	var configured interface{}
+	if err := json.Unmarshal([]byte(d.Get(attribute).(string)), &configured); err != nil {
+		return nil
+	}
+	refs, err := newDatasourceRefs(client)
+	if err != nil {
+		return err
+	}
+	refs.unresolveModel(model, configured)
+	return nil
+}
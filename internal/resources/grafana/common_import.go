@@ -0,0 +1,181 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/client/teams"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importResolver resolves the lookup value of a human-friendly import ID (ex: `Team A/Payments` in `folder:"Team A/Payments"`)
+// to the ID of the resource, without the org ID.
+type importResolver func(client *goapi.GrafanaHTTPAPI, value string) (string, error)
+
+// importStateWithLookup returns an importer that accepts `[<orgID>:]<kind>:<value>` IDs in addition to the canonical IDs of the resource.
+// The value can be quoted, ex: `folder:"Team A/Payments"`. It is resolved to the canonical ID, which is then imported as usual.
+// This works both with `terraform import` and `import {}` blocks.
+func importStateWithLookup(kind string, resolve importResolver) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		_, restOfID := SplitOrgResourceID(d.Id())
+		value, ok := parseImportLookup(kind, restOfID)
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+		if err := grafanaClientResourceValidation(d, meta); err != nil {
+			return nil, err
+		}
+
+		client, orgID, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+		id, err := resolve(client, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s %q: %w", kind, value, err)
+		}
+		d.SetId(MakeOrgResourceID(orgID, id))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importGlobalStateWithLookup is like importStateWithLookup, but for resources that aren't org-scoped.
+func importGlobalStateWithLookup(kind string, resolve importResolver) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		value, ok := parseImportLookup(kind, d.Id())
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if err := grafanaClientResourceValidation(d, meta); err != nil {
+			return nil, err
+		}
+
+		client, err := OAPIGlobalClient(meta)
+		if err != nil {
+			return nil, err
+		}
+		id, err := resolve(client, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s %q: %w", kind, value, err)
+		}
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// parseImportLookup returns the lookup value of an import ID if it has the `<kind>:` prefix.
+func parseImportLookup(kind, id string) (string, bool) {
+	value, ok := strings.CutPrefix(id, kind+common.ResourceIDSeparator)
+	if !ok {
+		return "", false
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return value, true
+}
+
+// resolveDashboardTitle resolves `title=<title>[@<folder path>]` to the UID of the dashboard.
+// A `@` within the title is escaped as `\@`, like a `/` within a folder title is escaped as `\/` in the folder path.
+// Without a folder, the title must be unique across all folders.
+func resolveDashboardTitle(client *goapi.GrafanaHTTPAPI, value string) (string, error) {
+	lookup, ok := strings.CutPrefix(value, "title=")
+	if !ok {
+		return "", fmt.Errorf("expected a value in the format `title=<title>[@<folder path>]`")
+	}
+
+	params := search.NewSearchParams().WithType(common.Ref("dash-db"))
+	title, folderPath, hasFolder := splitDashboardTitleLookup(lookup)
+	if hasFolder {
+		folderUID, err := findFolderWithPath(client, folderPath)
+		if err != nil {
+			return "", err
+		}
+		params.SetFolderUIDs([]string{folderUID})
+	}
+	params.SetQuery(&title)
+
+	var uids []string
+	var page int64 = 1
+	for {
+		params.SetPage(&page)
+		resp, err := client.Search.Search(params)
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Payload) == 0 {
+			break
+		}
+		for _, hit := range resp.Payload {
+			if hit.Title == title {
+				uids = append(uids, hit.UID)
+			}
+		}
+		page++
+	}
+
+	switch len(uids) {
+	case 0:
+		return "", fmt.Errorf("dashboard with title %q not found", title)
+	case 1:
+		return uids[0], nil
+	default:
+		return "", fmt.Errorf("found %d dashboards with title %q (UIDs: %s). Add the folder path (`title=<title>@<folder path>`) or import by UID", len(uids), title, strings.Join(uids, ", "))
+	}
+}
+
+// splitDashboardTitleLookup splits `<title>[@<folder path>]` at the first `@` that isn't escaped as `\@`.
+func splitDashboardTitleLookup(lookup string) (title string, folderPath string, hasFolder bool) {
+	var sb strings.Builder
+	for i := 0; i < len(lookup); i++ {
+		switch {
+		case lookup[i] == '\\' && i+1 < len(lookup) && lookup[i+1] == '@':
+			sb.WriteByte('@')
+			i++
+		case lookup[i] == '@':
+			return sb.String(), lookup[i+1:], true
+		default:
+			sb.WriteByte(lookup[i])
+		}
+	}
+	return sb.String(), "", false
+}
+
+// resolveContactPointName checks that the contact point exists. Its name is already its ID.
+func resolveContactPointName(client *goapi.GrafanaHTTPAPI, name string) (string, error) {
+	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams().WithName(&name))
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Payload) == 0 {
+		return "", fmt.Errorf("contact point with name %q not found", name)
+	}
+	return name, nil
+}
+
+// resolveTeamName resolves a team name to the ID of the team.
+func resolveTeamName(client *goapi.GrafanaHTTPAPI, name string) (string, error) {
+	resp, err := client.Teams.SearchTeams(teams.NewSearchTeamsParams().WithName(&name))
+	if err != nil {
+		return "", err
+	}
+	for _, team := range resp.Payload.Teams {
+		if team.Name == name {
+			return strconv.FormatInt(team.ID, 10), nil
+		}
+	}
+	return "", fmt.Errorf("team with name %q not found", name)
+}
+
+// resolveUserLogin resolves a user login (or email) to the ID of the user.
+func resolveUserLogin(client *goapi.GrafanaHTTPAPI, login string) (string, error) {
+	resp, err := client.Users.GetUserByLoginOrEmail(login)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(resp.Payload.ID, 10), nil
+}
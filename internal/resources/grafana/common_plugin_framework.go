@@ -7,6 +7,8 @@ import (
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionSchema "github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -60,8 +62,9 @@ func (r *basePluginFrameworkDataSource) clientFromNewOrgResource(orgIDStr string
 }
 
 type basePluginFrameworkResource struct {
-	client *goapi.GrafanaHTTPAPI
-	config *goapi.TransportConfig
+	client  *goapi.GrafanaHTTPAPI
+	config  *goapi.TransportConfig
+	clients *common.Client
 }
 
 func (r *basePluginFrameworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -83,16 +86,37 @@ func (r *basePluginFrameworkResource) Configure(ctx context.Context, req resourc
 
 	r.client = client.GrafanaAPI
 	r.config = client.GrafanaAPIConfig
+	r.clients = client
+}
+
+// connectionClient returns the client of the `grafana_connection` block of a resource, or the client of the provider if it isn't set
+func (r *basePluginFrameworkResource) connectionClient(ctx context.Context, connection []connectionModel) (*goapi.GrafanaHTTPAPI, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("client not configured")
+	}
+	if len(connection) == 0 {
+		return r.client, nil
+	}
+	client, err := r.clients.GrafanaConnection(ctx, common.GrafanaConnectionConfig{
+		URL:       connection[0].URL.ValueString(),
+		Auth:      connection[0].Auth.ValueString(),
+		StackSlug: connection[0].StackSlug.ValueString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.GrafanaAPI, nil
 }
 
 // clientFromExistingOrgResource creates a client from the ID of an org-scoped resource
 // Those IDs are in the <orgID>:<resourceID> format
-func (r *basePluginFrameworkResource) clientFromExistingOrgResource(idFormat *common.ResourceID, id string) (*goapi.GrafanaHTTPAPI, int64, []any, error) {
-	if r.client == nil {
-		return nil, 0, nil, fmt.Errorf("client not configured")
+func (r *basePluginFrameworkResource) clientFromExistingOrgResource(ctx context.Context, connection []connectionModel, idFormat *common.ResourceID, id string) (*goapi.GrafanaHTTPAPI, int64, []any, error) {
+	baseClient, err := r.connectionClient(ctx, connection)
+	if err != nil {
+		return nil, 0, nil, err
 	}
 
-	client := r.client.Clone()
+	client := baseClient.Clone()
 	split, err := idFormat.Split(id)
 	if err != nil {
 		return nil, 0, nil, err
@@ -110,12 +134,13 @@ func (r *basePluginFrameworkResource) clientFromExistingOrgResource(idFormat *co
 
 // clientFromNewOrgResource creates an OpenAPI client from the `org_id` attribute of a resource
 // This client is meant to be used in `Create` functions when the ID hasn't already been baked into the resource ID
-func (r *basePluginFrameworkResource) clientFromNewOrgResource(orgIDStr string) (*goapi.GrafanaHTTPAPI, int64, error) {
-	if r.client == nil {
-		return nil, 0, fmt.Errorf("client not configured")
+func (r *basePluginFrameworkResource) clientFromNewOrgResource(ctx context.Context, connection []connectionModel, orgIDStr string) (*goapi.GrafanaHTTPAPI, int64, error) {
+	baseClient, err := r.connectionClient(ctx, connection)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	client := r.client.Clone()
+	client := baseClient.Clone()
 	orgID, _ := strconv.ParseInt(orgIDStr, 10, 64)
 	if orgID == 0 {
 		orgID = client.OrgID()
@@ -125,6 +150,43 @@ func (r *basePluginFrameworkResource) clientFromNewOrgResource(orgIDStr string)
 	return client, orgID, nil
 }
 
+type basePluginFrameworkAction struct {
+	client *goapi.GrafanaHTTPAPI
+}
+
+func (a *basePluginFrameworkAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Configure is called multiple times (sometimes when ProviderData is not yet available), we only want to configure once
+	if req.ProviderData == nil || a.client != nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *common.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client.GrafanaAPI
+}
+
+// clientFromOrgID creates an OpenAPI client from the `org_id` attribute of an action, or the org ID part of a resource ID
+func (a *basePluginFrameworkAction) clientFromOrgID(orgID int64) (*goapi.GrafanaHTTPAPI, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("the Grafana client is required for this action. Set the auth and url provider attributes")
+	}
+
+	client := a.client.Clone()
+	if orgID > 0 {
+		client = client.WithOrgID(orgID)
+	}
+	return client, nil
+}
+
 // To be used in non-org-scoped resources
 // func (r *basePluginFrameworkResource) globalClient() (*goapi.GrafanaHTTPAPI, error) {
 // if r.client == nil {
@@ -150,6 +212,13 @@ func pluginFrameworkOrgIDAttribute() frameworkSchema.Attribute {
 	}
 }
 
+func pluginFrameworkActionOrgIDAttribute() actionSchema.Attribute {
+	return actionSchema.StringAttribute{
+		Optional:    true,
+		Description: "The Organization ID. If not set, the default organization is used for basic authentication, or the one that owns your service account for token authentication.",
+	}
+}
+
 type orgIDAttributePlanModifier struct{}
 
 func (d *orgIDAttributePlanModifier) Description(ctx context.Context) string {
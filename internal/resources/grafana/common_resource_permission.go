@@ -4,6 +4,7 @@
 package grafana
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/grafana/grafana-openapi-client-go/client"
@@ -42,6 +43,8 @@ type resourcePermissionItemBaseModel struct {
 	// Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
 	// So this is a generic ID to be written to FolderUID/DatasourceUID/etc
 	ResourceID types.String `tfsdk:"-"`
+
+	GrafanaConnection []connectionModel `tfsdk:"-"`
 }
 
 type resourcePermissionBase struct {
@@ -101,8 +104,8 @@ func (r *resourcePermissionBase) addInSchemaAttributes(attributes map[string]sch
 	return attributes
 }
 
-func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client *client.GrafanaHTTPAPI, itemID string) error) (*resourcePermissionItemBaseModel, diag.Diagnostics) {
-	client, orgID, splitID, err := r.clientFromExistingOrgResource(resourceFolderPermissionItemID, id)
+func (r *resourcePermissionBase) readItem(ctx context.Context, connection []connectionModel, id string, checkExistsFunc func(client *client.GrafanaHTTPAPI, itemID string) error) (*resourcePermissionItemBaseModel, diag.Diagnostics) {
+	client, orgID, splitID, err := r.clientFromExistingOrgResource(ctx, connection, resourceFolderPermissionItemID, id)
 	if err != nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Unable to parse resource ID", err.Error())}
 	}
@@ -129,10 +132,11 @@ func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client
 
 	for _, permission := range permissionsResp.Payload {
 		data := &resourcePermissionItemBaseModel{
-			ResourceID: types.StringValue(itemID),
-			ID:         types.StringValue(id),
-			OrgID:      types.StringValue(strconv.FormatInt(orgID, 10)),
-			Permission: types.StringValue(permission.Permission),
+			ResourceID:        types.StringValue(itemID),
+			ID:                types.StringValue(id),
+			OrgID:             types.StringValue(strconv.FormatInt(orgID, 10)),
+			Permission:        types.StringValue(permission.Permission),
+			GrafanaConnection: connection,
 		}
 		switch permissionTargetType {
 		case permissionTargetTeam:
@@ -163,8 +167,8 @@ func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client
 	return nil, nil
 }
 
-func (r *resourcePermissionBase) writeItem(itemID string, data *resourcePermissionItemBaseModel) diag.Diagnostics {
-	client, orgID, err := r.clientFromNewOrgResource(data.OrgID.ValueString())
+func (r *resourcePermissionBase) writeItem(ctx context.Context, itemID string, data *resourcePermissionItemBaseModel) diag.Diagnostics {
+	client, orgID, err := r.clientFromNewOrgResource(ctx, data.GrafanaConnection, data.OrgID.ValueString())
 	if err != nil {
 		return diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
 	}
@@ -0,0 +1,801 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+const (
+	// dashboardModelSchemaVersion is the version of the dashboard JSON model rendered by the `grafana_dashboard_model` data source.
+	dashboardModelSchemaVersion = 39
+	// dashboardGridWidth is the number of columns of the dashboard grid.
+	dashboardGridWidth = 24
+)
+
+var (
+	dashboardModelVariableTypes  = []string{"query", "custom", "constant", "datasource", "interval", "textbox", "adhoc"}
+	dashboardModelVariableHide   = []string{"", "label", "variable"}
+	dashboardModelVariableReload = []string{"never", "on_load", "on_time_range_change"}
+	dashboardModelLinkTypes      = []string{"link", "dashboards"}
+)
+
+func datasourceDashboardModel() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Renders a dashboard JSON model from typed blocks, to be used as the ` + "`config_json`" + ` of a ` + "`grafana_dashboard`" + ` resource.
+It doesn't call the Grafana API. Blocks can be generated with ` + "`dynamic`" + ` blocks, to build reusable dashboard modules.
+
+Panels are laid out automatically, from left to right and top to bottom, in the order of their blocks: first the top-level ` + "`panel`" + ` blocks, then the ` + "`row`" + ` blocks with their panels.
+
+* [JSON model documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/view-dashboard-json-model/)
+`,
+		ReadContext: dataSourceDashboardModelRead,
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the dashboard.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The unique identifier of the dashboard. If not set, Grafana generates one.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the dashboard.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The tags of the dashboard.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "browser",
+				Description: "The timezone of the dashboard, ex: `browser`, `utc` or `Europe/Paris`.",
+			},
+			"time_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now-6h",
+				Description: "The start of the default time range.",
+			},
+			"time_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now",
+				Description: "The end of the default time range.",
+			},
+			"refresh": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The refresh interval of the dashboard, ex: `1m`. If not set, the dashboard isn't refreshed automatically.",
+			},
+			"editable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the dashboard can be edited in the Grafana UI.",
+			},
+			"shared_crosshair": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the crosshair is shared between panels.",
+			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The variables of the dashboard, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the variable, used as `$name` in queries.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "query",
+							Description:  common.AllowedValuesDescription("The type of the variable", dashboardModelVariableTypes),
+							ValidateFunc: validation.StringInSlice(dashboardModelVariableTypes, false),
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The label of the variable.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the variable.",
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "The query of the variable. Its meaning depends on the type: the data source query for `query`, " +
+								"comma-separated values for `custom` and `interval`, the value for `constant` and `textbox`, and the data source type for `datasource`.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UID of the data source of `query` and `adhoc` variables.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the data source of `query` and `adhoc` variables, ex: `prometheus`.",
+						},
+						"default": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The values selected by default. Several values can be selected if `multi` is set.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"multi": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether several values can be selected.",
+						},
+						"include_all": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether an `All` option is available.",
+						},
+						"all_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value of the `All` option. If not set, it's the list of all the values.",
+						},
+						"regex": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A regex to filter or capture the values returned by the query.",
+						},
+						"sort": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "How the values are sorted: 0 for the query order, 1 and 2 for alphabetical (asc, desc), 3 and 4 for numerical, 5 and 6 for alphabetical case-insensitive.",
+						},
+						"hide": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							Description:  common.AllowedValuesDescription("What to hide in the variables bar. If not set, the label and value are shown", dashboardModelVariableHide),
+							ValidateFunc: validation.StringInSlice(dashboardModelVariableHide, false),
+						},
+						"reload": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "on_load",
+							Description:  common.AllowedValuesDescription("When the values of `query` variables are reloaded", dashboardModelVariableReload),
+							ValidateFunc: validation.StringInSlice(dashboardModelVariableReload, false),
+						},
+					},
+				},
+			},
+			"annotation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The annotation queries of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the annotation query.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The UID of the data source of the annotation query.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the data source, ex: `prometheus`.",
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The query expression, for data sources that use `expr`, like Prometheus and Loki.",
+						},
+						"target_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Other fields of the annotation query, as a JSON object.",
+							ValidateFunc: validation.StringIsJSON,
+						},
+						"enable": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the annotations are shown.",
+						},
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the toggle of the annotation query is hidden.",
+						},
+						"icon_color": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "red",
+							Description: "The color of the annotations.",
+						},
+					},
+				},
+			},
+			"link": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The links of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the link.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "link",
+							Description:  common.AllowedValuesDescription("The type of the link: a URL, or the dashboards with the given tags", dashboardModelLinkTypes),
+							ValidateFunc: validation.StringInSlice(dashboardModelLinkTypes, false),
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of `link` links.",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The tags of the dashboards of `dashboards` links.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"icon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "external link",
+							Description: "The icon of the link.",
+						},
+						"tooltip": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The tooltip of the link.",
+						},
+						"as_dropdown": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the dashboards of `dashboards` links are shown in a dropdown.",
+						},
+						"target_blank": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the link opens in a new tab.",
+						},
+						"include_vars": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the values of the variables are added to the link.",
+						},
+						"keep_time": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the time range is added to the link.",
+						},
+					},
+				},
+			},
+			"panel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The panels of the dashboard that aren't in a row.",
+				Elem:        dashboardModelPanelSchema(),
+			},
+			"row": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The rows of the dashboard, after the panels that aren't in a row.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the row.",
+						},
+						"collapsed": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the row is collapsed.",
+						},
+						"repeat": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of a variable to repeat the row for each of its values.",
+						},
+						"panel": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The panels of the row.",
+							Elem:        dashboardModelPanelSchema(),
+						},
+					},
+				},
+			},
+			"config_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered dashboard JSON model.",
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_dashboard_model", schema)
+}
+
+func dashboardModelPanelSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The title of the panel.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "timeseries",
+				Description: "The type of the panel, ex: `timeseries`, `stat` or `text`.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the panel.",
+			},
+			"width": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      12,
+				Description:  "The width of the panel, in grid columns. The grid has 24 columns.",
+				ValidateFunc: validation.IntBetween(1, dashboardGridWidth),
+			},
+			"height": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				Description:  "The height of the panel, in grid rows.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UID of the data source of the panel. It's the default data source of the targets.",
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The type of the data source of the panel, ex: `prometheus`.",
+			},
+			"unit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The unit of the values, ex: `percent` or `bytes`.",
+			},
+			"options_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The options of the panel, as a JSON object. They depend on the type of the panel.",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"field_config_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The field config of the panel (`defaults` and `overrides`), as a JSON object. `unit` is added to its defaults.",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"transparent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the panel has a transparent background.",
+			},
+			"repeat": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of a variable to repeat the panel for each of its values, horizontally.",
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The queries of the panel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The identifier of the query. If not set, queries are named `A`, `B`, `C`...",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UID of the data source of the query. If not set, the data source of the panel is used.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the data source of the query, ex: `prometheus`.",
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The query expression, for data sources that use `expr`, like Prometheus and Loki.",
+						},
+						"legend_format": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The legend of the series, ex: `{{instance}}`.",
+						},
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the query is disabled.",
+						},
+						"query_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Other fields of the query, as a JSON object. They depend on the data source.",
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDashboardModelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	model := map[string]interface{}{
+		"title":         d.Get("title"),
+		"tags":          common.ListToStringSlice(d.Get("tags").([]interface{})),
+		"timezone":      d.Get("timezone"),
+		"editable":      d.Get("editable"),
+		"graphTooltip":  0,
+		"time":          map[string]interface{}{"from": d.Get("time_from"), "to": d.Get("time_to")},
+		"schemaVersion": dashboardModelSchemaVersion,
+	}
+	for attribute, field := range map[string]string{"uid": "uid", "description": "description", "refresh": "refresh"} {
+		if v := d.Get(attribute).(string); v != "" {
+			model[field] = v
+		}
+	}
+	if d.Get("shared_crosshair").(bool) {
+		model["graphTooltip"] = 1
+	}
+
+	variables := []interface{}{}
+	for _, v := range d.Get("variable").([]interface{}) {
+		variables = append(variables, dashboardModelVariable(v.(map[string]interface{})))
+	}
+	model["templating"] = map[string]interface{}{"list": variables}
+
+	annotations := []interface{}{}
+	for _, a := range d.Get("annotation").([]interface{}) {
+		annotation, err := dashboardModelAnnotation(a.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		annotations = append(annotations, annotation)
+	}
+	model["annotations"] = map[string]interface{}{"list": annotations}
+
+	links := []interface{}{}
+	for _, l := range d.Get("link").([]interface{}) {
+		links = append(links, dashboardModelLink(l.(map[string]interface{})))
+	}
+	model["links"] = links
+
+	panels, err := dashboardModelPanels(d.Get("panel").([]interface{}), d.Get("row").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	model["panels"] = panels
+
+	configJSON, err := json.Marshal(model)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(configJSON)))
+	d.Set("config_json", string(configJSON))
+	return nil
+}
+
+func dashboardModelVariable(v map[string]interface{}) map[string]interface{} {
+	variable := map[string]interface{}{
+		"name":       v["name"],
+		"type":       v["type"],
+		"query":      v["query"],
+		"multi":      v["multi"],
+		"includeAll": v["include_all"],
+		"options":    []interface{}{},
+	}
+	for attribute, field := range map[string]string{"label": "label", "description": "description", "all_value": "allValue", "regex": "regex"} {
+		if value := v[attribute].(string); value != "" {
+			variable[field] = value
+		}
+	}
+	switch v["hide"] {
+	case "label":
+		variable["hide"] = 1
+	case "variable":
+		variable["hide"] = 2
+	default:
+		variable["hide"] = 0
+	}
+
+	switch v["type"] {
+	case "query":
+		variable["definition"] = v["query"]
+		variable["sort"] = v["sort"]
+		variable["refresh"] = map[string]int{"never": 0, "on_load": 1, "on_time_range_change": 2}[v["reload"].(string)]
+		variable["datasource"] = dashboardModelDatasource(v)
+	case "adhoc":
+		variable["datasource"] = dashboardModelDatasource(v)
+	case "custom", "interval":
+		options := []interface{}{}
+		for _, value := range strings.Split(v["query"].(string), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				options = append(options, map[string]interface{}{"text": value, "value": value, "selected": false})
+			}
+		}
+		variable["options"] = options
+	}
+
+	if defaults := common.ListToStringSlice(v["default"].([]interface{})); len(defaults) > 0 {
+		if v["multi"].(bool) {
+			variable["current"] = map[string]interface{}{"text": defaults, "value": defaults}
+		} else {
+			variable["current"] = map[string]interface{}{"text": defaults[0], "value": defaults[0]}
+		}
+	}
+	return variable
+}
+
+func dashboardModelAnnotation(a map[string]interface{}) (map[string]interface{}, error) {
+	target := map[string]interface{}{"refId": "Anno"}
+	if expr := a["expr"].(string); expr != "" {
+		target["expr"] = expr
+	}
+	if err := mergeJSONObject(target, a["target_json"].(string)); err != nil {
+		return nil, fmt.Errorf("annotation %q: target_json: %w", a["name"], err)
+	}
+	return map[string]interface{}{
+		"name":       a["name"],
+		"datasource": dashboardModelDatasource(a),
+		"enable":     a["enable"],
+		"hide":       a["hide"],
+		"iconColor":  a["icon_color"],
+		"target":     target,
+	}, nil
+}
+
+func dashboardModelLink(l map[string]interface{}) map[string]interface{} {
+	link := map[string]interface{}{
+		"title":       l["title"],
+		"type":        l["type"],
+		"icon":        l["icon"],
+		"tooltip":     l["tooltip"],
+		"asDropdown":  l["as_dropdown"],
+		"targetBlank": l["target_blank"],
+		"includeVars": l["include_vars"],
+		"keepTime":    l["keep_time"],
+		"tags":        common.ListToStringSlice(l["tags"].([]interface{})),
+	}
+	if url := l["url"].(string); url != "" {
+		link["url"] = url
+	}
+	return link
+}
+
+// dashboardModelLayout places panels on the dashboard grid, from left to right and top to bottom.
+type dashboardModelLayout struct {
+	x, y, lineHeight int
+	nextID           int
+}
+
+func (l *dashboardModelLayout) place(width, height int) map[string]interface{} {
+	if l.x+width > dashboardGridWidth {
+		l.newLine()
+	}
+	gridPos := map[string]interface{}{"x": l.x, "y": l.y, "w": width, "h": height}
+	l.x += width
+	l.lineHeight = max(l.lineHeight, height)
+	return gridPos
+}
+
+func (l *dashboardModelLayout) newLine() {
+	if l.x > 0 {
+		l.y += l.lineHeight
+	}
+	l.x, l.lineHeight = 0, 0
+}
+
+func (l *dashboardModelLayout) id() int {
+	l.nextID++
+	return l.nextID
+}
+
+// dashboardModelPanels renders the top-level panels, then the rows with their panels.
+// The panels of collapsed rows are nested in the row, while the panels of expanded rows follow it.
+func dashboardModelPanels(topLevelPanels, rows []interface{}) ([]interface{}, error) {
+	layout := &dashboardModelLayout{}
+	panels := []interface{}{}
+	for _, p := range topLevelPanels {
+		panel, err := dashboardModelPanel(p.(map[string]interface{}), layout)
+		if err != nil {
+			return nil, err
+		}
+		panels = append(panels, panel)
+	}
+
+	for _, r := range rows {
+		r := r.(map[string]interface{})
+		layout.newLine()
+		row := map[string]interface{}{
+			"type":      "row",
+			"id":        layout.id(),
+			"title":     r["title"],
+			"collapsed": r["collapsed"],
+			"gridPos":   map[string]interface{}{"x": 0, "y": layout.y, "w": dashboardGridWidth, "h": 1},
+			"panels":    []interface{}{},
+		}
+		if repeat := r["repeat"].(string); repeat != "" {
+			row["repeat"] = repeat
+		}
+		layout.y++
+		panels = append(panels, row)
+
+		// The panels of a collapsed row are laid out below it, but the next row follows it directly
+		rowLayout := layout
+		if r["collapsed"].(bool) {
+			rowLayout = &dashboardModelLayout{y: layout.y, nextID: layout.nextID}
+		}
+		rowPanels := []interface{}{}
+		for _, p := range r["panel"].([]interface{}) {
+			panel, err := dashboardModelPanel(p.(map[string]interface{}), rowLayout)
+			if err != nil {
+				return nil, err
+			}
+			rowPanels = append(rowPanels, panel)
+		}
+		if r["collapsed"].(bool) {
+			layout.nextID = rowLayout.nextID
+			row["panels"] = rowPanels
+		} else {
+			panels = append(panels, rowPanels...)
+		}
+	}
+	return panels, nil
+}
+
+func dashboardModelPanel(p map[string]interface{}, layout *dashboardModelLayout) (map[string]interface{}, error) {
+	panel := map[string]interface{}{
+		"id":      layout.id(),
+		"type":    p["type"],
+		"title":   p["title"],
+		"gridPos": layout.place(p["width"].(int), p["height"].(int)),
+	}
+	errorPrefix := fmt.Sprintf("panel %q", p["title"])
+	if description := p["description"].(string); description != "" {
+		panel["description"] = description
+	}
+	if p["transparent"].(bool) {
+		panel["transparent"] = true
+	}
+	if repeat := p["repeat"].(string); repeat != "" {
+		panel["repeat"] = repeat
+		panel["repeatDirection"] = "h"
+	}
+	panelDatasource := dashboardModelDatasource(p)
+	if panelDatasource != nil {
+		panel["datasource"] = panelDatasource
+	}
+
+	options := map[string]interface{}{}
+	if err := mergeJSONObject(options, p["options_json"].(string)); err != nil {
+		return nil, fmt.Errorf("%s: options_json: %w", errorPrefix, err)
+	}
+	panel["options"] = options
+
+	fieldConfig := map[string]interface{}{"defaults": map[string]interface{}{}, "overrides": []interface{}{}}
+	if err := mergeJSONObject(fieldConfig, p["field_config_json"].(string)); err != nil {
+		return nil, fmt.Errorf("%s: field_config_json: %w", errorPrefix, err)
+	}
+	if unit := p["unit"].(string); unit != "" {
+		if defaults, ok := fieldConfig["defaults"].(map[string]interface{}); ok {
+			defaults["unit"] = unit
+		}
+	}
+	panel["fieldConfig"] = fieldConfig
+
+	targets := []interface{}{}
+	for i, t := range p["target"].([]interface{}) {
+		t := t.(map[string]interface{})
+		refID := t["ref_id"].(string)
+		if refID == "" {
+			refID = dashboardModelRefID(i)
+		}
+		target := map[string]interface{}{"refId": refID}
+		if datasource := dashboardModelDatasource(t); datasource != nil {
+			target["datasource"] = datasource
+		} else if panelDatasource != nil {
+			target["datasource"] = panelDatasource
+		}
+		if expr := t["expr"].(string); expr != "" {
+			target["expr"] = expr
+		}
+		if legendFormat := t["legend_format"].(string); legendFormat != "" {
+			target["legendFormat"] = legendFormat
+		}
+		if t["hide"].(bool) {
+			target["hide"] = true
+		}
+		if err := mergeJSONObject(target, t["query_json"].(string)); err != nil {
+			return nil, fmt.Errorf("%s: target %s: query_json: %w", errorPrefix, refID, err)
+		}
+		targets = append(targets, target)
+	}
+	panel["targets"] = targets
+
+	return panel, nil
+}
+
+// dashboardModelDatasource returns the data source reference of a block with `datasource_uid` and `datasource_type` attributes, or nil if it isn't set.
+func dashboardModelDatasource(block map[string]interface{}) map[string]interface{} {
+	uid := block["datasource_uid"].(string)
+	if uid == "" {
+		return nil
+	}
+	datasource := map[string]interface{}{"uid": uid}
+	if dsType := block["datasource_type"].(string); dsType != "" {
+		datasource["type"] = dsType
+	}
+	return datasource
+}
+
+// dashboardModelRefID returns the default reference ID of the i-th query: A, B, ..., Z, AA, AB...
+func dashboardModelRefID(i int) string {
+	refID := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		refID = string(rune('A'+(i-1)%26)) + refID
+	}
+	return refID
+}
+
+// mergeJSONObject sets the fields of a JSON object on the given map. An empty string is ignored.
+func mergeJSONObject(dst map[string]interface{}, objectJSON string) error {
+	if objectJSON == "" {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(objectJSON), &fields); err != nil {
+		return err
+	}
+	for k, v := range fields {
+		dst[k] = v
+	}
+	return nil
+}
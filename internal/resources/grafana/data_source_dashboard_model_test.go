@@ -0,0 +1,152 @@
+package grafana_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+)
+
+func TestFakeDatasourceDashboardModel_basic(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	client := fake.Client(t, fakeGrafana).GrafanaAPI
+
+	checkRemote := func(s *terraform.State) error {
+		resp, err := client.Dashboards.GetDashboardByUID("services-model")
+		if err != nil {
+			return err
+		}
+		model := resp.Payload.Dashboard.(map[string]interface{})
+		var got []string
+		for _, p := range model["panels"].([]interface{}) {
+			panel := p.(map[string]interface{})
+			gridPos := panel["gridPos"].(map[string]interface{})
+			got = append(got, fmt.Sprintf("%v %s %s x=%v y=%v w=%v h=%v", panel["id"], panel["type"], panel["title"], gridPos["x"], gridPos["y"], gridPos["w"], gridPos["h"]))
+		}
+		expected := []string{
+			"1 text Overview x=0 y=0 w=24 h=3",
+			"2 row api x=0 y=3 w=24 h=1",
+			"3 timeseries Requests x=0 y=4 w=12 h=8",
+			"4 stat Errors x=12 y=4 w=12 h=8",
+			"5 row worker x=0 y=12 w=24 h=1",
+			"6 timeseries Requests x=0 y=13 w=12 h=8",
+			"7 stat Errors x=12 y=13 w=12 h=8",
+		}
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected panels %v, got %v", expected, got)
+		}
+
+		target := model["panels"].([]interface{})[2].(map[string]interface{})["targets"].([]interface{})[0].(map[string]interface{})
+		if target["refId"] != "A" || target["legendFormat"] != "{{instance}}" || target["datasource"].(map[string]interface{})["uid"] != "prometheus" {
+			return fmt.Errorf("unexpected target %v", target)
+		}
+		return nil
+	}
+
+	fake.UnitTest(t, fakeGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_dashboard_model/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.services", "uid", "services-model"),
+					checkRemote,
+				),
+			},
+		},
+	})
+}
+
+func TestFakeDatasourceDashboardModel_collapsedRow(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+
+	checkModel := func(s *terraform.State) error {
+		var model map[string]interface{}
+		if err := json.Unmarshal([]byte(s.RootModule().Resources["data.grafana_dashboard_model.test"].Primary.Attributes["config_json"]), &model); err != nil {
+			return err
+		}
+		panels := model["panels"].([]interface{})
+		if len(panels) != 2 {
+			return fmt.Errorf("expected 2 rows, got %v", panels)
+		}
+		collapsed := panels[0].(map[string]interface{})
+		rowPanels := collapsed["panels"].([]interface{})
+		if len(rowPanels) != 2 {
+			return fmt.Errorf("expected 2 panels in the collapsed row, got %v", rowPanels)
+		}
+		targets := rowPanels[1].(map[string]interface{})["targets"].([]interface{})
+		if refID := targets[1].(map[string]interface{})["refId"]; refID != "B" {
+			return fmt.Errorf("expected the second target to be B, got %v", refID)
+		}
+		if y := panels[1].(map[string]interface{})["gridPos"].(map[string]interface{})["y"]; y != 1.0 {
+			return fmt.Errorf("expected the second row to follow the collapsed row, got y=%v", y)
+		}
+		return nil
+	}
+
+	fake.UnitTest(t, fakeGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "grafana_dashboard_model" "test" {
+  title = "Collapsed"
+
+  row {
+    title     = "Details"
+    collapsed = true
+    panel {
+      title = "One"
+    }
+    panel {
+      title = "Two"
+      target {
+        expr = "up"
+      }
+      target {
+        expr = "down"
+      }
+    }
+  }
+
+  row {
+    title = "Other"
+  }
+}`,
+				Check: checkModel,
+			},
+		},
+	})
+}
+
+// The model is built without the Grafana API, so it works without the url and auth provider attributes
+func TestDatasourceDashboardModel_withoutClient(t *testing.T) {
+	var dataSource *schema.Resource
+	for _, d := range grafana.DataSources {
+		if d.Name == "grafana_dashboard_model" {
+			dataSource = d.Schema
+		}
+	}
+	require.NotNil(t, dataSource)
+	require.NotContains(t, dataSource.Schema, "grafana_connection")
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"title": "Offline",
+		"panel": []interface{}{map[string]interface{}{"title": "Requests"}},
+	})
+	diags := dataSource.ReadContext(context.Background(), d, &common.Client{})
+	require.Empty(t, diags)
+
+	var model map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(d.Get("config_json").(string)), &model))
+	require.Equal(t, "Offline", model["title"])
+	require.Len(t, model["panels"], 1)
+}
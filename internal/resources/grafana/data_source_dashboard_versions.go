@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/dashboard_versions"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDashboardVersions() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Datasource for retrieving the version history of a dashboard, from the most recent version.
+A dashboard can be restored to one of its versions with the ` + "`grafana_dashboard_version_restore`" + ` action.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [Dashboard Versions HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/)
+`,
+		ReadContext: dataSourceReadDashboardVersions,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the dashboard.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "Maximum number of versions to return.",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the dashboard, from the most recent.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"parent_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version this version was saved from.",
+						},
+						"restored_from": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version this version was restored from, or 0 if it wasn't restored.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the version was saved, in RFC 3339 format.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user who saved the version.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The commit message of the version.",
+						},
+					},
+				},
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_dashboard_versions", schema)
+}
+
+func dataSourceReadDashboardVersions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	uid := d.Get("dashboard_uid").(string)
+	limit := int64(d.Get("limit").(int))
+	params := dashboard_versions.NewGetDashboardVersionsByUIDParams().WithUID(uid).WithLimit(&limit)
+	resp, err := client.DashboardVersions.GetDashboardVersionsByUID(params)
+	if err != nil {
+		return diag.Errorf("error getting the versions of dashboard %q: %s", uid, err)
+	}
+
+	versions := make([]map[string]interface{}, len(resp.GetPayload()))
+	for i, version := range resp.GetPayload() {
+		versions[i] = map[string]interface{}{
+			"version":        version.Version,
+			"parent_version": version.ParentVersion,
+			"restored_from":  version.RestoredFrom,
+			"created":        time.Time(version.Created).Format(time.RFC3339),
+			"created_by":     version.CreatedBy,
+			"message":        version.Message,
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, uid))
+	if err := d.Set("versions", versions); err != nil {
+		return diag.Errorf("error setting versions attribute: %s", err)
+	}
+	return nil
+}
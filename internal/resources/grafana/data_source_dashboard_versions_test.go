@@ -0,0 +1,41 @@
+package grafana_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+)
+
+func TestFakeDatasourceDashboardVersions(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	example := testutils.TestAccExample(t, "data-sources/grafana_dashboard_versions/data-source.tf")
+
+	fake.UnitTest(t, fakeGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: example,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.message", "Initial version"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.created_by", "admin"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_versions.test", "versions.0.created"),
+				),
+			},
+			{
+				Config: strings.NewReplacer(`"Production Overview"`, `"Production"`, `"Initial version"`, `"Rename"`).Replace(example),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "2"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.parent_version", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.message", "Rename"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.1.version", "1"),
+				),
+			},
+		},
+	})
+}
@@ -10,26 +10,35 @@ import (
 )
 
 func datasourceDatasource() *common.DataSource {
+	updates := map[string]*schema.Schema{
+		"org_id": orgIDAttribute(),
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{"name", "uid"},
+		},
+		"uid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{"name", "uid"},
+		},
+		"secure_json_data_encoded": nil,
+		"http_headers":             nil,
+		"health_check":             nil,
+		"health_status":            nil,
+		"health_message":           nil,
+	}
+	// The whole JSON data is returned in json_data_encoded
+	for _, block := range datasourceConfigBlocks {
+		updates[block.name] = nil
+	}
+
 	schema := &schema.Resource{
 		Description: "Get details about a Grafana Datasource querying by either name, uid or ID",
 		ReadContext: datasourceDatasourceRead,
-		Schema: common.CloneResourceSchemaForDatasource(resourceDataSource().Schema, map[string]*schema.Schema{
-			"org_id": orgIDAttribute(),
-			"name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				AtLeastOneOf: []string{"name", "uid"},
-			},
-			"uid": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				AtLeastOneOf: []string{"name", "uid"},
-			},
-			"secure_json_data_encoded": nil,
-			"http_headers":             nil,
-		}),
+		Schema:      common.CloneResourceSchemaForDatasource(resourceDataSource().Schema, updates),
 	}
 	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_data_source", schema)
 }
@@ -3,9 +3,12 @@ package grafana
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,6 +17,8 @@ import (
 func datasourceFolder() *common.DataSource {
 	schema := &schema.Resource{
 		Description: `
+Looks up a folder by title, or by path for nested folders.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder/)
 `,
@@ -21,11 +26,27 @@ func datasourceFolder() *common.DataSource {
 		Schema: common.CloneResourceSchemaForDatasource(resourceFolder().Schema, map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The title of the folder. If several folders have this title, the first one found is returned: use `path` to look up nested folders.",
+				ExactlyOneOf: []string{"title", "path"},
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The path of the folder, made of folder titles separated by `/`, from the root, ex: `Platform/Payments/Alerts`. " +
+					"A `/` within a title can be escaped as `\\/`.",
+				ExactlyOneOf: []string{"title", "path"},
+			},
+			"parent_folder_path": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The title of the folder.",
+				Computed:    true,
+				Description: "The path of the parent folder, or an empty string for folders at the root.",
 			},
 			"prevent_destroy_if_not_empty": nil,
+			"create_parent_folders":        nil,
 		}),
 	}
 	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_folder", schema)
@@ -55,12 +76,152 @@ func findFolderWithTitle(client *goapi.GrafanaHTTPAPI, title string) (string, er
 	}
 }
 
+// findFolderWithPath returns the UID of the folder at the given path, ex: `Team A/Payments`.
+// Path segments are folder titles, starting from the root. A `/` within a title can be escaped as `\/`.
+func findFolderWithPath(client *goapi.GrafanaHTTPAPI, path string) (string, error) {
+	parentUID := ""
+	for _, title := range splitFolderPath(path) {
+		uid, err := findChildFolderWithTitle(client, parentUID, title)
+		if err != nil {
+			return "", err
+		}
+		parentUID = uid
+	}
+	if parentUID == "" {
+		return "", fmt.Errorf("invalid folder path %q", path)
+	}
+	return parentUID, nil
+}
+
+// findOrCreateFolderWithPath works like findFolderWithPath, but creates the folders of the path that don't exist.
+func findOrCreateFolderWithPath(client *goapi.GrafanaHTTPAPI, path string) (string, error) {
+	parentUID := ""
+	for _, title := range splitFolderPath(path) {
+		uids, err := findChildFolderUIDs(client, parentUID, title)
+		if err != nil {
+			return "", err
+		}
+		switch len(uids) {
+		case 0:
+			resp, err := client.Folders.CreateFolder(&models.CreateFolderCommand{Title: title, ParentUID: parentUID})
+			if err != nil {
+				return "", fmt.Errorf("failed to create folder %q: %w", title, err)
+			}
+			parentUID = resp.Payload.UID
+		case 1:
+			parentUID = uids[0]
+		default:
+			return "", fmt.Errorf("found %d folders with title %q in the same parent folder (UIDs: %s)", len(uids), title, strings.Join(uids, ", "))
+		}
+	}
+	if parentUID == "" {
+		return "", fmt.Errorf("invalid folder path %q", path)
+	}
+	return parentUID, nil
+}
+
+// findChildFolderWithTitle returns the UID of the folder with the given title, directly within the given parent folder ("" for the root)
+func findChildFolderWithTitle(client *goapi.GrafanaHTTPAPI, parentUID, title string) (string, error) {
+	uids, err := findChildFolderUIDs(client, parentUID, title)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(uids) {
+	case 0:
+		if parentUID == "" {
+			return "", fmt.Errorf("folder with title %q not found", title)
+		}
+		return "", fmt.Errorf("folder with title %q not found in folder %q", title, parentUID)
+	case 1:
+		return uids[0], nil
+	default:
+		return "", fmt.Errorf("found %d folders with title %q in the same parent folder (UIDs: %s)", len(uids), title, strings.Join(uids, ", "))
+	}
+}
+
+// findChildFolderUIDs returns the UIDs of the folders with the given title, directly within the given parent folder ("" for the root)
+func findChildFolderUIDs(client *goapi.GrafanaHTTPAPI, parentUID, title string) ([]string, error) {
+	var uids []string
+	var page int64 = 1
+	for {
+		params := search.NewSearchParams().WithType(common.Ref("dash-folder")).WithQuery(&title).WithPage(&page)
+		resp, err := client.Search.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Payload) == 0 {
+			return uids, nil
+		}
+		for _, folder := range resp.Payload {
+			if folder.Title == title && folder.FolderUID == parentUID {
+				uids = append(uids, folder.UID)
+			}
+		}
+		page++
+	}
+}
+
+func splitFolderPath(path string) []string {
+	var titles []string
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '/':
+			current.WriteByte('/')
+			i++
+		case path[i] == '/':
+			if current.Len() > 0 {
+				titles = append(titles, current.String())
+			}
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	if current.Len() > 0 {
+		titles = append(titles, current.String())
+	}
+	return titles
+}
+
+// joinFolderPath returns the path of a folder from the titles of its parents and its own, escaping the `/` within titles.
+func joinFolderPath(titles ...string) string {
+	escaped := make([]string, len(titles))
+	for i, title := range titles {
+		escaped[i] = strings.ReplaceAll(title, "/", `\/`)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// folderPathsEqual returns true if two folder paths have the same titles, ex: `A/B` and `/A/B/`.
+func folderPathsEqual(a, b string) bool {
+	return slices.Equal(splitFolderPath(a), splitFolderPath(b))
+}
+
 func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
-	uid, err := findFolderWithTitle(client, d.Get("title").(string))
+	var uid string
+	var err error
+	if path := d.Get("path").(string); path != "" {
+		uid, err = findFolderWithPath(client, path)
+	} else {
+		uid, err = findFolderWithTitle(client, d.Get("title").(string))
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(MakeOrgResourceID(orgID, uid))
-	return ReadFolder(ctx, d, meta)
+	folder, diags := readFolder(d, meta)
+	if folder == nil {
+		return diags
+	}
+
+	titles := make([]string, 0, len(folder.Parents)+1)
+	for _, parent := range folder.Parents {
+		titles = append(titles, parent.Title)
+	}
+	d.Set("parent_folder_path", joinFolderPath(titles...))
+	d.Set("path", joinFolderPath(append(titles, folder.Title)...))
+	return diags
 }
@@ -18,6 +18,8 @@ func datasourceFolders() *common.DataSource {
 		},
 
 		Description: `
+Lists the folders of the organization, including nested folders with their paths.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder/)
 `,
@@ -50,6 +52,17 @@ func datasourceFolders() *common.DataSource {
 							Computed:    true,
 							Description: "The folder's URL",
 						},
+						"parent_folder_uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The UID of the parent folder, or an empty string for folders at the root.",
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The path of the folder, made of folder titles separated by `/`, from the root, ex: `Platform/Payments/Alerts`. " +
+								"A `/` within a title is escaped as `\\/`.",
+						},
 					},
 				},
 			},
@@ -81,16 +94,35 @@ func readFolders(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	d.SetId(MakeOrgResourceID(orgID, "folders"))
 
+	byUID := make(map[string]*models.Hit, len(folders))
+	for _, folder := range folders {
+		byUID[folder.UID] = folder
+	}
+
 	folderItems := make([]interface{}, 0)
 	for _, folder := range folders {
 		f := map[string]interface{}{
-			"title": folder.Title,
-			"id":    folder.ID,
-			"uid":   folder.UID,
-			"url":   metaClient.GrafanaSubpath(folder.URL),
+			"title":             folder.Title,
+			"id":                folder.ID,
+			"uid":               folder.UID,
+			"url":               metaClient.GrafanaSubpath(folder.URL),
+			"parent_folder_uid": folder.FolderUID,
+			"path":              folderHitPath(folder, byUID),
 		}
 		folderItems = append(folderItems, f)
 	}
 
 	return diag.FromErr(d.Set("folders", folderItems))
 }
+
+// folderHitPath returns the path of a folder found by a search, from the titles of its parent folders.
+// If a parent folder isn't in the search results, ex: because of permissions, the path starts from its child.
+func folderHitPath(folder *models.Hit, byUID map[string]*models.Hit) string {
+	titles := []string{folder.Title}
+	seen := map[string]bool{folder.UID: true}
+	for parent := byUID[folder.FolderUID]; parent != nil && !seen[parent.UID]; parent = byUID[parent.FolderUID] {
+		seen[parent.UID] = true
+		titles = append([]string{parent.Title}, titles...)
+	}
+	return joinFolderPath(titles...)
+}
@@ -24,6 +24,8 @@ func datasourceLibraryPanel() *common.DataSource {
 				Optional:    true,
 				Description: "The unique identifier (UID) of the library panel.",
 			},
+			"conflict_policy":     nil,
+			"resolve_datasources": nil,
 		}),
 	}
 	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_library_panel", schema)
@@ -0,0 +1,71 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServerInfo() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Gets the version, edition and enabled feature toggles of the Grafana server.
+
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/other/#health-api)
+`,
+		ReadContext: dataSourceServerInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Grafana server.",
+			},
+			"commit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The commit the Grafana server was built from.",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the database of the Grafana server.",
+			},
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edition of the Grafana server, ex: `Open Source` or `Enterprise`.",
+			},
+			"enterprise_commit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The commit the Grafana Enterprise server was built from. Empty for the Open Source edition.",
+			},
+			"feature_toggles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The feature toggles enabled on the Grafana server.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_server_info", schema)
+}
+
+func dataSourceServerInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	info, err := meta.(*common.Client).GrafanaServerInfo()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("grafana_server_info")
+	d.Set("version", info.Version)
+	d.Set("commit", info.Commit)
+	d.Set("database", info.Database)
+	d.Set("edition", info.Edition)
+	d.Set("enterprise_commit", info.EnterpriseCommit)
+	d.Set("feature_toggles", info.FeatureToggles)
+
+	return nil
+}
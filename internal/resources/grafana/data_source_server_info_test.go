@@ -0,0 +1,27 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceServerInfo_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_server_info/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_server_info.current", "id", "grafana_server_info"),
+					resource.TestCheckResourceAttrSet("data.grafana_server_info.current", "version"),
+					resource.TestCheckResourceAttrSet("data.grafana_server_info.current", "edition"),
+					resource.TestCheckResourceAttr("data.grafana_server_info.current", "database", "ok"),
+				),
+			},
+		},
+	})
+}
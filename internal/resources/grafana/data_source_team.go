@@ -29,9 +29,19 @@ func datasourceTeam() *common.DataSource {
 				Default:     false,
 				Description: "Whether to read the team sync settings. This is only available in Grafana Enterprise.",
 			},
+			"members": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The email addresses of the members of the team.",
+			},
 			"ignore_externally_synced_members": nil,
+			"manage_members":                   nil,
+			"manage_preferences":               nil,
 		}),
 	}
+	// Reading the preferences of a team isn't deprecated, only managing them with the grafana_team resource
+	schema.Schema["preferences"].Deprecated = ""
 	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_team", schema)
 }
 
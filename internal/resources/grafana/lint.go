@@ -0,0 +1,377 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// LintSeverity is the severity of a LintIssue.
+// The Lint* functions check dashboards, rule groups and message templates offline, without a Grafana instance.
+// They are used by the `terraform-provider-grafana-lint` command.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem found by a Lint* function.
+type LintIssue struct {
+	Severity LintSeverity
+	Message  string
+}
+
+func lintErrorf(format string, args ...any) LintIssue {
+	return LintIssue{Severity: LintError, Message: fmt.Sprintf(format, args...)}
+}
+
+func lintWarningf(format string, args ...any) LintIssue {
+	return LintIssue{Severity: LintWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+// LintReferences are the UIDs and names of the data sources and library panels that dashboards can reference.
+// References aren't checked when the corresponding set is empty, since they may be managed elsewhere.
+type LintReferences struct {
+	DataSources   map[string]bool
+	LibraryPanels map[string]bool
+}
+
+// builtinDataSources are the data sources that exist in every Grafana instance.
+var builtinDataSources = map[string]bool{
+	"-- Grafana --":   true,
+	"grafana":         true,
+	"-- Mixed --":     true,
+	"-- Dashboard --": true,
+	"__expr__":        true,
+	"default":         true,
+}
+
+// corePanelTypes are the panel types bundled with Grafana. Plugin panel types contain dashes (ex: `grafana-clock-panel`) and aren't checked.
+var corePanelTypes = map[string]bool{
+	"alertlist": true, "annolist": true, "barchart": true, "bargauge": true, "candlestick": true, "canvas": true,
+	"dashlist": true, "datagrid": true, "debug": true, "flamegraph": true, "gauge": true, "geomap": true,
+	"gettingstarted": true, "graph": true, "heatmap": true, "histogram": true, "live": true, "logs": true,
+	"news": true, "nodeGraph": true, "piechart": true, "row": true, "singlestat": true, "stat": true,
+	"state-timeline": true, "status-history": true, "table": true, "table-old": true, "text": true,
+	"timeseries": true, "traces": true, "trend": true, "welcome": true, "xychart": true,
+}
+
+// LintDashboard checks the `config_json` of a dashboard.
+func LintDashboard(configJSON string, refs LintReferences) []LintIssue {
+	if _, errs := validateDashboardConfigJSON(configJSON, "config_json"); len(errs) > 0 {
+		return []LintIssue{lintErrorf("invalid JSON: %v", errs[0])}
+	}
+	model, _ := UnmarshalDashboardConfigJSON(configJSON)
+
+	var issues []LintIssue
+	variables := map[string]map[string]interface{}{}
+	templating, _ := model["templating"].(map[string]interface{})
+	variableList, _ := templating["list"].([]interface{})
+	for _, v := range variableList {
+		if v, ok := v.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok && name != "" {
+				variables[name] = v
+			}
+		}
+	}
+
+	panels := dashboardPanels(model)
+	panelIDs := map[float64]bool{}
+	for _, panel := range panels {
+		title, _ := panel["title"].(string)
+		if id, ok := panel["id"].(float64); ok {
+			if panelIDs[id] {
+				issues = append(issues, lintErrorf("panel %q: duplicate panel ID %v", title, id))
+			}
+			panelIDs[id] = true
+		}
+
+		if libraryPanel, ok := panel["libraryPanel"].(map[string]interface{}); ok {
+			uid, _ := libraryPanel["uid"].(string)
+			name, _ := libraryPanel["name"].(string)
+			if uid == "" {
+				issues = append(issues, lintErrorf("panel %q: library panel reference without a uid", title))
+			} else if len(refs.LibraryPanels) > 0 && !refs.LibraryPanels[uid] && !refs.LibraryPanels[name] {
+				issues = append(issues, lintWarningf("panel %q: unknown library panel %q", title, uid))
+			}
+			continue
+		}
+
+		if panelType, _ := panel["type"].(string); panelType == "" {
+			issues = append(issues, lintErrorf("panel %q: missing panel type", title))
+		} else if !strings.Contains(panelType, "-") && !corePanelTypes[panelType] {
+			issues = append(issues, lintWarningf("panel %q: unknown panel type %q", title, panelType))
+		}
+
+		issues = append(issues, lintDataSourceReference(fmt.Sprintf("panel %q", title), panel["datasource"], variables, refs)...)
+		targets, _ := panel["targets"].([]interface{})
+		for _, target := range targets {
+			if target, ok := target.(map[string]interface{}); ok {
+				refID, _ := target["refId"].(string)
+				issues = append(issues, lintDataSourceReference(fmt.Sprintf("panel %q, query %s", title, refID), target["datasource"], variables, refs)...)
+			}
+		}
+	}
+
+	annotations, _ := model["annotations"].(map[string]interface{})
+	annotationList, _ := annotations["list"].([]interface{})
+	for _, a := range annotationList {
+		if a, ok := a.(map[string]interface{}); ok {
+			issues = append(issues, lintDataSourceReference(fmt.Sprintf("annotation %q", a["name"]), a["datasource"], variables, refs)...)
+		}
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := variables[name]
+		issues = append(issues, lintDataSourceReference(fmt.Sprintf("variable %q", name), v["datasource"], variables, refs)...)
+		// Ad hoc filters are applied to queries without being referenced
+		if v["type"] == "adhoc" {
+			continue
+		}
+		if !dashboardReferencesVariable(model, name) {
+			issues = append(issues, lintWarningf("variable %q is not used", name))
+		}
+	}
+
+	return issues
+}
+
+// dashboardPanels returns the panels of a dashboard, including those of collapsed rows and of legacy `rows`.
+func dashboardPanels(model map[string]interface{}) []map[string]interface{} {
+	var panels []map[string]interface{}
+	var collect func(list interface{})
+	collect = func(list interface{}) {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			if panel, ok := item.(map[string]interface{}); ok {
+				panels = append(panels, panel)
+				collect(panel["panels"])
+			}
+		}
+	}
+	collect(model["panels"])
+	rows, _ := model["rows"].([]interface{})
+	for _, row := range rows {
+		if row, ok := row.(map[string]interface{}); ok {
+			collect(row["panels"])
+		}
+	}
+	return panels
+}
+
+func lintDataSourceReference(location string, ref interface{}, variables map[string]map[string]interface{}, refs LintReferences) []LintIssue {
+	var values []string
+	switch ref := ref.(type) {
+	case string:
+		values = []string{ref}
+	case map[string]interface{}:
+		uid, _ := ref["uid"].(string)
+		values = []string{uid}
+		if t, _ := ref["type"].(string); t == "datasource" || t == "__expr__" {
+			return nil
+		}
+	}
+
+	for _, value := range values {
+		if value == "" || builtinDataSources[value] {
+			continue
+		}
+		if name, ok := variableReference(value); ok {
+			if _, ok := variables[name]; !ok {
+				return []LintIssue{lintErrorf("%s: data source variable %q is not defined", location, name)}
+			}
+			continue
+		}
+		if len(refs.DataSources) > 0 && !refs.DataSources[value] {
+			return []LintIssue{lintWarningf("%s: unknown data source %q", location, value)}
+		}
+	}
+	return nil
+}
+
+var variableReferenceRegexp = regexp.MustCompile(`^(?:\$\{([^}:.]+)[^}]*\}|\$(\w+)|\[\[(\w+)\]\])$`)
+
+// variableReference returns the name of the variable referenced by a value, ex: `$ds`, `${ds}` or `[[ds]]`.
+func variableReference(value string) (string, bool) {
+	matches := variableReferenceRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1] + matches[2] + matches[3], true
+}
+
+// dashboardReferencesVariable checks whether a variable is referenced anywhere in the dashboard, except in its own definition.
+func dashboardReferencesVariable(model map[string]interface{}, name string) bool {
+	quoted := regexp.QuoteMeta(name)
+	reference := regexp.MustCompile(`\$\{` + quoted + `[}:.]|\$` + quoted + `\b|\[\[` + quoted + `[\]:]`)
+
+	for key, value := range model {
+		if key == "templating" {
+			continue
+		}
+		if b, _ := json.Marshal(value); reference.Match(b) {
+			return true
+		}
+	}
+	templating, _ := model["templating"].(map[string]interface{})
+	variableList, _ := templating["list"].([]interface{})
+	for _, v := range variableList {
+		if v, ok := v.(map[string]interface{}); ok && v["name"] != name {
+			if b, _ := json.Marshal(v); reference.Match(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LintRuleGroupResource checks the attributes of a `grafana_rule_group` resource, as decoded from its configuration.
+// The rules are unpacked like they are before being sent to the API.
+func LintRuleGroupResource(attributes map[string]interface{}) []LintIssue {
+	r := resourceRuleGroup().Schema
+	d := r.Data(nil)
+	for key, value := range attributes {
+		// Meta-arguments, ex: `provider` or `depends_on`
+		if _, ok := r.Schema[key]; !ok {
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return []LintIssue{lintErrorf("invalid %s: %v", key, err)}
+		}
+	}
+
+	var issues []LintIssue
+	var rules []*models.ProvisionedAlertRule
+	for _, raw := range d.Get("rule").([]interface{}) {
+		rule := raw.(map[string]interface{})
+		name := rule["name"].(string)
+		missingTimeRange := false
+		for _, data := range rule["data"].([]interface{}) {
+			data := data.(map[string]interface{})
+			// The model is empty when it can't be evaluated offline, ex: if it references other resources
+			if data["model"] == "" {
+				data["model"] = "{}"
+			}
+			if len(data["relative_time_range"].([]interface{})) == 0 {
+				missingTimeRange = true
+				issues = append(issues, lintErrorf("rule %q, query %s: relative_time_range is required", name, data["ref_id"]))
+			}
+		}
+		if missingTimeRange {
+			continue
+		}
+
+		unpacked, err := unpackAlertRule(raw, d.Get("name").(string), d.Get("folder_uid").(string), 0)
+		if err != nil {
+			issues = append(issues, lintErrorf("rule %q: %v", name, err))
+			continue
+		}
+		rules = append(rules, unpacked)
+	}
+	return append(issues, lintRules(rules)...)
+}
+
+// LintRuleGroupJSON checks a rule group in the format of the provisioning API, or rule groups in the format of the alerting export API (with a `groups` key).
+func LintRuleGroupJSON(content []byte) []LintIssue {
+	var export struct {
+		Groups []struct {
+			Rules []*models.ProvisionedAlertRule `json:"rules"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return []LintIssue{lintErrorf("invalid JSON: %v", err)}
+	}
+	if len(export.Groups) > 0 {
+		var issues []LintIssue
+		for _, group := range export.Groups {
+			issues = append(issues, lintRules(group.Rules)...)
+		}
+		return issues
+	}
+
+	var group models.AlertRuleGroup
+	if err := json.Unmarshal(content, &group); err != nil {
+		return []LintIssue{lintErrorf("invalid rule group: %v", err)}
+	}
+	return lintRules(group.Rules)
+}
+
+// expressionsWithRefID are the server-side expression types whose `expression` is the ref ID of another query.
+var expressionsWithRefID = map[string]bool{
+	"reduce":    true,
+	"resample":  true,
+	"threshold": true,
+}
+
+func lintRules(rules []*models.ProvisionedAlertRule) []LintIssue {
+	var issues []LintIssue
+	titles := map[string]bool{}
+	for _, rule := range rules {
+		var title, condition string
+		if rule.Title != nil {
+			title = *rule.Title
+		}
+		if rule.Condition != nil {
+			condition = *rule.Condition
+		}
+		if titles[title] {
+			issues = append(issues, lintErrorf("rule %q is defined more than once", title))
+		}
+		titles[title] = true
+
+		refIDs := map[string]bool{}
+		for _, query := range rule.Data {
+			if refIDs[query.RefID] {
+				issues = append(issues, lintErrorf("rule %q: duplicate ref_id %q", title, query.RefID))
+			}
+			refIDs[query.RefID] = true
+		}
+
+		if condition != "" && !refIDs[condition] {
+			issues = append(issues, lintErrorf("rule %q: condition %q does not match any ref_id", title, condition))
+		}
+		for _, query := range rule.Data {
+			model, _ := query.Model.(map[string]interface{})
+			expressionType, _ := model["type"].(string)
+			expression, _ := model["expression"].(string)
+			if query.DatasourceUID == "__expr__" && expressionsWithRefID[expressionType] && expression != "" && !refIDs[expression] {
+				issues = append(issues, lintErrorf("rule %q, query %s: expression %q does not match any ref_id", title, query.RefID, expression))
+			}
+		}
+	}
+	return issues
+}
+
+// templateFuncs are the functions available in Alertmanager and Grafana notification templates.
+// Only their names matter, to parse templates.
+var templateFuncs = func() template.FuncMap {
+	funcs := template.FuncMap{}
+	for _, name := range []string{
+		"toUpper", "toLower", "title", "trimSpace", "join", "match", "safeHtml", "safeUrl", "urlUnescape",
+		"reReplaceAll", "stringSlice", "date", "tz", "since", "humanizeDuration", "toTime", "toJson",
+		"humanize", "humanize1024", "humanizePercentage", "humanizeTimestamp", "externalURL", "pathPrefix",
+		"graphLink", "tableLink", "parseDuration", "args", "sortByLabel",
+	} {
+		funcs[name] = func(...interface{}) interface{} { return nil }
+	}
+	return funcs
+}()
+
+// LintMessageTemplate checks that a notification template is a valid Go template.
+func LintMessageTemplate(content string) []LintIssue {
+	if _, err := template.New("").Funcs(templateFuncs).Parse(content); err != nil {
+		return []LintIssue{lintErrorf("invalid template: %v", err)}
+	}
+	return nil
+}
@@ -33,19 +33,21 @@ func orgIDAttribute() *schema.Schema {
 }
 
 // MakeOrgResourceID creates a resource ID for an org-scoped resource
+// The resource ID is escaped, so that it can contain the separator
 func MakeOrgResourceID(orgID int64, resourceID interface{}) string {
-	return fmt.Sprintf("%d:%s", orgID, fmt.Sprint(resourceID))
+	return fmt.Sprintf("%d:%s", orgID, common.EscapeResourceIDPart(fmt.Sprint(resourceID)))
 }
 
 // SplitOrgResourceID splits into two parts (org ID and resource ID) the ID of an org-scoped resource
+// The resource ID is unescaped. IDs of resources with more than two parts must be split with their common.ResourceID instead
 func SplitOrgResourceID(id string) (int64, string) {
 	if strings.ContainsRune(id, ':') {
 		parts := strings.SplitN(id, ":", 2)
 		orgID, err := strconv.ParseInt(parts[0], 10, 64)
 		if err != nil {
-			return 0, id
+			return 0, common.UnescapeResourceIDPart(id)
 		}
-		return orgID, parts[1]
+		return orgID, common.UnescapeResourceIDPart(parts[1])
 	}
 
 	return 0, id
@@ -61,7 +61,7 @@ This resource requires Grafana 9.1.0 or later.
 		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteContactPoint),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithLookup("contact_point", resolveContactPointName),
 		},
 
 		SchemaVersion: 0,
@@ -104,7 +104,9 @@ This resource requires Grafana 9.1.0 or later.
 		"grafana_contact_point",
 		orgResourceIDString("name"),
 		resource,
-	).WithLister(listerFunctionOrgResource(listContactPoints))
+	).WithLister(listerFunctionOrgResource(listContactPoints)).
+		WithImportLookups("contact_point:{{ name }}").
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func listContactPoints(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -183,7 +185,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 				params.SetXDisableProvenance(&provenanceDisabled)
 			}
 			if _, err := client.Provisioning.PutContactpoint(params); err != nil {
-				return diag.FromErr(err)
+				return common.APIErrorDiagnostics(fmt.Sprintf("error updating %s notifier of contact point %q", *p.gfState.Type, data.Get("name")), err, nil)
 			}
 		} else {
 			// If the contact point does not have a UID, create it.
@@ -204,7 +206,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 				return nil
 			})
 			if err != nil {
-				return diag.FromErr(err)
+				return common.APIErrorDiagnostics(fmt.Sprintf("error creating %s notifier of contact point %q", *p.gfState.Type, data.Get("name")), err, nil)
 			}
 		}
 
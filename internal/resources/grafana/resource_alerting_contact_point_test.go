@@ -13,8 +13,38 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 )
 
+func TestFakeContactPoint_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_contact_point/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_contact_point.my_contact_point", "name", "My Contact Point"),
+					resource.TestCheckResourceAttr("grafana_contact_point.my_contact_point", "email.#", "1"),
+					resource.TestCheckResourceAttr("grafana_contact_point.my_contact_point", "email.0.addresses.#", "2"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_contact_point.my_contact_point"),
+				),
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_contact_point/resource.tf", map[string]string{
+					"firing": "alerts firing",
+				}),
+				Check: resource.TestCheckResourceAttr("grafana_contact_point.my_contact_point", "email.0.message", "{{ len .Alerts.Firing }} alerts firing."),
+			},
+			{
+				ResourceName:      "grafana_contact_point.my_contact_point",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccContactPoint_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
 
@@ -44,6 +74,12 @@ func TestAccContactPoint_basic(t *testing.T) {
 				ImportStateId:     "My Contact Point",
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "grafana_contact_point.my_contact_point",
+				ImportState:       true,
+				ImportStateId:     "contact_point:My Contact Point",
+				ImportStateVerify: true,
+			},
 			// Test update content.
 			{
 				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_contact_point/resource.tf", map[string]string{
@@ -66,7 +66,8 @@ This resource requires Grafana 9.1.0 or later.
 		"grafana_message_template",
 		orgResourceIDString("name"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listMessageTemplate))
+	).WithLister(listerFunctionOrgResource(listMessageTemplate)).
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func listMessageTemplate(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -6,10 +6,34 @@ import (
 
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+func TestFakeMessageTemplate_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_message_template/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_message_template.my_template", "id", "1:My Reusable Template"),
+					resource.TestCheckResourceAttr("grafana_message_template.my_template", "template", "{{define \"My Reusable Template\" }}\n template content\n{{ end }}"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_message_template.my_template"),
+				),
+			},
+			{
+				ResourceName:            "grafana_message_template.my_template",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_provenance"},
+			},
+		},
+	})
+}
+
 func TestAccMessageTemplate_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
 
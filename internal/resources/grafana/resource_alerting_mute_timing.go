@@ -133,7 +133,8 @@ This resource requires Grafana 9.1.0 or later.
 		"grafana_mute_timing",
 		orgResourceIDString("name"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listMuteTimings))
+	).WithLister(listerFunctionOrgResource(listMuteTimings)).
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func listMuteTimings(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
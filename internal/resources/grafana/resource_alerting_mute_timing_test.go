@@ -9,8 +9,65 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 )
 
+func TestFakeMuteTiming_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_mute_timing/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_mute_timing.my_mute_timing", "name", "My Mute Timing"),
+					resource.TestCheckResourceAttr("grafana_mute_timing.my_mute_timing", "intervals.0.weekdays.#", "2"),
+					resource.TestCheckResourceAttr("grafana_mute_timing.my_mute_timing", "intervals.0.location", "America/New_York"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_mute_timing.my_mute_timing"),
+				),
+			},
+			{
+				ResourceName:            "grafana_mute_timing.my_mute_timing",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_provenance"},
+			},
+		},
+	})
+}
+
+func TestFakeMuteTiming_nameWithSeparator(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_mute_timing/resource.tf", map[string]string{
+					"My Mute Timing": "team:mute",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_mute_timing.my_mute_timing", "id", `1:team\:mute`),
+					resource.TestCheckResourceAttr("grafana_mute_timing.my_mute_timing", "name", "team:mute"),
+				),
+			},
+			{
+				ResourceName:            "grafana_mute_timing.my_mute_timing",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_provenance"},
+			},
+			// IDs made before escaping are still supported
+			{
+				ResourceName:            "grafana_mute_timing.my_mute_timing",
+				ImportState:             true,
+				ImportStateId:           "1:team:mute",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_provenance"},
+			},
+		},
+	})
+}
+
 func TestAccMuteTiming_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">9.0.0")
 
@@ -91,7 +91,8 @@ This resource requires Grafana 9.1.0 or later.
 		"grafana_notification_policy",
 		orgResourceIDString("anyString"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listNotificationPolicies))
+	).WithLister(listerFunctionOrgResource(listNotificationPolicies)).
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 // The maximum depth of policy tree that the provider supports, as Terraform does not allow for infinitely recursive schemas.
@@ -259,7 +260,8 @@ func putNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 	}
 
 	data.SetId(MakeOrgResourceID(orgID, PolicySingletonID))
-	return readNotificationPolicy(ctx, data, meta)
+	diags := annotateChange(client, "grafana_notification_policy", "", "notification policy tree changed")
+	return append(diags, readNotificationPolicy(ctx, data, meta)...)
 }
 
 func deleteNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
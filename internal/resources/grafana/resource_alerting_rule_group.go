@@ -3,6 +3,7 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -29,6 +30,15 @@ var resourceRuleGroupID = common.NewResourceID(
 	common.StringIDField("title"),
 )
 
+// splitRuleGroupID returns the folder UID and the title of a rule group from its ID.
+func splitRuleGroupID(id string) (string, string, error) {
+	parts, err := resourceRuleGroupID.Split(id)
+	if err != nil {
+		return "", "", err
+	}
+	return parts[len(parts)-2].(string), parts[len(parts)-1].(string), nil
+}
+
 func resourceRuleGroup() *common.Resource {
 	schema := &schema.Resource{
 		Description: `
@@ -46,6 +56,29 @@ This resource requires Grafana 9.1.0 or later.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: checkDatasourceRefs(func(d *schema.ResourceDiff, refs *datasourceRefs) error {
+			// The references are read from the configuration to skip the unknown ones, ex: the UIDs of data sources created in the same apply
+			rules := d.GetRawConfig().GetAttr("rule")
+			if !rules.IsKnown() || rules.IsNull() {
+				return nil
+			}
+			var errs []error
+			for i, rule := range rules.AsValueSlice() {
+				if !rule.IsKnown() || rule.IsNull() || !rule.GetAttr("data").IsKnown() || rule.GetAttr("data").IsNull() {
+					continue
+				}
+				for j, query := range rule.GetAttr("data").AsValueSlice() {
+					uid := query.GetAttr("datasource_uid")
+					if !uid.IsKnown() || uid.IsNull() {
+						continue
+					}
+					if _, err := refs.resolveUID(uid.AsString()); err != nil {
+						errs = append(errs, fmt.Errorf("rule.%d.data.%d.datasource_uid: %w", i, j, err))
+					}
+				}
+			}
+			return errors.Join(errs...)
+		}),
 
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
@@ -74,6 +107,8 @@ This resource requires Grafana 9.1.0 or later.
 				Default:     false,
 				Description: "Allow modifying the rule group from other sources than Terraform or the Grafana API.",
 			},
+			"conflict_policy":     conflictPolicyAttribute(conflictPolicyOverwrite),
+			"resolve_datasources": resolveDatasourcesAttribute("the `datasource_uid` of the queries, which can then be data source names"),
 			"rule": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -255,7 +290,8 @@ This resource requires Grafana 9.1.0 or later.
 		"grafana_rule_group",
 		resourceRuleGroupID,
 		schema,
-	).WithLister(listerFunctionOrgResource(listRuleGroups))
+	).WithLister(listerFunctionOrgResource(listRuleGroups)).
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func listRuleGroups(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -288,11 +324,11 @@ func listRuleGroups(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int
 }
 
 func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, orgID, idWithoutOrg := OAPIClientFromExistingOrgResource(meta, data.Id())
+	client, orgID, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	folderUID, title, found := strings.Cut(idWithoutOrg, common.ResourceIDSeparator)
-	if !found {
-		return diag.Errorf("invalid ID %q", idWithoutOrg)
+	folderUID, title, err := splitRuleGroupID(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	resp, err := client.Provisioning.GetAlertRuleGroup(title, folderUID)
@@ -322,6 +358,11 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 		}
 		rules = append(rules, packed)
 	}
+	if data.Get("resolve_datasources").(bool) {
+		if err := unresolveRuleDatasources(client, data.Get("rule").([]interface{}), rules); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	data.Set("disable_provenance", disableProvenance)
 	data.Set("rule", rules)
 	data.SetId(resourceRuleGroupID.Make(orgID, folderUID, title))
@@ -329,9 +370,75 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 	return nil
 }
 
+// unresolveRuleDatasources keeps the configured `datasource_uid` of the queries of rules read from Grafana (packed with packAlertRule), where they resolve to the same data source.
+// Rules are matched by name, and queries by `ref_id`.
+func unresolveRuleDatasources(client *goapi.GrafanaHTTPAPI, configuredRules, rules []interface{}) error {
+	configuredUIDs := map[[2]string]string{}
+	for _, rule := range configuredRules {
+		rule := rule.(map[string]interface{})
+		for _, query := range rule["data"].([]interface{}) {
+			query := query.(map[string]interface{})
+			configuredUIDs[[2]string{rule["name"].(string), query["ref_id"].(string)}] = query["datasource_uid"].(string)
+		}
+	}
+	if len(configuredUIDs) == 0 {
+		return nil
+	}
+
+	refs, err := newDatasourceRefs(client)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		rule := rule.(map[string]interface{})
+		// Packed rules have a *string name
+		name, _ := rule["name"].(*string)
+		if name == nil {
+			continue
+		}
+		for _, query := range rule["data"].([]interface{}) {
+			query := query.(map[string]interface{})
+			configuredUID, ok := configuredUIDs[[2]string{*name, query["ref_id"].(string)}]
+			if !ok {
+				continue
+			}
+			if uid, err := refs.resolveUID(configuredUID); err == nil && uid == query["datasource_uid"] {
+				query["datasource_uid"] = configuredUID
+			}
+		}
+	}
+	return nil
+}
+
+// ruleGroupAPIFieldNames maps the fields of the rule group API to the attributes of the resource, when they differ by more than their case.
+// It is used to attach API validation errors to the right attribute.
+var ruleGroupAPIFieldNames = map[string]string{
+	"rules":    "rule",
+	"title":    "name",
+	"interval": "interval_seconds",
+	"isPaused": "is_paused",
+}
+
 func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, data)
 
+	var diags diag.Diagnostics
+	if !data.IsNewResource() {
+		if policy := getConflictPolicy(data, conflictPolicyOverwrite); policy != conflictPolicyOverwrite {
+			if diags = checkRuleGroupConflict(client, data, policy); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	var refs *datasourceRefs
+	if data.Get("resolve_datasources").(bool) {
+		var err error
+		if refs, err = newDatasourceRefs(client); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
 	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
 		respAlertRules, err := client.Provisioning.GetAlertRules()
 		if err != nil {
@@ -362,6 +469,13 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 			if err != nil {
 				return retry.NonRetryableError(err)
 			}
+			if refs != nil {
+				for _, query := range ruleToApply.Data {
+					if query.DatasourceUID, err = refs.resolveUID(query.DatasourceUID); err != nil {
+						return retry.NonRetryableError(err)
+					}
+				}
+			}
 
 			// Check if a rule with the same name already exists within the same rule group
 			for _, r := range rules {
@@ -408,18 +522,114 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 	})
 
 	if retryErr != nil {
-		return diag.FromErr(retryErr)
+		return append(diags, common.APIErrorDiagnostics("error saving rule group", retryErr, ruleGroupAPIFieldNames)...)
+	}
+
+	diags = append(diags, annotateChange(client, "grafana_rule_group", "",
+		fmt.Sprintf("rule group %q changed in folder %s", data.Get("name").(string), data.Get("folder_uid").(string)),
+		"folder:"+data.Get("folder_uid").(string),
+	)...)
+	return append(diags, readAlertRuleGroup(ctx, data, meta)...)
+}
+
+// checkRuleGroupConflict compares the rule group in the state with the remote one.
+// Rule groups aren't versioned, so their contents are compared instead.
+// If they differ, the rule group was modified outside of Terraform and a diagnostic is returned according to the conflict policy.
+func checkRuleGroupConflict(client *goapi.GrafanaHTTPAPI, data *schema.ResourceData, policy string) diag.Diagnostics {
+	folderUID, title, err := splitRuleGroupID(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	return readAlertRuleGroup(ctx, data, meta)
+	resp, err := client.Provisioning.GetAlertRuleGroup(title, folderUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteRules := make([]interface{}, 0, len(resp.Payload.Rules))
+	for _, r := range resp.Payload.Rules {
+		packed, err := packAlertRule(r)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		remoteRules = append(remoteRules, packed)
+	}
+
+	stateInterval, _ := data.GetChange("interval_seconds")
+	stateRules, _ := data.GetChange("rule")
+	if data.Get("resolve_datasources").(bool) {
+		if err := unresolveRuleDatasources(client, stateRules.([]interface{}), remoteRules); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	stateSnapshot, err := ruleGroupSnapshot(stateInterval, stateRules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteSnapshot, err := ruleGroupSnapshot(resp.Payload.Interval, remoteRules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	changes := common.JSONChanges(stateSnapshot, remoteSnapshot)
+	if len(changes) == 0 {
+		return nil
+	}
+	return conflictDiagnostics(policy, "rule group", data.Id(), "its rules differ from the state", "rule", changes)
+}
+
+// ruleGroupSnapshot builds a comparable JSON representation of a rule group from its interval and packed rules.
+// Empty values are removed since they are represented differently in the state and in packed API responses.
+func ruleGroupSnapshot(interval interface{}, rules interface{}) (interface{}, error) {
+	snapshotJSON, err := json.Marshal(map[string]interface{}{
+		"interval_seconds": interval,
+		"rule":             rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var snapshot interface{}
+	if err := json.Unmarshal(snapshotJSON, &snapshot); err != nil {
+		return nil, err
+	}
+	return pruneEmptyJSONValues(snapshot), nil
+}
+
+func pruneEmptyJSONValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			child = pruneEmptyJSONValues(child)
+			switch c := child.(type) {
+			case nil:
+				delete(v, k)
+				continue
+			case map[string]interface{}:
+				if len(c) == 0 {
+					delete(v, k)
+					continue
+				}
+			case []interface{}:
+				if len(c) == 0 {
+					delete(v, k)
+					continue
+				}
+			}
+			v[k] = child
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = pruneEmptyJSONValues(child)
+		}
+	}
+	return value
 }
 
 func deleteAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _, idWithoutOrg := OAPIClientFromExistingOrgResource(meta, data.Id())
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
-	folderUID, title, found := strings.Cut(idWithoutOrg, common.ResourceIDSeparator)
-	if !found {
-		return diag.Errorf("invalid ID %q", idWithoutOrg)
+	folderUID, title, err := splitRuleGroupID(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	// TODO use DeleteAlertRuleGroup method instead (available since Grafana 11)
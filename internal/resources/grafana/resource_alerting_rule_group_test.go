@@ -6,14 +6,169 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 )
 
+func TestFakeAlertRule_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_rule_group/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "name", "My Rule Group"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "interval_seconds", "240"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.#", "1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.name", "My Alert Rule 1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.data.#", "2"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_rule_group.my_alert_rule"),
+				),
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_rule_group/resource.tf", map[string]string{
+					"My Alert Rule 1": "My Alert Rule Renamed",
+				}),
+				Check: resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.name", "My Alert Rule Renamed"),
+			},
+			{
+				ResourceName:      "grafana_rule_group.my_alert_rule",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFakeAlertRule_resolveDatasources(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	_, err := client.Datasources.AddDataSource(&models.AddDataSourceCommand{UID: "prom-uid", Name: "Prometheus", Type: "prometheus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(datasource string) string {
+		return testutils.TestAccExampleWithReplace(t, "resources/grafana_rule_group/resource.tf", map[string]string{
+			"org_id           = 1": "org_id           = 1\n  resolve_datasources = true",
+			"PD8C576611E62080A":    datasource,
+		})
+	}
+	checkRemoteUID := func(s *terraform.State) error {
+		group, err := client.Provisioning.GetAlertRuleGroup("My Rule Group", s.RootModule().Resources["grafana_folder.rule_folder"].Primary.Attributes["uid"])
+		if err != nil {
+			return err
+		}
+		if uid := group.Payload.Rules[0].Data[0].DatasourceUID; uid != "prom-uid" {
+			return fmt.Errorf("expected the data source UID to be resolved to prom-uid, got %s", uid)
+		}
+		return nil
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("Prometheus"),
+				Check: resource.ComposeTestCheckFunc(
+					checkRemoteUID,
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.data.0.datasource_uid", "Prometheus"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_alert_rule", "rule.0.data.1.datasource_uid", "-100"),
+				),
+			},
+			{
+				Config:   config("Prometheus"),
+				PlanOnly: true,
+			},
+			{
+				Config:      config("Missing"),
+				ExpectError: regexp.MustCompile(`rule.0.data.0.datasource_uid: data source "Missing" not found`),
+			},
+			// The UIDs of the data sources created in the same apply are unknown during the plan
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_rule_group/resource.tf", map[string]string{
+					"org_id           = 1": "org_id           = 1\n  resolve_datasources = true",
+					`"PD8C576611E62080A"`:  "grafana_data_source.created.uid",
+				}) + `
+resource "grafana_data_source" "created" {
+  name = "Created"
+  type = "prometheus"
+  url  = "http://localhost:9090"
+}`,
+				Check: resource.TestCheckResourceAttrPair("grafana_rule_group.my_alert_rule", "rule.0.data.0.datasource_uid", "grafana_data_source.created", "uid"),
+			},
+		},
+	})
+}
+
+func TestFakeAlertRule_conflictPolicy(t *testing.T) {
+	for _, tc := range conflictPolicyTestCases {
+		t.Run(conflictPolicyTestName(tc.policy, tc.editRemotely), func(t *testing.T) {
+			fakeGrafana := fake.NewGrafana(t)
+			client := fake.Client(t, fakeGrafana).GrafanaAPI
+			_, err := client.Folders.CreateFolder(&models.CreateFolderCommand{UID: "rule-folder", Title: "Rules"})
+			require.NoError(t, err)
+			putRuleGroup := func(interval int64) {
+				forDuration := strfmt.Duration(0)
+				_, err := client.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+					WithFolderUID("rule-folder").
+					WithGroup("Conflict").
+					WithBody(&models.AlertRuleGroup{
+						Title:     "Conflict",
+						FolderUID: "rule-folder",
+						Interval:  interval,
+						Rules: []*models.ProvisionedAlertRule{{
+							UID:          "conflict-rule",
+							Title:        common.Ref("My Rule"),
+							Condition:    common.Ref("A"),
+							For:          &forDuration,
+							NoDataState:  common.Ref("NoData"),
+							ExecErrState: common.Ref("Alerting"),
+							Data: []*models.AlertQuery{{
+								RefID:             "A",
+								DatasourceUID:     "__expr__",
+								RelativeTimeRange: &models.RelativeTimeRange{},
+								Model:             map[string]interface{}{"type": "math", "expression": "1 > 0"},
+							}},
+						}},
+					}))
+				require.NoError(t, err)
+			}
+			putRuleGroup(60)
+
+			server := fake.ProviderServer(t, fakeGrafana)
+			state, identity := testutils.ReadResource(t, server, "grafana_rule_group", "1:rule-folder:Conflict")
+			if tc.editRemotely {
+				putRuleGroup(120)
+			}
+
+			diags := testutils.ApplyResourceChange(t, server, "grafana_rule_group", state, identity, map[string]tftypes.Value{
+				"interval_seconds": tftypes.NewValue(tftypes.Number, 300),
+				"conflict_policy":  tftypes.NewValue(tftypes.String, tc.policy),
+			})
+			checkConflictDiagnostics(t, diags, tc.expectedSeverity, "interval_seconds changed")
+
+			resp, err := client.Provisioning.GetAlertRuleGroup("Conflict", "rule-folder")
+			require.NoError(t, err)
+			expectedInterval := int64(120)
+			if tc.expectOverwrite {
+				expectedInterval = 300
+			}
+			require.Equal(t, expectedInterval, resp.Payload.Interval)
+		})
+	}
+}
+
 func TestAccAlertRule_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
 
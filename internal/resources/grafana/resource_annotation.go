@@ -84,7 +84,12 @@ func resourceAnnotation() *common.Resource {
 		"grafana_annotation",
 		orgResourceIDInt("id"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listAnnotations))
+	).WithLister(listerFunctionOrgResource(listAnnotations)).
+		WithListTags(annotationTags)
+}
+
+func annotationTags(d *schema.ResourceData) []string {
+	return common.SetToStringSlice(d.Get("tags").(*schema.Set))
 }
 
 func listAnnotations(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -5,9 +5,13 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
@@ -26,6 +30,8 @@ func resourceDashboard() *common.Resource {
 		Description: `
 Manages Grafana dashboards.
 
+Dashboards can be imported by title, with ` + "`dashboard:title=<title>`" + `, or ` + "`dashboard:title=<title>@<folder path>`" + ` if the title isn't unique. A ` + "`@`" + ` within the title must be escaped as ` + "`\\@`" + `.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard/)
 `,
@@ -35,8 +41,25 @@ Manages Grafana dashboards.
 		UpdateContext: UpdateDashboard,
 		DeleteContext: DeleteDashboard,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithLookup("dashboard", resolveDashboardTitle),
 		},
+		CustomizeDiff: customdiff.All(
+			warnDashboardConfigChanges,
+			checkDatasourceRefs(func(d *schema.ResourceDiff, refs *datasourceRefs) error {
+				config := d.GetRawConfig().GetAttr("config_json")
+				if !config.IsKnown() || config.IsNull() {
+					return nil
+				}
+				model, err := UnmarshalDashboardConfigJSON(config.AsString())
+				if err != nil {
+					return nil
+				}
+				if err := refs.resolveModel(model); err != nil {
+					return fmt.Errorf("config_json: %w", err)
+				}
+				return nil
+			}),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
@@ -78,7 +101,34 @@ Manages Grafana dashboards.
 				Required:     true,
 				StateFunc:    NormalizeDashboardConfigJSON,
 				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Description:  "The complete dashboard model JSON. When it changes, the changes to the model are listed in a warning of the plan, ex: `panels[0].targets[0].expr changed`.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					ignorePaths := dashboardIgnorePaths(d)
+					if len(ignorePaths) == 0 {
+						return false
+					}
+					config := d.GetRawConfig().GetAttr("config_json")
+					if !config.IsKnown() || config.IsNull() {
+						return false
+					}
+					return old == normalizeDashboardConfigJSON(config.AsString(), ignorePaths)
+				},
+			},
+			"config_json_ignore_paths": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+						if err := common.ValidateJSONPath(i.(string)); err != nil {
+							return nil, []error{err}
+						}
+						return nil, nil
+					},
+				},
+				Description: "Paths of the dashboard model that are edited in Grafana rather than managed by Terraform, ex: `time`, `refresh` or `templating.list[*].current`. " +
+					"They are ignored when comparing `config_json` with the dashboard in Grafana, and their values in Grafana are kept when the dashboard is updated. " +
+					"Object keys are separated by dots, and `[*]` matches all the elements of an array.",
 			},
 			"overwrite": {
 				Type:        schema.TypeBool,
@@ -90,6 +140,8 @@ Manages Grafana dashboards.
 				Optional:    true,
 				Description: "Set a commit message for the version history.",
 			},
+			"conflict_policy":     conflictPolicyAttribute(conflictPolicyOverwrite),
+			"resolve_datasources": resolveDatasourcesAttribute("`config_json` (panels, queries, variables and annotations)"),
 		},
 		SchemaVersion: 1, // The state upgrader was removed in v2. To upgrade, users can first upgrade to the last v1 release, apply, then upgrade to v2.
 	}
@@ -99,7 +151,9 @@ Manages Grafana dashboards.
 		"grafana_dashboard",
 		orgResourceIDString("uid"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listDashboards))
+	).WithLister(listerFunctionOrgResource(listDashboards)).
+		WithListTags(dashboardTags).
+		WithImportLookups("dashboard:title={{ title }}", "dashboard:title={{ title }}@{{ folderPath }}")
 }
 
 func listDashboards(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -120,6 +174,88 @@ func listDashboardOrFolder(client *goapi.GrafanaHTTPAPI, orgID int64, searchType
 	return uids, nil
 }
 
+// dashboardTags returns the tags of the dashboard model.
+func dashboardTags(d *schema.ResourceData) []string {
+	model, err := UnmarshalDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return nil
+	}
+	tags, _ := model["tags"].([]interface{})
+	return common.ListToStringSlice(tags)
+}
+
+// maxDashboardConfigChanges is the maximum number of changes listed in the plan warning of a dashboard.
+const maxDashboardConfigChanges = 50
+
+// warnDashboardConfigChanges adds a warning to the plan listing the changes to the dashboard model, ex: `panels[3].targets[0].expr changed`.
+// The plan only shows `config_json` as a replaced string, or as a replaced hash with `store_dashboard_sha256`, which is hard to review.
+// When the state only holds the hash of the model, the current model is read from Grafana.
+func warnDashboardConfigChanges(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("config_json") {
+		return nil
+	}
+	newConfig := d.GetRawConfig().GetAttr("config_json")
+	if !newConfig.IsKnown() || newConfig.IsNull() {
+		return nil
+	}
+	newModel, err := UnmarshalDashboardConfigJSON(newConfig.AsString())
+	if err != nil {
+		return nil
+	}
+	normalizeDashboardModel(newModel)
+
+	oldConfig, _ := d.GetChange("config_json")
+	oldModel, err := UnmarshalDashboardConfigJSON(oldConfig.(string))
+	if err != nil {
+		if oldModel = remoteDashboardModel(d, meta, newModel); oldModel == nil {
+			return nil
+		}
+	}
+	normalizeDashboardModel(oldModel)
+	if _, ok := newModel["uid"]; !ok {
+		delete(oldModel, "uid")
+	}
+	for _, path := range dashboardIgnorePaths(d) {
+		common.RemoveJSONPath(oldModel, path)
+		common.RemoveJSONPath(newModel, path)
+	}
+
+	if changes := common.JSONChanges(oldModel, newModel); len(changes) > 0 {
+		common.AddPlanWarning(ctx,
+			fmt.Sprintf("Dashboard %s changes", d.Id()),
+			fmt.Sprintf("The following changes will be made to the dashboard model:\n%s", common.SummarizeJSONChanges(changes, maxDashboardConfigChanges)),
+			cty.GetAttrPath("config_json"),
+		)
+	}
+	return nil
+}
+
+// remoteDashboardModel reads the model of a dashboard from Grafana, or returns nil if it can't be read.
+// With `resolve_datasources`, the data source references that match the configured model are kept as configured.
+func remoteDashboardModel(d *schema.ResourceDiff, meta interface{}, configuredModel map[string]interface{}) map[string]interface{} {
+	// The client of the provider can't read dashboards of another instance
+	if _, ok := d.GetOk("grafana_connection"); ok {
+		return nil
+	}
+	if client, ok := meta.(*common.Client); !ok || client.GrafanaAPI == nil {
+		return nil
+	}
+	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
+	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		return nil
+	}
+	model, _ := resp.Payload.Dashboard.(map[string]interface{})
+	if d.Get("resolve_datasources").(bool) {
+		refs, err := newDatasourceRefs(client)
+		if err != nil {
+			return nil
+		}
+		refs.unresolveModel(model, configuredModel)
+	}
+	return model
+}
+
 func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
@@ -127,12 +263,16 @@ func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := resolveModelDatasources(client, d, dashboard.Dashboard); err != nil {
+		return diag.FromErr(err)
+	}
 	resp, err := client.Dashboards.PostDashboard(&dashboard)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating dashboard", err, nil)
 	}
 	d.SetId(MakeOrgResourceID(orgID, *resp.Payload.UID))
-	return ReadDashboard(ctx, d, meta)
+	diags := annotateDashboardChange(client, dashboard, resp.Payload, 0)
+	return append(diags, ReadDashboard(ctx, d, meta)...)
 }
 
 func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -145,6 +285,7 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 	}
 	dashboard := resp.Payload
 	model := dashboard.Dashboard.(map[string]interface{})
+	stateVersion := d.Get("version").(int)
 
 	d.SetId(MakeOrgResourceID(orgID, uid))
 	d.Set("org_id", strconv.FormatInt(orgID, 10))
@@ -180,8 +321,15 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 			delete(remoteDashJSON, "uid")
 		}
 	}
-	configJSON = NormalizeDashboardConfigJSON(remoteDashJSON)
-	d.Set("config_json", configJSON)
+	if err := unresolveModelDatasources(client, d, "config_json", remoteDashJSON); err != nil {
+		return diag.FromErr(err)
+	}
+	// With resolved data sources and a hashed model, the configured references are unknown.
+	// The model is unchanged since it was saved by Terraform if its version is the same, so the stored hash is kept.
+	keepHash := d.Get("resolve_datasources").(bool) && common.SHA256Regexp.MatchString(configJSON) && stateVersion == int(model["version"].(float64))
+	if !keepHash {
+		d.Set("config_json", normalizeDashboardConfigJSON(remoteDashJSON, dashboardIgnorePaths(d)))
+	}
 
 	return nil
 }
@@ -195,12 +343,120 @@ func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 	dashboard.Dashboard.(map[string]interface{})["id"] = d.Get("dashboard_id").(int)
 	dashboard.Overwrite = true
+	if err := resolveModelDatasources(client, d, dashboard.Dashboard); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the values edited in Grafana
+	if ignorePaths := dashboardIgnorePaths(d); len(ignorePaths) > 0 {
+		_, uid := SplitOrgResourceID(d.Id())
+		resp, err := client.Dashboards.GetDashboardByUID(uid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, path := range ignorePaths {
+			common.CopyJSONPath(dashboard.Dashboard, resp.Payload.Dashboard, path)
+		}
+	}
+
+	var diags diag.Diagnostics
+	policy := getConflictPolicy(d, conflictPolicyOverwrite)
+	if policy != conflictPolicyOverwrite {
+		if diags = checkDashboardConflict(client, d, policy); diags.HasError() {
+			return diags
+		}
+		if policy == conflictPolicyFail {
+			// Let Grafana reject the save if the dashboard was modified after the check above
+			dashboard.Dashboard.(map[string]interface{})["version"] = d.Get("version").(int)
+			dashboard.Overwrite = false
+		}
+	}
+
 	resp, err := client.Dashboards.PostDashboard(&dashboard)
 	if err != nil {
-		return diag.FromErr(err)
+		if apiErr, ok := err.(runtime.ClientResponseStatus); ok && apiErr.IsCode(http.StatusPreconditionFailed) && policy == conflictPolicyFail {
+			return append(diags, conflictDiagnostics(policy, "dashboard", d.Id(), "the version was changed while saving", "config_json", nil)...)
+		}
+		return append(diags, common.APIErrorDiagnostics("error updating dashboard", err, nil)...)
 	}
 	d.SetId(MakeOrgResourceID(orgID, *resp.Payload.UID))
-	return ReadDashboard(ctx, d, meta)
+	diags = append(diags, annotateDashboardChange(client, dashboard, resp.Payload, int64(d.Get("version").(int)))...)
+	return append(diags, ReadDashboard(ctx, d, meta)...)
+}
+
+// annotateDashboardChange annotates a saved dashboard with its version delta, when `annotate_changes` is enabled.
+func annotateDashboardChange(client *goapi.GrafanaHTTPAPI, dashboard models.SaveDashboardCommand, saved *models.PostDashboardOKBody, previousVersion int64) diag.Diagnostics {
+	title, _ := dashboard.Dashboard.(map[string]interface{})["title"].(string)
+	version := int64(0)
+	if saved.Version != nil {
+		version = *saved.Version
+	}
+	return annotateChange(client, "grafana_dashboard", *saved.UID,
+		fmt.Sprintf("dashboard %q changed from version %d to %d", title, previousVersion, version),
+		fmt.Sprintf("version:%d->%d", previousVersion, version),
+	)
+}
+
+// checkDashboardConflict compares the version of the dashboard in the state with the remote version.
+// If they differ, the dashboard was modified outside of Terraform and a diagnostic is returned according to the conflict policy.
+func checkDashboardConflict(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, policy string) diag.Diagnostics {
+	_, uid := SplitOrgResourceID(d.Id())
+	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteModel, ok := resp.Payload.Dashboard.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("unexpected dashboard model type: %T", resp.Payload.Dashboard)
+	}
+
+	storedVersion := int64(d.Get("version").(int))
+	remoteVersionFloat, _ := remoteModel["version"].(float64)
+	remoteVersion := int64(remoteVersionFloat)
+	if storedVersion == remoteVersion {
+		return nil
+	}
+
+	stateConfigJSON, _ := d.GetChange("config_json")
+	return conflictDiagnostics(
+		policy,
+		"dashboard",
+		d.Id(),
+		fmt.Sprintf("version %d in state, version %d in Grafana", storedVersion, remoteVersion),
+		"config_json",
+		dashboardRemoteChanges(stateConfigJSON.(string), remoteModel, dashboardIgnorePaths(d)),
+	)
+}
+
+// dashboardRemoteChanges lists the changes between the dashboard model stored in the state and the remote one.
+// It returns nil if the stored model is unknown (for example, when only its SHA256 hash is stored).
+func dashboardRemoteChanges(stateConfigJSON string, remoteModel map[string]interface{}, ignorePaths []string) []string {
+	if stateConfigJSON == "" || common.SHA256Regexp.MatchString(stateConfigJSON) {
+		return nil
+	}
+	stateModel, err := UnmarshalDashboardConfigJSON(stateConfigJSON)
+	if err != nil {
+		return nil
+	}
+
+	// Work on a copy, the normalization modifies the model in place
+	remoteJSON, err := json.Marshal(remoteModel)
+	if err != nil {
+		return nil
+	}
+	remoteModelCopy, err := UnmarshalDashboardConfigJSON(string(remoteJSON))
+	if err != nil {
+		return nil
+	}
+	normalizeDashboardModel(remoteModelCopy)
+	if _, ok := stateModel["uid"]; !ok {
+		delete(remoteModelCopy, "uid")
+	}
+	for _, path := range ignorePaths {
+		common.RemoveJSONPath(remoteModelCopy, path)
+	}
+
+	return common.JSONChanges(stateModel, remoteModelCopy)
 }
 
 func DeleteDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -217,6 +473,9 @@ func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error)
 		Message:   d.Get("message").(string),
 		FolderUID: folderID,
 	}
+	if dashboard.Message == "" && AnnotateChanges {
+		dashboard.Message = ChangeMessage
+	}
 
 	configJSON := d.Get("config_json").(string)
 	dashboardJSON, err := UnmarshalDashboardConfigJSON(configJSON)
@@ -228,6 +487,11 @@ func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error)
 	return dashboard, nil
 }
 
+// dashboardIgnorePaths returns the `config_json_ignore_paths` of a dashboard.
+func dashboardIgnorePaths(d interface{ Get(string) interface{} }) []string {
+	return common.ListToStringSlice(d.Get("config_json_ignore_paths").([]interface{}))
+}
+
 // UnmarshalDashboardConfigJSON is a convenience func for unmarshalling
 // `config_json` field.
 func UnmarshalDashboardConfigJSON(configJSON string) (map[string]interface{}, error) {
@@ -260,6 +524,11 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 //     be managed in code.
 //   - `version`: is incremented by Grafana each time a dashboard changes.
 func NormalizeDashboardConfigJSON(config interface{}) string {
+	return normalizeDashboardConfigJSON(config, nil)
+}
+
+// normalizeDashboardConfigJSON is NormalizeDashboardConfigJSON, also removing the given paths (see `config_json_ignore_paths`) from the model.
+func normalizeDashboardConfigJSON(config interface{}, ignorePaths []string) string {
 	var dashboardJSON map[string]interface{}
 	switch c := config.(type) {
 	case map[string]interface{}:
@@ -272,6 +541,23 @@ func NormalizeDashboardConfigJSON(config interface{}) string {
 		}
 	}
 
+	normalizeDashboardModel(dashboardJSON)
+	for _, path := range ignorePaths {
+		common.RemoveJSONPath(dashboardJSON, path)
+	}
+	j, _ := json.Marshal(dashboardJSON)
+
+	if StoreDashboardSHA256 {
+		configHash := sha256.Sum256(j)
+		return fmt.Sprintf("%x", configHash[:])
+	} else {
+		return string(j)
+	}
+}
+
+// normalizeDashboardModel removes the fields managed by Grafana from a dashboard model, in place.
+// See NormalizeDashboardConfigJSON for details.
+func normalizeDashboardModel(dashboardJSON map[string]interface{}) {
 	delete(dashboardJSON, "id")
 	delete(dashboardJSON, "version")
 
@@ -291,13 +577,4 @@ func NormalizeDashboardConfigJSON(config interface{}) string {
 			}
 		}
 	}
-
-	j, _ := json.Marshal(dashboardJSON)
-
-	if StoreDashboardSHA256 {
-		configHash := sha256.Sum256(j)
-		return fmt.Sprintf("%x", configHash[:])
-	} else {
-		return string(j)
-	}
 }
@@ -42,6 +42,8 @@ type resourceDashboardPermissionItemModel struct {
 	User         types.String `tfsdk:"user"`
 	Permission   types.String `tfsdk:"permission"`
 	DashboardUID types.String `tfsdk:"dashboard_uid"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -53,6 +55,8 @@ func (m *resourceDashboardPermissionItemModel) ToBase() *resourcePermissionItemB
 		Team:       m.Team,
 		User:       m.User,
 		Permission: m.Permission,
+
+		GrafanaConnection: m.GrafanaConnection,
 	}
 }
 
@@ -64,6 +68,7 @@ func (m *resourceDashboardPermissionItemModel) SetFromBase(base *resourcePermiss
 	m.Team = base.Team
 	m.User = base.User
 	m.Permission = base.Permission
+	m.GrafanaConnection = base.GrafanaConnection
 }
 
 type resourceDashboardPermissionItem struct{ resourcePermissionBase }
@@ -84,11 +89,15 @@ func (r *resourceDashboardPermissionItem) Schema(ctx context.Context, req resour
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(true),
+		},
 	}
 }
 
 func (r *resourceDashboardPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.dashboardQuery)
+	connection, id := splitConnectionImportID(req.ID)
+	readData, diags := r.readItem(ctx, connection, id, r.dashboardQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -111,7 +120,7 @@ func (r *resourceDashboardPermissionItem) Create(ctx context.Context, req resour
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DashboardUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -126,7 +135,7 @@ func (r *resourceDashboardPermissionItem) Read(ctx context.Context, req resource
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.dashboardQuery)
+	readData, diags := r.readItem(ctx, data.GrafanaConnection, data.ID.ValueString(), r.dashboardQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -149,7 +158,7 @@ func (r *resourceDashboardPermissionItem) Update(ctx context.Context, req resour
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DashboardUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -164,7 +173,7 @@ func (r *resourceDashboardPermissionItem) Delete(ctx context.Context, req resour
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.DashboardUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.DashboardUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
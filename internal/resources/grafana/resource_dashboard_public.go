@@ -88,7 +88,7 @@ Manages Grafana public dashboards.
 		"grafana_dashboard_public",
 		resourcePublicDashboardID,
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "10.2.0"})
 }
 
 func CreatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
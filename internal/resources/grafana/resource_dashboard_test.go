@@ -1,20 +1,400 @@
 package grafana_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/grafana/grafana-openapi-client-go/client/annotations"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
+func TestFakeDashboard_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "id", "1:my-dashboard-uid"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "folder", "my-folder-uid"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "url", grafana.URL()+"/d/my-dashboard-uid/my-dashboard"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_dashboard.test"),
+				),
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_dashboard/resource.tf", map[string]string{
+					"My Dashboard": "My Dashboard Updated",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "url", grafana.URL()+"/d/my-dashboard-uid/my-dashboard-updated"),
+				),
+			},
+			{
+				ResourceName:            "grafana_dashboard.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"message"},
+			},
+		},
+	})
+}
+
+func TestFakeDashboard_importByTitle(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	// The title contains a `@`, which is also the separator of the folder path
+	config := `
+resource "grafana_folder" "prod" {
+  title = "Prod"
+}
+
+resource "grafana_dashboard" "test" {
+  folder      = grafana_folder.prod.uid
+  config_json = jsonencode({
+    uid   = "alerts-prod"
+    title = "Alerts @ prod"
+  })
+}
+
+resource "grafana_dashboard" "other" {
+  config_json = jsonencode({
+    uid   = "alerts-other"
+    title = "Alerts @ other"
+  })
+}`
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:            "grafana_dashboard.test",
+				ImportState:             true,
+				ImportStateId:           `dashboard:title=Alerts \@ prod`,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"message"},
+			},
+			{
+				ResourceName:            "grafana_dashboard.test",
+				ImportState:             true,
+				ImportStateId:           `dashboard:title=Alerts \@ prod@Prod`,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"message"},
+			},
+			{
+				// Without the escape, the title is split at the `@`
+				ResourceName:  "grafana_dashboard.test",
+				ImportState:   true,
+				ImportStateId: `dashboard:title=Alerts @ prod`,
+				ExpectError:   regexp.MustCompile(`folder with title " prod" not found`),
+			},
+		},
+	})
+}
+
+func TestFakeDashboard_annotateChanges(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	t.Setenv("GRAFANA_ANNOTATE_CHANGES", "true")
+	t.Setenv("GRAFANA_CHANGE_MESSAGE", "Commit abc123")
+
+	checkAnnotations := func(expectedTexts ...string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			params := annotations.NewGetAnnotationsParams().WithTags([]string{"terraform", "grafana_dashboard"})
+			resp, err := fake.Client(t, grafana).GrafanaAPI.Annotations.GetAnnotations(params)
+			if err != nil {
+				return err
+			}
+			var texts []string
+			for _, a := range resp.Payload {
+				if a.DashboardUID != "my-dashboard-uid" {
+					return fmt.Errorf("expected the annotation on the dashboard, got %q", a.DashboardUID)
+				}
+				texts = append(texts, a.Text)
+			}
+			if !slices.Equal(texts, expectedTexts) {
+				return fmt.Errorf("expected annotations %q, got %q", expectedTexts, texts)
+			}
+			return nil
+		}
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard/resource.tf"),
+				Check: checkAnnotations(
+					"Terraform: dashboard \"My Dashboard\" changed from version 0 to 1\n\nCommit abc123",
+				),
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_dashboard/resource.tf", map[string]string{
+					"My Dashboard": "My Dashboard Updated",
+				}),
+				Check: checkAnnotations(
+					"Terraform: dashboard \"My Dashboard\" changed from version 0 to 1\n\nCommit abc123",
+					"Terraform: dashboard \"My Dashboard Updated\" changed from version 1 to 2\n\nCommit abc123",
+				),
+			},
+		},
+	})
+}
+
+func TestFakeDashboard_planWarning(t *testing.T) {
+	oldConfig := `{"uid":"my-dashboard-uid","title":"My Dashboard","panels":[{"type":"timeseries","targets":[{"expr":"up"}]}],"templating":{"list":[]}}`
+	newConfig := `{"uid":"my-dashboard-uid","title":"My Dashboard","panels":[{"type":"timeseries","targets":[{"expr":"up == 1"}]}],"templating":{"list":[{"name":"env","type":"custom"}]}}`
+	expectedDetail := "The following changes will be made to the dashboard model:\n" +
+		"  - panels[0].targets[0].expr changed\n" +
+		"  - templating.list[env] added\n"
+
+	for _, useSHA256 := range []bool{false, true} {
+		t.Run(fmt.Sprintf("useSHA256=%t", useSHA256), func(t *testing.T) {
+			t.Setenv("GRAFANA_STORE_DASHBOARD_SHA256", fmt.Sprintf("%t", useSHA256))
+			// The setting is global, it's set when configuring the provider
+			t.Cleanup(func() { grafana.StoreDashboardSHA256 = false })
+			fakeGrafana := fake.NewGrafana(t)
+			// With SHA256, the current model is read from Grafana
+			var model map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(oldConfig), &model))
+			_, err := fake.Client(t, fakeGrafana).GrafanaAPI.Dashboards.PostDashboard(&models.SaveDashboardCommand{Dashboard: model})
+			require.NoError(t, err)
+
+			server := fake.ProviderServer(t, fakeGrafana)
+			state := map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "1:my-dashboard-uid"),
+				"org_id":      tftypes.NewValue(tftypes.String, "1"),
+				"config_json": tftypes.NewValue(tftypes.String, grafana.NormalizeDashboardConfigJSON(oldConfig)),
+			}
+
+			diags := testutils.PlanResourceChange(t, server, "grafana_dashboard", state, map[string]tftypes.Value{
+				"config_json": tftypes.NewValue(tftypes.String, newConfig),
+			})
+			require.Len(t, diags, 1)
+			require.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+			require.Equal(t, "Dashboard 1:my-dashboard-uid changes", diags[0].Summary)
+			require.Equal(t, expectedDetail, diags[0].Detail)
+
+			// Formatting changes aren't reported
+			diags = testutils.PlanResourceChange(t, server, "grafana_dashboard", state, map[string]tftypes.Value{
+				"config_json": tftypes.NewValue(tftypes.String, strings.ReplaceAll(oldConfig, ",", ", ")),
+			})
+			require.Empty(t, diags)
+		})
+	}
+}
+
+func TestFakeDashboard_conflictPolicy(t *testing.T) {
+	for _, tc := range conflictPolicyTestCases {
+		t.Run(conflictPolicyTestName(tc.policy, tc.editRemotely), func(t *testing.T) {
+			fakeGrafana := fake.NewGrafana(t)
+			client := fake.Client(t, fakeGrafana).GrafanaAPI
+			saveDashboard := func(title string) {
+				_, err := client.Dashboards.PostDashboard(&models.SaveDashboardCommand{
+					Dashboard: map[string]interface{}{"uid": "conflict", "title": title},
+					Overwrite: true,
+				})
+				require.NoError(t, err)
+			}
+			saveDashboard("Initial")
+
+			server := fake.ProviderServer(t, fakeGrafana)
+			state, identity := testutils.ReadResource(t, server, "grafana_dashboard", "1:conflict")
+			if tc.editRemotely {
+				saveDashboard("Edited in Grafana")
+			}
+
+			diags := testutils.ApplyResourceChange(t, server, "grafana_dashboard", state, identity, map[string]tftypes.Value{
+				"config_json":     tftypes.NewValue(tftypes.String, `{"uid":"conflict","title":"From Terraform"}`),
+				"conflict_policy": tftypes.NewValue(tftypes.String, tc.policy),
+			})
+			checkConflictDiagnostics(t, diags, tc.expectedSeverity, "title changed")
+
+			resp, err := client.Dashboards.GetDashboardByUID("conflict")
+			require.NoError(t, err)
+			expectedTitle := "Edited in Grafana"
+			if tc.expectOverwrite {
+				expectedTitle = "From Terraform"
+			}
+			require.Equal(t, expectedTitle, resp.Payload.Dashboard.(map[string]interface{})["title"])
+		})
+	}
+}
+
+func TestFakeDashboard_ignorePaths(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	client := fake.Client(t, fakeGrafana).GrafanaAPI
+
+	config := func(title string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid     = "ignore-paths"
+    title   = %q
+    time    = { from = "now-6h", to = "now" }
+    refresh = "5m"
+    templating = {
+      list = [{ name = "env", type = "custom", current = { value = "prod" } }]
+    }
+  })
+  config_json_ignore_paths = ["time", "templating.list[*].current"]
+}`, title)
+	}
+	checkRemote := func(title, from, current string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			resp, err := client.Dashboards.GetDashboardByUID("ignore-paths")
+			if err != nil {
+				return err
+			}
+			model := resp.Payload.Dashboard.(map[string]interface{})
+			templateVar := model["templating"].(map[string]interface{})["list"].([]interface{})[0].(map[string]interface{})
+			got := []interface{}{model["title"], model["time"].(map[string]interface{})["from"], templateVar["current"].(map[string]interface{})["value"]}
+			if expected := []interface{}{title, from, current}; !slices.Equal(got, expected) {
+				return fmt.Errorf("expected title, time and variable %v, got %v", expected, got)
+			}
+			return nil
+		}
+	}
+
+	fake.UnitTest(t, fakeGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("My Dashboard"),
+				Check: resource.ComposeTestCheckFunc(
+					checkRemote("My Dashboard", "now-6h", "prod"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", `{"refresh":"5m","templating":{"list":[{"name":"env","type":"custom"}]},"title":"My Dashboard","uid":"ignore-paths"}`),
+				),
+			},
+			{
+				// Edit the ignored paths in Grafana: no changes are planned
+				PreConfig: func() {
+					resp, err := client.Dashboards.GetDashboardByUID("ignore-paths")
+					require.NoError(t, err)
+					model := resp.Payload.Dashboard.(map[string]interface{})
+					model["time"] = map[string]interface{}{"from": "now-1h", "to": "now"}
+					model["templating"].(map[string]interface{})["list"].([]interface{})[0].(map[string]interface{})["current"] = map[string]interface{}{"value": "dev"}
+					_, err = client.Dashboards.PostDashboard(&models.SaveDashboardCommand{Dashboard: model, Overwrite: true})
+					require.NoError(t, err)
+				},
+				Config:   config("My Dashboard"),
+				PlanOnly: true,
+			},
+			{
+				// The values edited in Grafana are kept on update
+				Config: config("My Dashboard Updated"),
+				Check:  checkRemote("My Dashboard Updated", "now-1h", "dev"),
+			},
+		},
+	})
+}
+
+func TestFakeDashboard_resolveDatasources(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	client := fake.Client(t, fakeGrafana).GrafanaAPI
+	for _, ds := range []*models.AddDataSourceCommand{
+		{UID: "prom-uid", Name: "Prometheus", Type: "prometheus"},
+		{UID: "loki-uid", Name: "Loki", Type: "loki"},
+	} {
+		_, err := client.Datasources.AddDataSource(ds)
+		require.NoError(t, err)
+	}
+
+	config := func(datasource string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "resolve-datasources"
+    title = "My Dashboard"
+    panels = [{
+      type       = "timeseries"
+      datasource = %q
+      targets = [
+        { refId = "A", datasource = { type = "loki", uid = "uid-in-another-instance" } },
+        { refId = "B", datasource = { type = "__expr__", uid = "__expr__" } },
+      ]
+    }]
+  })
+  resolve_datasources = true
+}`, datasource)
+	}
+
+	fake.UnitTest(t, fakeGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("Prometheus"),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						resp, err := client.Dashboards.GetDashboardByUID("resolve-datasources")
+						if err != nil {
+							return err
+						}
+						panel := resp.Payload.Dashboard.(map[string]interface{})["panels"].([]interface{})[0].(map[string]interface{})
+						targets := panel["targets"].([]interface{})
+						got, err := json.Marshal([]interface{}{panel["datasource"], targets[0].(map[string]interface{})["datasource"], targets[1].(map[string]interface{})["datasource"]})
+						if err != nil {
+							return err
+						}
+						if expected := `[{"type":"prometheus","uid":"prom-uid"},{"type":"loki","uid":"loki-uid"},{"type":"__expr__","uid":"__expr__"}]`; string(got) != expected {
+							return fmt.Errorf("expected data sources %s, got %s", expected, got)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttrWith("grafana_dashboard.test", "config_json", func(value string) error {
+						if !strings.Contains(value, `"datasource":"Prometheus"`) || !strings.Contains(value, `"uid":"uid-in-another-instance"`) {
+							return fmt.Errorf("expected the configured data source references in the state, got %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   config("Prometheus"),
+				PlanOnly: true,
+			},
+			{
+				Config:      config("Missing"),
+				ExpectError: regexp.MustCompile(`config_json: data source "Missing" not found`),
+			},
+		},
+	})
+}
+
+func TestFakeDashboard_resolveDatasourcesPlanError(t *testing.T) {
+	server := fake.ProviderServer(t, fake.NewGrafana(t))
+
+	diags := testutils.PlanResourceChange(t, server, "grafana_dashboard", nil, map[string]tftypes.Value{
+		"config_json":         tftypes.NewValue(tftypes.String, `{"title":"My Dashboard","panels":[{"type":"timeseries","datasource":"Missing"}]}`),
+		"resolve_datasources": tftypes.NewValue(tftypes.Bool, true),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, tfprotov5.DiagnosticSeverityError, diags[0].Severity)
+	require.Contains(t, diags[0].Summary, `config_json: data source "Missing" not found`)
+
+	// The model isn't checked while it's unknown, ex: when it references a data source created in the same apply
+	diags = testutils.PlanResourceChange(t, server, "grafana_dashboard", nil, map[string]tftypes.Value{
+		"config_json":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"resolve_datasources": tftypes.NewValue(tftypes.Bool, true),
+	})
+	require.Empty(t, diags)
+}
+
 func TestAccDashboard_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -202,6 +582,13 @@ func TestAccDashboard_folder_uid(t *testing.T) {
 				ResourceName:      "grafana_dashboard.test_folder",
 				ImportStateVerify: true,
 			},
+			// Test import using the title and folder path
+			{
+				ImportState:       true,
+				ResourceName:      "grafana_dashboard.test_folder",
+				ImportStateId:     fmt.Sprintf("dashboard:title=%[1]s@%[1]s-2", uid),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -27,12 +27,17 @@ func resourceDataSource() *common.Resource {
 
 The required arguments for this resource vary depending on the type of data
 source selected (via the 'type' argument).
+
+The configuration of Prometheus, Loki, Tempo, Elasticsearch, PostgreSQL, MySQL, CloudWatch and InfluxDB data sources
+can be set with typed blocks, which are validated, rather than with ` + "`json_data_encoded`" + ` and ` + "`secure_json_data_encoded`" + `.
+The fields of the blocks are merged into the JSON data, so both can be used together, as long as they don't set the same keys.
 `,
 
 		CreateContext: CreateDataSource,
 		UpdateContext: UpdateDataSource,
 		DeleteContext: DeleteDataSource,
 		ReadContext:   ReadDataSource,
+		CustomizeDiff: customizeDatasourceHealthCheckDiff,
 		SchemaVersion: 1,
 
 		Importer: &schema.ResourceImporter{
@@ -52,7 +57,7 @@ source selected (via the 'type' argument).
 			},
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: withDatasourceHealthCheck(withDatasourceConfigBlocks(map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"uid": {
 				Type:        schema.TypeString,
@@ -119,7 +124,7 @@ source selected (via the 'type' argument).
 			},
 			"json_data_encoded":        datasourceJSONDataAttribute(),
 			"secure_json_data_encoded": datasourceSecureJSONDataAttribute(),
-		},
+		})),
 	}
 
 	return common.NewLegacySDKResource(
@@ -227,7 +232,10 @@ func CreateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 
 	d.SetId(MakeOrgResourceID(orgID, resp.Payload.Datasource.UID))
-	return ReadDataSource(ctx, d, meta)
+	if diags := ReadDataSource(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	return checkDatasourceHealth(ctx, d, client, resp.Payload.Datasource.UID)
 }
 
 // UpdateDataSource updates a Grafana datasource
@@ -253,9 +261,11 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		User:            dataSource.User,
 		WithCredentials: dataSource.WithCredentials,
 	}
-	_, err = client.Datasources.UpdateDataSourceByUID(idStr, &body)
+	if _, err = client.Datasources.UpdateDataSourceByUID(idStr, &body); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return diag.FromErr(err)
+	return checkDatasourceHealth(ctx, d, client, idStr)
 }
 
 // ReadDataSource reads a Grafana datasource
@@ -299,6 +309,7 @@ func datasourceToState(d *schema.ResourceData, dataSource *models.DataSource) di
 
 func datasourceConfigToState(d *schema.ResourceData, dataSource *models.DataSource) diag.Diagnostics {
 	gottenJSONData, gottenHeaders := removeHeadersFromJSONData(dataSource.JSONData.(map[string]interface{}))
+	datasourceConfigBlocksToState(d, gottenJSONData)
 	encodedJSONData, err := json.Marshal(gottenJSONData)
 	if err != nil {
 		return diag.Errorf("Failed to marshal JSON data: %s", err)
@@ -320,7 +331,7 @@ func datasourceConfigToState(d *schema.ResourceData, dataSource *models.DataSour
 }
 
 func stateToDatasource(d *schema.ResourceData) (*models.AddDataSourceCommand, error) {
-	jd, sd, err := stateToDatasourceConfig(d)
+	jd, sd, err := stateToDatasourceConfig(d, d.Get("type").(string))
 	if err != nil {
 		return nil, err
 	}
@@ -341,8 +352,8 @@ func stateToDatasource(d *schema.ResourceData) (*models.AddDataSourceCommand, er
 	}, err
 }
 
-// stateToDatasourceConfig extracts the json data from the config
-func stateToDatasourceConfig(d *schema.ResourceData) (map[string]interface{}, map[string]string, error) {
+// stateToDatasourceConfig extracts the json data from the config, including the typed blocks of the given data source type
+func stateToDatasourceConfig(d *schema.ResourceData, datasourceType string) (map[string]interface{}, map[string]string, error) {
 	httpHeaders := make(map[string]string)
 	for key, value := range d.Get("http_headers").(map[string]interface{}) {
 		httpHeaders[key] = fmt.Sprintf("%v", value)
@@ -356,6 +367,9 @@ func stateToDatasourceConfig(d *schema.ResourceData) (map[string]interface{}, ma
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := datasourceConfigBlocksToJSONData(d, datasourceType, jd, sd); err != nil {
+		return nil, nil, err
+	}
 
 	jd, sd = jsonDataWithHeaders(jd, sd, httpHeaders)
 	return jd, sd, nil
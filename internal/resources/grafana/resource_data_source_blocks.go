@@ -0,0 +1,403 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// datasourceConfigBlock is a typed block of the data source resources, for the configuration of a common data source type.
+// Its fields are merged into the JSON data (or the secure JSON data) when writing the data source,
+// and extracted from the JSON data when reading it, so that they don't appear in `json_data_encoded`.
+type datasourceConfigBlock struct {
+	name    string
+	title   string
+	types   []string
+	docsURL string
+	fields  []datasourceConfigField
+}
+
+// datasourceConfigField is a field of a typed data source block.
+type datasourceConfigField struct {
+	name string
+	// key is the key of the field in the JSON data. Nested keys are separated by dots, ex: `serviceMap.datasourceUid`.
+	key         string
+	valueType   schema.ValueType
+	secure      bool
+	description string
+	validate    schema.SchemaValidateFunc
+}
+
+// Durations use the Grafana syntax, ex: `15s`, `1m`, `1d`
+var datasourceDurationRegex = regexp.MustCompile(`^\d+(ms|s|m|h|d|w|y)$`)
+
+func validateDatasourceDuration() schema.SchemaValidateFunc {
+	return validation.StringMatch(datasourceDurationRegex, "must be a duration, ex: `15s`, `1m`, `1h` or `1d`")
+}
+
+func stringField(name, key, description string, allowedValues ...string) datasourceConfigField {
+	field := datasourceConfigField{name: name, key: key, valueType: schema.TypeString, description: description}
+	if len(allowedValues) > 0 {
+		field.description = fmt.Sprintf("%s Allowed values: `%s`.", description, strings.Join(allowedValues, "`, `"))
+		field.validate = validation.StringInSlice(allowedValues, false)
+	}
+	return field
+}
+
+func durationField(name, key, description string) datasourceConfigField {
+	return datasourceConfigField{name: name, key: key, valueType: schema.TypeString, description: description + " Ex: `15s`, `1m`.", validate: validateDatasourceDuration()}
+}
+
+func boolField(name, key, description string) datasourceConfigField {
+	return datasourceConfigField{name: name, key: key, valueType: schema.TypeBool, description: description}
+}
+
+func intField(name, key, description string) datasourceConfigField {
+	return datasourceConfigField{name: name, key: key, valueType: schema.TypeInt, description: description, validate: validation.IntAtLeast(0)}
+}
+
+func secureField(name, key, description string) datasourceConfigField {
+	return datasourceConfigField{name: name, key: key, valueType: schema.TypeString, secure: true, description: description}
+}
+
+func sqlConnectionFields() []datasourceConfigField {
+	return []datasourceConfigField{
+		intField("max_open_conns", "maxOpenConns", "The maximum number of open connections to the database."),
+		intField("max_idle_conns", "maxIdleConns", "The maximum number of connections in the idle connection pool."),
+		intField("conn_max_lifetime", "connMaxLifetime", "The maximum amount of time in seconds a connection may be reused."),
+		durationField("time_interval", "timeInterval", "The lowest interval used for grouping by time."),
+		secureField("password", "password", "The password of the database user."),
+	}
+}
+
+var datasourceConfigBlocks = []datasourceConfigBlock{
+	{
+		name:    "prometheus",
+		title:   "Prometheus",
+		types:   []string{"prometheus"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/prometheus/configure-prometheus-data-source/",
+		fields: []datasourceConfigField{
+			stringField("http_method", "httpMethod", "The HTTP method used to query Prometheus.", "GET", "POST"),
+			durationField("scrape_interval", "timeInterval", "The scrape and evaluation interval of Prometheus."),
+			durationField("query_timeout", "queryTimeout", "The timeout of the queries."),
+			stringField("prometheus_type", "prometheusType", "The type of the Prometheus database.", "Prometheus", "Cortex", "Mimir", "Thanos"),
+			stringField("prometheus_version", "prometheusVersion", "The version of the Prometheus database, ex: `2.50.0`."),
+			stringField("cache_level", "cacheLevel", "The caching level of the editor queries.", "Low", "Medium", "High", "None"),
+			boolField("incremental_querying", "incrementalQuerying", "Whether to only query the new data of dashboards, rather than the whole time range."),
+			boolField("disable_recording_rules", "disableRecordingRules", "Whether to disable the recording rules in the query builder."),
+			stringField("custom_query_parameters", "customQueryParameters", "Custom parameters added to the query URL, ex: `max_source_resolution=5m&timeout=10`."),
+			boolField("manage_alerts", "manageAlerts", "Whether to manage the alerts of this data source in the alerting UI."),
+		},
+	},
+	{
+		name:    "loki",
+		title:   "Loki",
+		types:   []string{"loki"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/loki/configure-loki-data-source/",
+		fields: []datasourceConfigField{
+			{
+				name:        "max_lines",
+				key:         "maxLines",
+				valueType:   schema.TypeString,
+				description: "The maximum number of log lines returned by Loki, ex: `1000`.",
+				validate:    validation.StringMatch(regexp.MustCompile(`^\d+$`), "must be a number"),
+			},
+			boolField("manage_alerts", "manageAlerts", "Whether to manage the alerts of this data source in the alerting UI."),
+		},
+	},
+	{
+		name:    "tempo",
+		title:   "Tempo",
+		types:   []string{"tempo"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/tempo/configure-tempo-data-source/",
+		fields: []datasourceConfigField{
+			stringField("traces_to_logs_datasource_uid", "tracesToLogsV2.datasourceUid", "The UID of the logs data source to link traces to."),
+			stringField("traces_to_metrics_datasource_uid", "tracesToMetrics.datasourceUid", "The UID of the metrics data source to link traces to."),
+			stringField("service_map_datasource_uid", "serviceMap.datasourceUid", "The UID of the Prometheus data source containing the service graph metrics."),
+			boolField("node_graph_enabled", "nodeGraph.enabled", "Whether to display the node graph of the traces."),
+			boolField("search_hidden", "search.hide", "Whether to hide the search query type."),
+			stringField("span_bar_type", "spanBar.type", "The information displayed next to the names of the spans.", "None", "Duration", "Tag"),
+			boolField("streaming_search_enabled", "streamingEnabled.search", "Whether to stream the results of the search queries."),
+		},
+	},
+	{
+		name:    "elasticsearch",
+		title:   "Elasticsearch",
+		types:   []string{"elasticsearch"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/elasticsearch/configure-elasticsearch-data-source/",
+		fields: []datasourceConfigField{
+			stringField("index", "index", "The name or the pattern of the index, ex: `[logs-]YYYY.MM.DD`."),
+			stringField("interval", "interval", "The interval of the index pattern.", "Hourly", "Daily", "Weekly", "Monthly", "Yearly"),
+			stringField("time_field", "timeField", "The name of the time field, ex: `@timestamp`."),
+			durationField("time_interval", "timeInterval", "The lowest interval used for grouping by time."),
+			intField("max_concurrent_shard_requests", "maxConcurrentShardRequests", "The maximum number of concurrent shard requests of each query."),
+			stringField("log_message_field", "logMessageField", "The field containing the message of the logs."),
+			stringField("log_level_field", "logLevelField", "The field containing the level of the logs."),
+			boolField("include_frozen", "includeFrozen", "Whether to include the frozen indices in the searches."),
+		},
+	},
+	{
+		name:    "postgres",
+		title:   "PostgreSQL",
+		types:   []string{"grafana-postgresql-datasource", "postgres"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/postgres/configure/",
+		fields: append([]datasourceConfigField{
+			stringField("ssl_mode", "sslmode", "The TLS/SSL mode of the connection.", "disable", "require", "verify-ca", "verify-full"),
+			intField("postgres_version", "postgresVersion", "The version of PostgreSQL, ex: `1500` for 15 or later, `1200` for 12."),
+			boolField("timescaledb", "timescaledb", "Whether the TimescaleDB extension is available."),
+		}, sqlConnectionFields()...),
+	},
+	{
+		name:    "mysql",
+		title:   "MySQL",
+		types:   []string{"mysql"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/mysql/configure/",
+		fields: append([]datasourceConfigField{
+			boolField("tls_auth", "tlsAuth", "Whether to use TLS client authentication."),
+			boolField("tls_auth_with_ca_cert", "tlsAuthWithCACert", "Whether to verify the certificate of the server with a CA certificate."),
+			boolField("tls_skip_verify", "tlsSkipVerify", "Whether to skip the verification of the certificate of the server."),
+			stringField("timezone", "timezone", "The timezone of the session, ex: `+05:00` or `Europe/Paris`."),
+		}, sqlConnectionFields()...),
+	},
+	{
+		name:    "cloudwatch",
+		title:   "CloudWatch",
+		types:   []string{"cloudwatch"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/aws-cloudwatch/configure/",
+		fields: []datasourceConfigField{
+			stringField("auth_type", "authType", "The authentication provider.", "default", "keys", "credentials", "ec2_iam_role", "grafana_assume_role"),
+			stringField("default_region", "defaultRegion", "The default region, ex: `us-east-1`."),
+			stringField("assume_role_arn", "assumeRoleArn", "The ARN of the role to assume."),
+			stringField("external_id", "externalId", "The external ID used to assume the role."),
+			stringField("profile", "profile", "The profile of the credentials file, with the `credentials` authentication provider."),
+			stringField("endpoint", "endpoint", "A custom endpoint of the CloudWatch API."),
+			stringField("custom_metrics_namespaces", "customMetricsNamespaces", "The namespaces of the custom metrics, separated by commas."),
+			durationField("logs_timeout", "logsTimeout", "The timeout of the CloudWatch Logs queries."),
+			secureField("access_key", "accessKey", "The access key ID, with the `keys` authentication provider."),
+			secureField("secret_key", "secretKey", "The secret access key, with the `keys` authentication provider."),
+		},
+	},
+	{
+		name:    "influxdb",
+		title:   "InfluxDB",
+		types:   []string{"influxdb"},
+		docsURL: "https://grafana.com/docs/grafana/latest/datasources/influxdb/configure-influxdb-data-source/",
+		fields: []datasourceConfigField{
+			stringField("query_language", "version", "The query language.", "InfluxQL", "Flux", "SQL"),
+			stringField("organization", "organization", "The organization, with the Flux query language."),
+			stringField("default_bucket", "defaultBucket", "The default bucket, with the Flux query language."),
+			stringField("db_name", "dbName", "The name of the database, with the InfluxQL and SQL query languages."),
+			stringField("http_mode", "httpMode", "The HTTP method used to query InfluxDB, with the InfluxQL query language.", "GET", "POST"),
+			intField("max_series", "maxSeries", "The maximum number of series or tables displayed."),
+			durationField("time_interval", "timeInterval", "The lowest interval used for grouping by time."),
+			secureField("token", "token", "The token, with the Flux and SQL query languages."),
+			secureField("password", "password", "The password, with the InfluxQL query language."),
+		},
+	},
+}
+
+// withDatasourceConfigBlocks adds the typed data source blocks to the schema of a data source resource.
+func withDatasourceConfigBlocks(attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, block := range datasourceConfigBlocks {
+		fields := map[string]*schema.Schema{}
+		for _, field := range block.fields {
+			location := "JSON data"
+			if field.secure {
+				location = "secure JSON data"
+			}
+			fields[field.name] = &schema.Schema{
+				Type:         field.valueType,
+				Optional:     true,
+				Sensitive:    field.secure,
+				Description:  fmt.Sprintf("%s Sets `%s` in the %s.", field.description, field.key, location),
+				ValidateFunc: field.validate,
+			}
+		}
+		attributes[block.name] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Description: fmt.Sprintf("Typed configuration of %s data sources (type `%s`), merged into the JSON data and the secure JSON data. "+
+				"Its keys can't also be set in `json_data_encoded` or `secure_json_data_encoded`. See the [documentation](%s).", block.title, strings.Join(block.types, "` or `"), block.docsURL),
+			Elem: &schema.Resource{Schema: fields},
+		}
+	}
+	return attributes
+}
+
+// datasourceConfigBlocksToJSONData merges the configured typed blocks into the JSON data and the secure JSON data.
+// Only the fields set in the configuration are written, including those set to a zero value. A data source type of "" skips the type check.
+func datasourceConfigBlocksToJSONData(d *schema.ResourceData, datasourceType string, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	for _, block := range datasourceConfigBlocks {
+		list := d.Get(block.name).([]interface{})
+		if len(list) == 0 || list[0] == nil {
+			continue
+		}
+		if datasourceType != "" && !slices.Contains(block.types, datasourceType) {
+			return fmt.Errorf("the %s block can only be used with data sources of type `%s`, got `%s`", block.name, strings.Join(block.types, "` or `"), datasourceType)
+		}
+
+		values := list[0].(map[string]interface{})
+		configured := datasourceConfigBlockFields(d, block)
+		for _, field := range block.fields {
+			value := values[field.name]
+			if !configured[field.name] {
+				continue
+			}
+
+			if field.secure {
+				if _, ok := secureJSONData[field.key]; ok {
+					return fmt.Errorf("`%s` is set by both the %s block and `secure_json_data_encoded`", field.key, block.name)
+				}
+				secureJSONData[field.key] = value.(string)
+				continue
+			}
+			if _, ok := jsonDataValue(jsonData, field.key); ok {
+				return fmt.Errorf("`%s` is set by both the %s block and `json_data_encoded`", field.key, block.name)
+			}
+			setJSONDataValue(jsonData, field.key, value)
+		}
+	}
+	return nil
+}
+
+// datasourceConfigBlocksToState moves the fields of the typed blocks that are in the state from the JSON data to the blocks.
+// Only the fields managed by the blocks are moved, the other keys stay in the JSON data, even if a block has a field for them.
+// The JSON data is modified in place. Secure fields can't be read, so they are kept from the state.
+func datasourceConfigBlocksToState(d *schema.ResourceData, jsonData map[string]interface{}) {
+	for _, block := range datasourceConfigBlocks {
+		// The blocks aren't in the schema of the data source, which returns the whole JSON data
+		list, _ := d.Get(block.name).([]interface{})
+		if len(list) == 0 || list[0] == nil {
+			continue
+		}
+
+		current := list[0].(map[string]interface{})
+		configured := datasourceConfigBlockFields(d, block)
+		values := map[string]interface{}{}
+		for _, field := range block.fields {
+			if field.secure {
+				values[field.name] = current[field.name]
+				continue
+			}
+			if !configured[field.name] {
+				continue
+			}
+			value, ok := jsonDataValue(jsonData, field.key)
+			if !ok {
+				continue
+			}
+			// Values of another type are left in the JSON data, so that they show up in the diff of `json_data_encoded`
+			switch v := value.(type) {
+			case string:
+				if field.valueType != schema.TypeString {
+					continue
+				}
+			case bool:
+				if field.valueType != schema.TypeBool {
+					continue
+				}
+			case float64:
+				if field.valueType != schema.TypeInt || v != float64(int(v)) {
+					continue
+				}
+				value = int(v)
+			default:
+				continue
+			}
+			values[field.name] = value
+			deleteJSONDataValue(jsonData, field.key)
+		}
+		d.Set(block.name, []interface{}{values})
+	}
+}
+
+// datasourceConfigBlockFields returns the names of the fields of a typed block that are managed by the block.
+// When the configuration is available, they are the fields set in the block, including those set to a zero value.
+// When refreshing the state, there's no configuration: they are the fields whose keys aren't in `json_data_encoded`.
+func datasourceConfigBlockFields(d *schema.ResourceData, block datasourceConfigBlock) map[string]bool {
+	fields := map[string]bool{}
+	if config := d.GetRawConfig(); !config.IsNull() {
+		list := config.GetAttr(block.name)
+		if !list.IsKnown() || list.IsNull() || list.LengthInt() == 0 {
+			return fields
+		}
+		values := list.Index(cty.NumberIntVal(0))
+		for _, field := range block.fields {
+			if value := values.GetAttr(field.name); !value.IsKnown() || !value.IsNull() {
+				fields[field.name] = true
+			}
+		}
+		return fields
+	}
+
+	jsonData := map[string]interface{}{}
+	if encoded, _ := d.Get("json_data_encoded").(string); encoded != "" {
+		if err := json.Unmarshal([]byte(encoded), &jsonData); err != nil {
+			return fields
+		}
+	}
+	for _, field := range block.fields {
+		if _, ok := jsonDataValue(jsonData, field.key); !ok {
+			fields[field.name] = true
+		}
+	}
+	return fields
+}
+
+// jsonDataValue returns the value of a key of the JSON data. Nested keys are separated by dots.
+func jsonDataValue(jsonData map[string]interface{}, key string) (interface{}, bool) {
+	parent, last, ok := jsonDataParent(jsonData, key, false)
+	if !ok {
+		return nil, false
+	}
+	value, ok := parent[last]
+	return value, ok
+}
+
+func setJSONDataValue(jsonData map[string]interface{}, key string, value interface{}) {
+	parent, last, _ := jsonDataParent(jsonData, key, true)
+	parent[last] = value
+}
+
+// deleteJSONDataValue deletes a key of the JSON data, and the objects that contained it if they are empty.
+func deleteJSONDataValue(jsonData map[string]interface{}, key string) {
+	parentKey, last, nested := strings.Cut(key, ".")
+	if !nested {
+		delete(jsonData, key)
+		return
+	}
+	parent, ok := jsonData[parentKey].(map[string]interface{})
+	if !ok {
+		return
+	}
+	deleteJSONDataValue(parent, last)
+	if len(parent) == 0 {
+		delete(jsonData, parentKey)
+	}
+}
+
+// jsonDataParent returns the object containing a key of the JSON data, and the last part of the key.
+// If create is true, the missing objects are created.
+func jsonDataParent(jsonData map[string]interface{}, key string, create bool) (map[string]interface{}, string, bool) {
+	parts := strings.Split(key, ".")
+	parent := jsonData
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := parent[part].(map[string]interface{})
+		if !ok {
+			if !create {
+				return nil, "", false
+			}
+			child = map[string]interface{}{}
+			parent[part] = child
+		}
+		parent = child
+	}
+	return parent, parts[len(parts)-1], true
+}
@@ -25,11 +25,12 @@ func resourceDataSourceConfig() *common.Resource {
 		UpdateContext: UpdateDataSourceConfig,
 		ReadContext:   ReadDataSourceConfig,
 		DeleteContext: DeleteDataSourceConfig,
+		CustomizeDiff: customizeDatasourceHealthCheckDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: withDatasourceHealthCheck(withDatasourceConfigBlocks(map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
 			"uid": {
 				Type:        schema.TypeString,
@@ -41,7 +42,7 @@ func resourceDataSourceConfig() *common.Resource {
 			"http_headers":             datasourceHTTPHeadersAttribute(),
 			"json_data_encoded":        datasourceJSONDataAttribute(),
 			"secure_json_data_encoded": datasourceSecureJSONDataAttribute(),
-		},
+		})),
 	}
 
 	return common.NewLegacySDKResource(
@@ -57,7 +58,10 @@ func UpdateDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta in
 	if diag := updateGrafanaDataSourceConfig(d, d.Get("uid").(string), client); diag.HasError() {
 		return diag
 	}
-	return ReadDataSourceConfig(ctx, d, meta)
+	if diag := ReadDataSourceConfig(ctx, d, meta); diag.HasError() {
+		return diag
+	}
+	return checkDatasourceHealth(ctx, d, client, d.Get("uid").(string))
 }
 
 func ReadDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -87,7 +91,7 @@ func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string,
 	ds := resp.GetPayload()
 	d.SetId(MakeOrgResourceID(ds.OrgID, ds.UID))
 
-	jd, sd, err := stateToDatasourceConfig(d)
+	jd, sd, err := stateToDatasourceConfig(d, ds.Type)
 	if err != nil {
 		return diag.FromErr(err)
 	}
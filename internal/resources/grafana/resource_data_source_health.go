@@ -0,0 +1,120 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	datasourceHealthOK    = "OK"
+	datasourceHealthError = "ERROR"
+)
+
+// withDatasourceHealthCheck adds the `health_check` block and the health status attributes to the schema of a data source resource.
+func withDatasourceHealthCheck(attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	attributes["health_check"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Checks the health of the data source after it is created or updated, with the health endpoint of its plugin. " +
+			"The result is available in the `health_status` and `health_message` attributes. The health isn't checked when the data source is refreshed.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to check the health of the data source.",
+				},
+				"fail_on_error": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+					Description: "Whether to fail the apply when the data source is unhealthy. Otherwise, a warning is reported. " +
+						"A data source that is unhealthy when it is created is then marked as tainted, and replaced by the next apply.",
+				},
+				"timeout": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "30s",
+					Description:      "The timeout of the health check, ex: `30s`.",
+					ValidateDiagFunc: common.ValidateDuration,
+				},
+			},
+		},
+	}
+	attributes["health_status"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The status of the last health check of the data source: `OK` or `ERROR`. Empty when the health check is disabled.",
+	}
+	attributes["health_message"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The message of the last health check of the data source.",
+	}
+	return attributes
+}
+
+// customizeDatasourceHealthCheckDiff plans a new health status when the data source is updated, since its health is checked again.
+func customizeDatasourceHealthCheckDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if err := d.SetNewComputed("health_status"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("health_message")
+}
+
+// checkDatasourceHealth checks the health of the data source with the given UID, if it's enabled in the `health_check` block,
+// and sets the health status attributes. An unhealthy data source is reported as a warning, or as an error with `fail_on_error`.
+func checkDatasourceHealth(ctx context.Context, d *schema.ResourceData, client *goapi.GrafanaHTTPAPI, uid string) diag.Diagnostics {
+	list := d.Get("health_check").([]interface{})
+	if len(list) == 0 || list[0] == nil || !list[0].(map[string]interface{})["enabled"].(bool) {
+		// The attributes are left unset when the health was never checked, so that they match the state of imported data sources
+		if d.Get("health_status").(string) != "" {
+			d.Set("health_status", "")
+			d.Set("health_message", "")
+		}
+		return nil
+	}
+	healthCheck := list[0].(map[string]interface{})
+	timeout, _ := time.ParseDuration(healthCheck["timeout"].(string))
+
+	status, message := datasourceHealthOK, ""
+	params := datasources.NewCheckDatasourceHealthWithUIDParams().WithUID(uid).WithContext(ctx).WithTimeout(timeout)
+	resp, err := client.Datasources.CheckDatasourceHealthWithUIDWithParams(params)
+	if err != nil {
+		status, message = datasourceHealthError, err.Error()
+		if apiErr, ok := common.DecodeAPIError(err); ok && apiErr.Message != "" {
+			message = apiErr.Message
+		}
+	} else if resp.Payload != nil {
+		message = resp.Payload.Message
+	}
+	d.Set("health_status", status)
+	d.Set("health_message", message)
+
+	if status == datasourceHealthOK {
+		return nil
+	}
+	severity := diag.Warning
+	if healthCheck["fail_on_error"].(bool) {
+		severity = diag.Error
+	}
+	return diag.Diagnostics{{
+		Severity:      severity,
+		Summary:       fmt.Sprintf("The health check of data source %s failed", uid),
+		Detail:        message,
+		AttributePath: cty.GetAttrPath("health_check"),
+	}}
+}
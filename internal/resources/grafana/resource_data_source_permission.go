@@ -43,7 +43,7 @@ Manages the entire set of permissions for a datasource. Permissions that aren't
 		"grafana_data_source_permission",
 		orgResourceIDInt("datasourceID"),
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func resourceDatasourcePermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
@@ -31,7 +31,7 @@ func makeResourceDatasourcePermissionItem() *common.Resource {
 		resourceDatasourcePermissionItemName,
 		resourceDatasourcePermissionItemID,
 		resourceStruct,
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 type resourceDatasourcePermissionItemModel struct {
@@ -42,6 +42,8 @@ type resourceDatasourcePermissionItemModel struct {
 	User          types.String `tfsdk:"user"`
 	Permission    types.String `tfsdk:"permission"`
 	DatasourceUID types.String `tfsdk:"datasource_uid"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -53,6 +55,8 @@ func (m *resourceDatasourcePermissionItemModel) ToBase() *resourcePermissionItem
 		Team:       m.Team,
 		User:       m.User,
 		Permission: m.Permission,
+
+		GrafanaConnection: m.GrafanaConnection,
 	}
 }
 
@@ -64,6 +68,7 @@ func (m *resourceDatasourcePermissionItemModel) SetFromBase(base *resourcePermis
 	m.Team = base.Team
 	m.User = base.User
 	m.Permission = base.Permission
+	m.GrafanaConnection = base.GrafanaConnection
 }
 
 type resourceDatasourcePermissionItem struct{ resourcePermissionBase }
@@ -84,11 +89,15 @@ func (r *resourceDatasourcePermissionItem) Schema(ctx context.Context, req resou
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(true),
+		},
 	}
 }
 
 func (r *resourceDatasourcePermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.datasourceQuery)
+	connection, id := splitConnectionImportID(req.ID)
+	readData, diags := r.readItem(ctx, connection, id, r.datasourceQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -111,7 +120,7 @@ func (r *resourceDatasourcePermissionItem) Create(ctx context.Context, req resou
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -126,7 +135,7 @@ func (r *resourceDatasourcePermissionItem) Read(ctx context.Context, req resourc
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.datasourceQuery)
+	readData, diags := r.readItem(ctx, data.GrafanaConnection, data.ID.ValueString(), r.datasourceQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -149,7 +158,7 @@ func (r *resourceDatasourcePermissionItem) Update(ctx context.Context, req resou
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -164,7 +173,7 @@ func (r *resourceDatasourcePermissionItem) Delete(ctx context.Context, req resou
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.DatasourceUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.DatasourceUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
@@ -1,21 +1,339 @@
 package grafana_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestFakeDataSource_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_data_source/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.influxdb", "name", "myapp-metrics"),
+					resource.TestCheckResourceAttr("grafana_data_source.influxdb", "database_name", "dbname"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "basic_auth_username", "username"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_data_source.influxdb"),
+				),
+			},
+			{
+				ResourceName:            "grafana_data_source.influxdb",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers"},
+			},
+		},
+	})
+}
+
+func TestFakeDataSource_typedBlocks(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := func(httpMethod string) string {
+		return fmt.Sprintf(`
+resource "grafana_data_source" "prometheus" {
+	type = "prometheus"
+	name = "prometheus"
+	uid  = "prometheus"
+	url  = "http://prometheus.invalid"
+
+	json_data_encoded = jsonencode({
+		exemplarTraceIdDestinations = [{ name = "traceID", datasourceUid = "tempo" }]
+	})
+
+	prometheus {
+		http_method          = %q
+		scrape_interval      = "30s"
+		prometheus_type      = "Mimir"
+		incremental_querying = true
+	}
+}
+
+resource "grafana_data_source" "tempo" {
+	type = "tempo"
+	name = "tempo"
+	uid  = "tempo"
+	url  = "http://tempo.invalid"
+
+	json_data_encoded = jsonencode({
+		tracesToLogsV2 = { tags = [{ key = "service.name" }] }
+	})
+
+	tempo {
+		traces_to_logs_datasource_uid = "loki"
+		service_map_datasource_uid    = grafana_data_source.prometheus.uid
+		node_graph_enabled            = true
+	}
+}
+
+resource "grafana_data_source" "postgres" {
+	type          = "grafana-postgresql-datasource"
+	name          = "postgres"
+	url           = "postgres.invalid:5432"
+	username      = "grafana"
+	database_name = "grafana"
+
+	postgres {
+		ssl_mode         = "disable"
+		postgres_version = 1500
+		password         = "secret"
+	}
+}
+
+resource "grafana_data_source" "influxdb" {
+	type = "influxdb"
+	name = "influxdb"
+	url  = "http://influxdb.invalid"
+
+	lifecycle {
+		ignore_changes = [json_data_encoded]
+	}
+}
+
+resource "grafana_data_source_config" "influxdb" {
+	uid = grafana_data_source.influxdb.uid
+
+	influxdb {
+		query_language = "Flux"
+		organization   = "my-org"
+		default_bucket = "telegraf"
+		token          = "my-token"
+	}
+}`, httpMethod)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("POST"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.http_method", "POST"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.cache_level", ""),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", `{"exemplarTraceIdDestinations":[{"datasourceUid":"tempo","name":"traceID"}]}`),
+					checkFakeDataSourceJSONData(client, "grafana_data_source.prometheus", `{"exemplarTraceIdDestinations":[{"datasourceUid":"tempo","name":"traceID"}],"httpMethod":"POST","incrementalQuerying":true,"prometheusType":"Mimir","timeInterval":"30s"}`),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", `{"tracesToLogsV2":{"tags":[{"key":"service.name"}]}}`),
+					checkFakeDataSourceJSONData(client, "grafana_data_source.tempo", `{"nodeGraph":{"enabled":true},"serviceMap":{"datasourceUid":"prometheus"},"tracesToLogsV2":{"datasourceUid":"loki","tags":[{"key":"service.name"}]}}`),
+					resource.TestCheckResourceAttr("grafana_data_source.postgres", "json_data_encoded", "{}"),
+					resource.TestCheckResourceAttr("grafana_data_source.postgres", "postgres.0.password", "secret"),
+					checkFakeDataSourceJSONData(client, "grafana_data_source.postgres", `{"postgresVersion":1500,"sslmode":"disable"}`),
+					resource.TestCheckResourceAttr("grafana_data_source_config.influxdb", "influxdb.0.organization", "my-org"),
+					checkFakeDataSourceJSONData(client, "grafana_data_source_config.influxdb", `{"defaultBucket":"telegraf","organization":"my-org","version":"Flux"}`),
+				),
+			},
+			{
+				Config: config("GET"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.http_method", "GET"),
+					checkFakeDataSourceJSONData(client, "grafana_data_source.prometheus", `{"exemplarTraceIdDestinations":[{"datasourceUid":"tempo","name":"traceID"}],"httpMethod":"GET","incrementalQuerying":true,"prometheusType":"Mimir","timeInterval":"30s"}`),
+				),
+			},
+			// Without the block, the keys are imported in json_data_encoded
+			{
+				ResourceName:            "grafana_data_source.prometheus",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json_data_encoded", "prometheus"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if jsonData := states[0].Attributes["json_data_encoded"]; !strings.Contains(jsonData, `"httpMethod":"GET"`) {
+						return fmt.Errorf("expected httpMethod in json_data_encoded, got %s", jsonData)
+					}
+					return nil
+				},
+			},
+			{
+				Config: `
+resource "grafana_data_source" "invalid" {
+	type = "prometheus"
+	name = "invalid"
+
+	prometheus {
+		scrape_interval = "30 seconds"
+	}
+}`,
+				ExpectError: regexp.MustCompile("must be a duration"),
+			},
+			{
+				Config: `
+resource "grafana_data_source" "wrong_type" {
+	type = "prometheus"
+	name = "wrong-type"
+
+	loki {
+		max_lines = "1000"
+	}
+}`,
+				ExpectError: regexp.MustCompile("the loki block can only be used with data sources of type `loki`, got `prometheus`"),
+			},
+			{
+				Config: `
+resource "grafana_data_source" "conflict" {
+	type = "prometheus"
+	name = "conflict"
+
+	json_data_encoded = jsonencode({ httpMethod = "GET" })
+	prometheus {
+		http_method = "POST"
+	}
+}`,
+				ExpectError: regexp.MustCompile("`httpMethod` is set by both the prometheus block and `json_data_encoded`"),
+			},
+		},
+	})
+}
+
+func TestFakeDataSource_typedBlocksWithJSONData(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := func(fields string) string {
+		return fmt.Sprintf(`
+resource "grafana_data_source" "prometheus" {
+	type = "prometheus"
+	name = "prometheus"
+	url  = "http://prometheus.invalid"
+
+	json_data_encoded = jsonencode({
+		timeInterval = "30s"
+	})
+
+	prometheus {
+		http_method = "POST"
+		%s
+	}
+}`, fields)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			// Keys of json_data_encoded stay there, even if the block has a field for them
+			{
+				Config: config("manage_alerts = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", `{"timeInterval":"30s"}`),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.scrape_interval", ""),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "prometheus.0.manage_alerts", "false"),
+					checkFakeDataSourceJSONData(client, "grafana_data_source.prometheus", `{"httpMethod":"POST","manageAlerts":false,"timeInterval":"30s"}`),
+				),
+			},
+			{
+				Config: config("manage_alerts = true"),
+				Check:  checkFakeDataSourceJSONData(client, "grafana_data_source.prometheus", `{"httpMethod":"POST","manageAlerts":true,"timeInterval":"30s"}`),
+			},
+			// Fields that aren't set anymore are removed
+			{
+				Config: config(""),
+				Check:  checkFakeDataSourceJSONData(client, "grafana_data_source.prometheus", `{"httpMethod":"POST","timeInterval":"30s"}`),
+			},
+		},
+	})
+}
+
+func TestFakeDataSource_healthCheck(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	config := func(url string, failOnError bool) string {
+		return fmt.Sprintf(`
+resource "grafana_data_source" "test" {
+	type = "prometheus"
+	name = "health-check"
+	url  = %q
+
+	health_check {
+		fail_on_error = %t
+		timeout       = "10s"
+	}
+}
+
+resource "grafana_data_source" "unchecked" {
+	type = "loki"
+	name = "unchecked"
+	url  = "http://unhealthy.invalid"
+
+	lifecycle {
+		ignore_changes = [json_data_encoded]
+	}
+}
+
+resource "grafana_data_source_config" "unchecked" {
+	uid = grafana_data_source.unchecked.uid
+
+	loki {
+		max_lines = "1000"
+	}
+
+	health_check {}
+}`, url, failOnError)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("http://prometheus.invalid", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_status", "OK"),
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_message", "Data source is working"),
+					resource.TestCheckNoResourceAttr("grafana_data_source.unchecked", "health_status"),
+					// The data source config is unhealthy, but only reports a warning
+					resource.TestCheckResourceAttr("grafana_data_source_config.unchecked", "health_status", "ERROR"),
+					resource.TestMatchResourceAttr("grafana_data_source_config.unchecked", "health_message", regexp.MustCompile("connection refused")),
+				),
+			},
+			{
+				Config: config("http://unhealthy.invalid", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.test", "health_status", "ERROR"),
+					resource.TestMatchResourceAttr("grafana_data_source.test", "health_message", regexp.MustCompile("connection refused")),
+				),
+			},
+			{
+				Config:      config("http://unhealthy.invalid/v2", true),
+				ExpectError: regexp.MustCompile("The health check of data source .* failed"),
+			},
+		},
+	})
+}
+
+// checkFakeDataSourceJSONData checks the JSON data of a data source in Grafana.
+func checkFakeDataSourceJSONData(client *goapi.GrafanaHTTPAPI, resourceName, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		resp, err := client.Datasources.GetDataSourceByUID(rs.Primary.Attributes["uid"])
+		if err != nil {
+			return err
+		}
+		jsonData, err := json.Marshal(resp.Payload.JSONData)
+		if err != nil {
+			return err
+		}
+		if string(jsonData) != expected {
+			return fmt.Errorf("expected the JSON data %s, got %s", expected, jsonData)
+		}
+		return nil
+	}
+}
+
 func TestAccDataSource_Loki(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -3,11 +3,19 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 
@@ -17,6 +25,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var folderDeleteContentsOptions = []string{"none", "dashboards", "all"}
+
 var folderUIDValidation = validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9\-\_]+$`), "folder UIDs can only be alphanumeric, dashes, or underscores")
 
 func resourceFolder() *common.Resource {
@@ -32,8 +42,9 @@ func resourceFolder() *common.Resource {
 		ReadContext:   ReadFolder,
 		UpdateContext: UpdateFolder,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithLookup("folder", findFolderWithPath),
 		},
+		CustomizeDiff: customizeFolderParentPathDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
@@ -59,7 +70,30 @@ func resourceFolder() *common.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Prevent deletion of the folder if it is not empty (contains dashboards or alert rules). This feature requires Grafana 10.2 or later.",
+				Description: "Prevent deletion of the folder if it is not empty (contains dashboards, folders, alert rules or library panels). This feature requires Grafana 10.2 or later.",
+			},
+			"delete_contents": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: common.AllowedValuesDescription("What to delete in the folder and its subfolders before deleting it", folderDeleteContentsOptions) + " " +
+					"With `dashboards`, the dashboards are deleted. With `all`, the alert rules, dashboards and library panels are deleted, in this order, " +
+					"so that folders holding library panels can be deleted. The deleted items are reported in a warning. " +
+					"If not set, or set to `none`, Grafana deletes the contents it can with the folder. " +
+					"To keep the contents, use `move_contents_to` instead. " +
+					"Alert rules and library panels that the credentials of the provider can't list are skipped, with a warning. " +
+					"`prevent_destroy_if_not_empty` takes precedence over this attribute.",
+				ValidateFunc:  validation.StringInSlice(folderDeleteContentsOptions, false),
+				ConflictsWith: []string{"move_contents_to"},
+			},
+			"move_contents_to": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The UID of a folder to move the contents of the folder to before deleting it: its subfolders, alert rules, dashboards and library panels. " +
+					"Alert rules keep their rule group. The moved items are reported in a warning. " +
+					"Alert rules and library panels that the credentials of the provider can't list are skipped, with a warning. " +
+					"`prevent_destroy_if_not_empty` takes precedence over this attribute.",
+				ValidateFunc:  folderUIDValidation,
+				ConflictsWith: []string{"delete_contents"},
 			},
 			"parent_folder_uid": {
 				Type:     schema.TypeString,
@@ -69,6 +103,32 @@ func resourceFolder() *common.Resource {
 					"If set, the folder will be nested. " +
 					"If not set, the folder will be created in the root folder. " +
 					"Note: This requires the nestedFolders feature flag to be enabled on your Grafana instance.",
+				ConflictsWith: []string{"parent_folder_path"},
+				// The parent folder is found with the path instead
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return !d.GetRawConfig().GetAttr("parent_folder_path").IsNull()
+				},
+			},
+			"parent_folder_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// Imported folders don't have a path in the state. The path is compared with the parent folder in customizeFolderParentPathDiff
+				Computed: true,
+				Description: "The path of the parent folder, made of folder titles separated by `/`, from the root, ex: `Platform/Payments`. " +
+					"A `/` within a title can be escaped as `\\/`. This is an alternative to `parent_folder_uid`. " +
+					"The folder is replaced when the path leads to another parent folder. " +
+					"Renaming or moving the parent folders doesn't replace it, and neither does setting the path of the current parent folder, ex: after an import.",
+				ConflictsWith: []string{"parent_folder_uid"},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return folderPathsEqual(oldValue, newValue)
+				},
+			},
+			"create_parent_folders": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to create the folders of `parent_folder_path` that don't exist. " +
+					"They are not managed by Terraform: they are not deleted with this folder.",
+				RequiredWith: []string{"parent_folder_path"},
 			},
 		},
 	}
@@ -78,7 +138,42 @@ func resourceFolder() *common.Resource {
 		"grafana_folder",
 		orgResourceIDString("uid"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listFolders))
+	).WithLister(listerFunctionOrgResource(listFolders)).
+		WithImportLookups("folder:{{ path }}")
+}
+
+// customizeFolderParentPathDiff replaces the folder when `parent_folder_path` leads to another folder than its current parent.
+// Changes to the path that lead to the current parent are cleared, ex: when the path is set after an import, or instead of `parent_folder_uid`.
+func customizeFolderParentPathDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("parent_folder_path") {
+		return nil
+	}
+	path := d.GetRawConfig().GetAttr("parent_folder_path")
+	if path.IsNull() {
+		return nil
+	}
+	if !path.IsKnown() || !d.NewValueKnown("grafana_connection") {
+		return d.ForceNew("parent_folder_path")
+	}
+
+	if connection, ok := connectionConfig(d); ok {
+		connectionClient, err := meta.(*common.Client).GrafanaConnection(ctx, connection)
+		if err != nil {
+			return err
+		}
+		meta = connectionClient
+	}
+	if meta.(*common.Client).GrafanaAPI == nil {
+		return d.ForceNew("parent_folder_path")
+	}
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	// The parent folder may not exist yet, ex: when it's created in the same apply or with `create_parent_folders`
+	parentUID, err := findFolderWithPath(client, path.AsString())
+	if currentParentUID, _ := d.GetChange("parent_folder_uid"); err == nil && parentUID == currentParentUID.(string) {
+		return d.Clear("parent_folder_path")
+	}
+	return d.ForceNew("parent_folder_path")
 }
 
 func listFolders(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -101,6 +196,18 @@ func CreateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 		body.ParentUID = parentUID.(string)
 	}
 
+	if parentPath, ok := d.GetOk("parent_folder_path"); ok {
+		findParent := findFolderWithPath
+		if d.Get("create_parent_folders").(bool) {
+			findParent = findOrCreateFolderWithPath
+		}
+		parentUID, err := findParent(client, parentPath.(string))
+		if err != nil {
+			return diag.Errorf("failed to find the parent folder: %s", err)
+		}
+		body.ParentUID = parentUID
+	}
+
 	resp, err := client.Folders.CreateFolder(&body)
 	if err != nil {
 		return diag.Errorf("failed to create folder: %s", err)
@@ -133,12 +240,18 @@ func UpdateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 }
 
 func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, diags := readFolder(d, meta)
+	return diags
+}
+
+// readFolder reads the folder into the resource data, and returns it. It returns a nil folder if it wasn't found.
+func readFolder(d *schema.ResourceData, meta interface{}) (*models.Folder, diag.Diagnostics) {
 	metaClient := meta.(*common.Client)
 	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
 	folder, err := GetFolderByIDorUID(client.Folders, idStr)
 	if err, shouldReturn := common.CheckReadError("folder", d, err); shouldReturn {
-		return err
+		return nil, err
 	}
 
 	d.SetId(MakeOrgResourceID(orgID, folder.UID))
@@ -148,24 +261,48 @@ func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	d.Set("url", metaClient.GrafanaSubpath(folder.URL))
 	d.Set("parent_folder_uid", folder.ParentUID)
 
-	return nil
+	return folder, nil
 }
 
 func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 	deleteParams := folders.NewDeleteFolderParams().WithFolderUID(uid)
+	var diags diag.Diagnostics
 	if d.Get("prevent_destroy_if_not_empty").(bool) {
 		searchParams := search.NewSearchParams().WithFolderUIDs([]string{uid})
 		searchResp, err := client.Search.Search(searchParams)
 		if err != nil {
 			return diag.Errorf("failed to search for dashboards in folder: %s", err)
 		}
-		if len(searchResp.GetPayload()) > 0 {
-			var dashboardAndFolderNames []string
-			for _, dashboard := range searchResp.GetPayload() {
-				dashboardAndFolderNames = append(dashboardAndFolderNames, dashboard.Title)
+		var contents []string
+		for _, hit := range searchResp.GetPayload() {
+			kind := "dashboard"
+			if hit.Type == "dash-folder" {
+				kind = "folder"
 			}
-			return diag.Errorf("folder %s is not empty and prevent_destroy_if_not_empty is set. It contains the following dashboards and/or folders: %v", uid, dashboardAndFolderNames)
+			contents = append(contents, fmt.Sprintf("%s %q", kind, hit.Title))
+		}
+		folder, err := client.Folders.GetFolderByUID(uid)
+		if err != nil {
+			return diag.Errorf("failed to get folder %s: %s", uid, err)
+		}
+		folderIDs := map[string]int64{uid: folder.Payload.ID}
+		rules, err := folderAlertRules(client, folderIDs)
+		if err = skipForbiddenFolderContents(err, &diags); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		for _, rule := range rules {
+			contents = append(contents, fmt.Sprintf("alert rule %q", rule.title))
+		}
+		panels, err := folderLibraryPanels(client, folderIDs)
+		if err = skipForbiddenFolderContents(err, &diags); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		for _, panel := range panels {
+			contents = append(contents, fmt.Sprintf("library panel %q", panel.Name))
+		}
+		if len(contents) > 0 {
+			return append(diags, diag.Errorf("folder %s is not empty and prevent_destroy_if_not_empty is set. It contains the following items: %s", uid, strings.Join(contents, ", "))...)
 		}
 	} else {
 		// If we're not preventing destroys, then we can force delete folders that have alert rules
@@ -173,9 +310,303 @@ func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 		deleteParams.WithForceDeleteRules(&force)
 	}
 
+	if target := d.Get("move_contents_to").(string); target != "" {
+		moved, err := moveFolderContents(client, uid, target, &diags)
+		if len(moved) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Moved %d items from folder %s to folder %s", len(moved), uid, target),
+				Detail:   strings.Join(moved, "\n"),
+			})
+		}
+		if err != nil {
+			return append(diags, diag.Errorf("failed to move the contents of folder %s to folder %s: %s", uid, target, err)...)
+		}
+	}
+
+	if deleteContents := d.Get("delete_contents").(string); deleteContents == "dashboards" || deleteContents == "all" {
+		deleted, err := deleteFolderContents(client, uid, deleteContents == "all", &diags)
+		if len(deleted) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Deleted %d items in folder %s", len(deleted), uid),
+				Detail:   strings.Join(deleted, "\n"),
+			})
+		}
+		if err != nil {
+			return append(diags, diag.Errorf("failed to delete the contents of folder %s: %s", uid, err)...)
+		}
+	}
+
 	_, err := client.Folders.DeleteFolder(deleteParams)
-	diag, _ := common.CheckReadError("folder", d, err)
-	return diag
+	readDiags, _ := common.CheckReadError("folder", d, err)
+	return append(diags, readDiags...)
+}
+
+// deleteFolderContents deletes the dashboards of a folder and its subfolders, and with `all`, their alert rules and library panels.
+// Alert rules are deleted first, and library panels last, as they can't be deleted while dashboards use them.
+// It returns the descriptions of the deleted items, including when it fails midway. The contents that can't be listed are skipped with a warning.
+func deleteFolderContents(client *goapi.GrafanaHTTPAPI, folderUID string, all bool, diags *diag.Diagnostics) ([]string, error) {
+	folder, err := client.Folders.GetFolderByUID(folderUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the folder: %w", err)
+	}
+	// The folder IDs are needed to list the library panels of the folders
+	folderIDs := map[string]int64{folderUID: folder.Payload.ID}
+	var dashboards []*models.Hit
+	for pending := []string{folderUID}; len(pending) > 0; pending = pending[1:] {
+		subfolders, folderDashboards, err := searchFolderContents(client, pending[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, subfolder := range subfolders {
+			if _, ok := folderIDs[subfolder.UID]; !ok {
+				folderIDs[subfolder.UID] = subfolder.ID
+				pending = append(pending, subfolder.UID)
+			}
+		}
+		dashboards = append(dashboards, folderDashboards...)
+	}
+
+	var deleted []string
+	if all {
+		rules, err := folderAlertRules(client, folderIDs)
+		if err = skipForbiddenFolderContents(err, diags); err != nil {
+			return deleted, err
+		}
+		for _, rule := range rules {
+			if _, err := client.Provisioning.DeleteAlertRule(provisioning.NewDeleteAlertRuleParams().WithUID(rule.uid)); err != nil {
+				return deleted, fmt.Errorf("failed to delete alert rule %s: %w", rule.uid, err)
+			}
+			deleted = append(deleted, fmt.Sprintf("alert rule %q (%s) of rule group %q", rule.title, rule.uid, rule.group))
+		}
+	}
+
+	for _, dashboard := range dashboards {
+		if _, err := client.Dashboards.DeleteDashboardByUID(dashboard.UID); err != nil && !common.IsNotFoundError(err) {
+			return deleted, fmt.Errorf("failed to delete dashboard %s: %w", dashboard.UID, err)
+		}
+		deleted = append(deleted, fmt.Sprintf("dashboard %q (%s)", dashboard.Title, dashboard.UID))
+	}
+
+	if all {
+		panels, err := folderLibraryPanels(client, folderIDs)
+		if err = skipForbiddenFolderContents(err, diags); err != nil {
+			return deleted, err
+		}
+		for _, panel := range panels {
+			if _, err := client.LibraryElements.DeleteLibraryElementByUID(panel.UID); err != nil {
+				return deleted, fmt.Errorf("failed to delete library panel %s (is it used by a dashboard in another folder?): %w", panel.UID, err)
+			}
+			deleted = append(deleted, fmt.Sprintf("library panel %q (%s)", panel.Name, panel.UID))
+		}
+	}
+
+	return deleted, nil
+}
+
+// moveFolderContents moves the subfolders, alert rules, dashboards and library panels of a folder to another folder.
+// The subfolders are moved with their contents. Alert rules keep their rule group.
+// It returns the descriptions of the moved items, including when it fails midway. The contents that can't be listed are skipped with a warning.
+func moveFolderContents(client *goapi.GrafanaHTTPAPI, folderUID, targetUID string, diags *diag.Diagnostics) ([]string, error) {
+	folder, err := client.Folders.GetFolderByUID(folderUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the folder: %w", err)
+	}
+	folderIDs := map[string]int64{folderUID: folder.Payload.ID}
+	subfolders, dashboards, err := searchFolderContents(client, folderUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []string
+	for _, subfolder := range subfolders {
+		if _, err := client.Folders.MoveFolder(subfolder.UID, &models.MoveFolderCommand{ParentUID: targetUID}); err != nil {
+			return moved, fmt.Errorf("failed to move folder %s: %w", subfolder.UID, err)
+		}
+		moved = append(moved, fmt.Sprintf("folder %q (%s)", subfolder.Title, subfolder.UID))
+	}
+
+	rules, err := folderAlertRules(client, folderIDs)
+	if err = skipForbiddenFolderContents(err, diags); err != nil {
+		return moved, err
+	}
+	for _, r := range rules {
+		resp, err := client.Provisioning.GetAlertRule(r.uid)
+		if err != nil {
+			return moved, fmt.Errorf("failed to get alert rule %s: %w", r.uid, err)
+		}
+		rule := resp.Payload
+		rule.FolderUID = &targetUID
+		params := provisioning.NewPutAlertRuleParams().WithUID(r.uid).WithBody(rule)
+		// Rules that weren't provisioned stay editable in the Grafana UI
+		if rule.Provenance == "" {
+			params.WithXDisableProvenance(common.Ref("true"))
+		}
+		if _, err := client.Provisioning.PutAlertRule(params); err != nil {
+			return moved, fmt.Errorf("failed to move alert rule %s: %w", r.uid, err)
+		}
+		moved = append(moved, fmt.Sprintf("alert rule %q (%s) of rule group %q", r.title, r.uid, r.group))
+	}
+
+	for _, dashboard := range dashboards {
+		resp, err := client.Dashboards.GetDashboardByUID(dashboard.UID)
+		if err != nil {
+			return moved, fmt.Errorf("failed to get dashboard %s: %w", dashboard.UID, err)
+		}
+		if _, err := client.Dashboards.PostDashboard(&models.SaveDashboardCommand{
+			Dashboard: resp.Payload.Dashboard,
+			FolderUID: targetUID,
+			Overwrite: true,
+			Message:   fmt.Sprintf("Moved from folder %s", folderUID),
+		}); err != nil {
+			return moved, fmt.Errorf("failed to move dashboard %s: %w", dashboard.UID, err)
+		}
+		moved = append(moved, fmt.Sprintf("dashboard %q (%s)", dashboard.Title, dashboard.UID))
+	}
+
+	panels, err := folderLibraryPanels(client, folderIDs)
+	if err = skipForbiddenFolderContents(err, diags); err != nil {
+		return moved, err
+	}
+	for _, panel := range panels {
+		if _, err := client.LibraryElements.UpdateLibraryElement(panel.UID, &models.PatchLibraryElementCommand{
+			FolderUID: targetUID,
+			Kind:      panel.Kind,
+			Model:     panel.Model,
+			Name:      panel.Name,
+			Version:   panel.Version,
+		}); err != nil {
+			return moved, fmt.Errorf("failed to move library panel %s: %w", panel.UID, err)
+		}
+		moved = append(moved, fmt.Sprintf("library panel %q (%s)", panel.Name, panel.UID))
+	}
+
+	return moved, nil
+}
+
+// searchFolderContents returns the subfolders and the dashboards of a folder, without the contents of the subfolders.
+func searchFolderContents(client *goapi.GrafanaHTTPAPI, folderUID string) (subfolders, dashboards []*models.Hit, err error) {
+	var page int64 = 1
+	for {
+		resp, err := client.Search.Search(search.NewSearchParams().WithFolderUIDs([]string{folderUID}).WithPage(&page))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to search the folder contents: %w", err)
+		}
+		if len(resp.Payload) == 0 {
+			return subfolders, dashboards, nil
+		}
+		for _, hit := range resp.Payload {
+			switch hit.Type {
+			case "dash-folder":
+				subfolders = append(subfolders, hit)
+			case "dash-db":
+				dashboards = append(dashboards, hit)
+			}
+		}
+		page++
+	}
+}
+
+type folderAlertRule struct {
+	uid, title, group string
+}
+
+// folderAlertRules returns the alert rules of the given folders, whose IDs are keyed by UID.
+// The rules are exported folder by folder, so that only read access to these folders is needed.
+func folderAlertRules(client *goapi.GrafanaHTTPAPI, folderIDs map[string]int64) ([]folderAlertRule, error) {
+	var rules []folderAlertRule
+	for _, folderUID := range slices.Sorted(maps.Keys(folderIDs)) {
+		params := provisioning.NewGetAlertRulesExportParams().WithFolderUID([]string{folderUID}).WithFormat(common.Ref("json"))
+		resp, err := client.Provisioning.GetAlertRulesExport(params)
+		if err != nil {
+			if common.IsNotFoundError(err) {
+				// The folder has no alert rules
+				continue
+			}
+			return nil, folderContentsError("alert rules", "alert.rules:read", folderUID, err)
+		}
+		for _, group := range resp.Payload.Groups {
+			for _, rule := range group.Rules {
+				rules = append(rules, folderAlertRule{uid: rule.UID, title: rule.Title, group: group.Name})
+			}
+		}
+	}
+	return rules, nil
+}
+
+// folderLibraryPanels returns the library panels of the given folders, whose IDs are keyed by UID.
+func folderLibraryPanels(client *goapi.GrafanaHTTPAPI, folderIDs map[string]int64) ([]*models.LibraryElementDTO, error) {
+	var filter []string
+	for _, id := range folderIDs {
+		filter = append(filter, strconv.FormatInt(id, 10))
+	}
+	slices.Sort(filter)
+
+	var panels []*models.LibraryElementDTO
+	var page int64 = 1
+	for {
+		params := library_elements.NewGetLibraryElementsParams().
+			WithKind(common.Ref(libraryPanelKind)).
+			WithFolderFilter(common.Ref(strings.Join(filter, ","))).
+			WithPage(&page)
+		resp, err := client.LibraryElements.GetLibraryElements(params)
+		if err != nil {
+			return nil, folderContentsError("library panels", "library.panels:read", "", err)
+		}
+		if len(resp.Payload.Result.Elements) == 0 {
+			return panels, nil
+		}
+		for _, panel := range resp.Payload.Result.Elements {
+			// The filter uses the deprecated folder IDs, the UIDs are checked as well
+			if _, ok := folderIDs[panel.FolderUID]; ok {
+				panels = append(panels, panel)
+			}
+		}
+		page++
+	}
+}
+
+// folderContentsForbiddenError is returned when the provider isn't allowed to list some contents of a folder.
+type folderContentsForbiddenError struct {
+	contents, permission, in string
+	err                      error
+}
+
+func (e *folderContentsForbiddenError) Error() string {
+	return fmt.Sprintf("the provider isn't allowed to list the %s of %s, so they were skipped. "+
+		"Grant the `%s` permission on the folder to the credentials of the provider to include them: %s", e.contents, e.in, e.permission, e.err)
+}
+
+func (e *folderContentsForbiddenError) Unwrap() error {
+	return e.err
+}
+
+// folderContentsError describes an error listing some contents of a folder. Forbidden requests return a folderContentsForbiddenError.
+func folderContentsError(contents, permission, folderUID string, err error) error {
+	in := "the folders"
+	if folderUID != "" {
+		in = "folder " + folderUID
+	}
+	if apiErr, ok := common.DecodeAPIError(err); ok && apiErr.StatusCode == http.StatusForbidden {
+		return &folderContentsForbiddenError{contents: contents, permission: permission, in: in, err: err}
+	}
+	return fmt.Errorf("failed to list the %s of %s: %w", contents, in, err)
+}
+
+// skipForbiddenFolderContents adds a warning for the folder contents that the provider isn't allowed to list, so that they are skipped
+// rather than failing the deletion of the folder. Other errors are returned unchanged.
+func skipForbiddenFolderContents(err error, diags *diag.Diagnostics) error {
+	var forbidden *folderContentsForbiddenError
+	if !errors.As(err, &forbidden) {
+		return err
+	}
+	*diags = append(*diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Skipped the %s of %s", forbidden.contents, forbidden.in),
+		Detail:   forbidden.Error(),
+	})
+	return nil
 }
 
 func ValidateFolderConfigJSON(configI interface{}, k string) ([]string, []error) {
@@ -42,6 +42,8 @@ type resourceFolderPermissionItemModel struct {
 	User       types.String `tfsdk:"user"`
 	Permission types.String `tfsdk:"permission"`
 	FolderUID  types.String `tfsdk:"folder_uid"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -53,6 +55,8 @@ func (m *resourceFolderPermissionItemModel) ToBase() *resourcePermissionItemBase
 		Team:       m.Team,
 		User:       m.User,
 		Permission: m.Permission,
+
+		GrafanaConnection: m.GrafanaConnection,
 	}
 }
 
@@ -64,6 +68,7 @@ func (m *resourceFolderPermissionItemModel) SetFromBase(base *resourcePermission
 	m.Team = base.Team
 	m.User = base.User
 	m.Permission = base.Permission
+	m.GrafanaConnection = base.GrafanaConnection
 }
 
 type resourceFolderPermissionItem struct{ resourcePermissionBase }
@@ -86,11 +91,15 @@ func (r *resourceFolderPermissionItem) Schema(ctx context.Context, req resource.
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(true),
+		},
 	}
 }
 
 func (r *resourceFolderPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.folderQuery)
+	connection, id := splitConnectionImportID(req.ID)
+	readData, diags := r.readItem(ctx, connection, id, r.folderQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -113,7 +122,7 @@ func (r *resourceFolderPermissionItem) Create(ctx context.Context, req resource.
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.FolderUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -128,7 +137,7 @@ func (r *resourceFolderPermissionItem) Read(ctx context.Context, req resource.Re
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.folderQuery)
+	readData, diags := r.readItem(ctx, data.GrafanaConnection, data.ID.ValueString(), r.folderQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -151,7 +160,7 @@ func (r *resourceFolderPermissionItem) Update(ctx context.Context, req resource.
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.FolderUID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -166,7 +175,7 @@ func (r *resourceFolderPermissionItem) Delete(ctx context.Context, req resource.
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.FolderUID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.FolderUID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
@@ -1,6 +1,7 @@
 package grafana_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -8,17 +9,494 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestFakeFolder_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_folder/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("grafana_folder.test_folder", "id", defaultOrgIDRegexp),
+					resource.TestCheckResourceAttr("grafana_folder.test_folder", "title", "Terraform Test Folder"),
+					resource.TestCheckResourceAttr("grafana_folder.test_folder_with_uid", "id", "1:test-folder-uid"),
+					resource.TestCheckResourceAttr("grafana_folder.test_folder_with_uid", "url", grafana.URL()+"/dashboards/f/test-folder-uid/terraform-test-folder-with-uid"),
+					resource.TestCheckResourceAttr("grafana_dashboard.test_folder", "uid", "dashboard-in-folder"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_folder.test_folder_with_uid"),
+				),
+			},
+			{
+				ResourceName:            "grafana_folder.test_folder_with_uid",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_folder/resource.tf", map[string]string{
+					"Terraform Test Folder": "Terraform Test Folder Updated",
+				}),
+				Check: resource.TestCheckResourceAttr("grafana_folder.test_folder", "title", "Terraform Test Folder Updated"),
+			},
+		},
+	})
+}
+
+func TestFakeFolder_parentFolderPath(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	config := func(createParentFolders bool) string {
+		return fmt.Sprintf(`
+resource "grafana_folder" "alerts" {
+  title                 = "Alerts"
+  parent_folder_path    = "Platform/Payments"
+  create_parent_folders = %t
+}
+
+data "grafana_folder" "alerts" {
+  path = "Platform/Payments/${grafana_folder.alerts.title}"
+}
+
+data "grafana_folders" "all" {
+  depends_on = [grafana_folder.alerts]
+}`, createParentFolders)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      config(false),
+				ExpectError: regexp.MustCompile(`folder with title "Platform" not found`),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_folder.alerts", "parent_folder_uid"),
+					resource.TestCheckResourceAttrPair("data.grafana_folder.alerts", "uid", "grafana_folder.alerts", "uid"),
+					resource.TestCheckResourceAttrPair("data.grafana_folder.alerts", "parent_folder_uid", "grafana_folder.alerts", "parent_folder_uid"),
+					resource.TestCheckResourceAttr("data.grafana_folder.alerts", "title", "Alerts"),
+					resource.TestCheckResourceAttr("data.grafana_folder.alerts", "parent_folder_path", "Platform/Payments"),
+					resource.TestCheckResourceAttr("data.grafana_folder.alerts", "path", "Platform/Payments/Alerts"),
+					resource.TestCheckResourceAttr("data.grafana_folders.all", "folders.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_folders.all", "folders.*", map[string]string{"title": "Platform", "path": "Platform", "parent_folder_uid": ""}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_folders.all", "folders.*", map[string]string{"title": "Alerts", "path": "Platform/Payments/Alerts"}),
+				),
+			},
+			{
+				ResourceName:            "grafana_folder.alerts",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"parent_folder_path", "create_parent_folders", "prevent_destroy_if_not_empty"},
+			},
+		},
+	})
+}
+
+func TestFakeFolder_parentFolderUID(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	config := func(parentFolderUID string) string {
+		return fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+  title = "Parent"
+  uid   = "parent"
+}
+
+resource "grafana_folder" "child" {
+  title             = "Child"
+  parent_folder_uid = %s
+}`, parentFolderUID)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("grafana_folder.parent.uid"),
+				Check:  resource.TestCheckResourceAttr("grafana_folder.child", "parent_folder_uid", "parent"),
+			},
+			// Removing the parent folder moves the folder to the root folder
+			{
+				Config: config("null"),
+				Check:  resource.TestCheckResourceAttr("grafana_folder.child", "parent_folder_uid", ""),
+			},
+		},
+	})
+}
+
+func TestFakeFolder_parentFolderPathAfterImport(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	config := func(child string) string {
+		return `
+resource "grafana_folder" "parent" {
+  title = "Parent"
+  uid   = "parent"
+}
+
+resource "grafana_folder" "other" {
+  title = "Other"
+  uid   = "other"
+}
+
+resource "grafana_folder" "child" {
+  title = "Child"
+  ` + child + `
+}`
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config(`parent_folder_uid = grafana_folder.parent.uid`),
+			},
+			{
+				ResourceName:       "grafana_folder.child",
+				ImportState:        true,
+				ImportStateId:      `folder:"Parent/Child"`,
+				ImportStatePersist: true,
+			},
+			// The path leads to the current parent folder: the folder isn't replaced
+			{
+				Config:   config(`parent_folder_path = "Parent"`),
+				PlanOnly: true,
+			},
+			{
+				Config: config(`parent_folder_path = "Parent"`),
+			},
+			// The path leads to another folder: the folder is replaced
+			{
+				Config: config(`parent_folder_path = "Other"`),
+				Check:  resource.TestCheckResourceAttr("grafana_folder.child", "parent_folder_uid", "other"),
+			},
+		},
+	})
+}
+
+func TestFakeFolder_deleteContents(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := func(deleteContents string) string {
+		return fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+  uid             = "parent"
+  title           = "Parent"
+  delete_contents = %q
+}`, deleteContents)
+	}
+	addContents := func() {
+		_, err := client.Folders.CreateFolder(&models.CreateFolderCommand{UID: "child", Title: "Child", ParentUID: "parent"})
+		require.NoError(t, err)
+		for _, folderUID := range []string{"parent", "child"} {
+			_, err := client.Dashboards.PostDashboard(&models.SaveDashboardCommand{
+				Dashboard: map[string]any{"uid": folderUID + "-dashboard", "title": "Dashboard"},
+				FolderUID: folderUID,
+			})
+			require.NoError(t, err)
+		}
+		_, err = client.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+			UID: "child-panel", Name: "Panel", Kind: 1, FolderUID: "child", Model: map[string]any{"type": "text"},
+		})
+		require.NoError(t, err)
+		title := "Rule"
+		_, err = client.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+			WithFolderUID("child").
+			WithGroup("group").
+			WithBody(&models.AlertRuleGroup{Title: "group", Interval: 60, Rules: []*models.ProvisionedAlertRule{{Title: &title}}}))
+		require.NoError(t, err)
+	}
+	checkDeleted := func(s *terraform.State) error {
+		if _, err := client.Folders.GetFolderByUID("parent"); !common.IsNotFoundError(err) {
+			return fmt.Errorf("expected the folder to be deleted, got %v", err)
+		}
+		panels, err := client.LibraryElements.GetLibraryElements(library_elements.NewGetLibraryElementsParams())
+		if err != nil {
+			return err
+		}
+		if len(panels.Payload.Result.Elements) > 0 {
+			return fmt.Errorf("expected the library panels to be deleted, got %d", len(panels.Payload.Result.Elements))
+		}
+		rules, err := client.Provisioning.GetAlertRules()
+		if err != nil {
+			return err
+		}
+		if len(rules.Payload) > 0 {
+			return fmt.Errorf("expected the alert rules to be deleted, got %d", len(rules.Payload))
+		}
+		return nil
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		CheckDestroy: checkDeleted,
+		Steps: []resource.TestStep{
+			{
+				Config: config("dashboards"),
+			},
+			{
+				// Library panels block the deletion of their folder, but the dashboards are deleted
+				PreConfig:   addContents,
+				Config:      config("dashboards"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`contains library elements in use`),
+			},
+			{
+				Config: config("all"),
+				Check: func(s *terraform.State) error {
+					dashboards, err := client.Search.Search(nil)
+					if err != nil {
+						return err
+					}
+					for _, hit := range dashboards.Payload {
+						if hit.Type == "dash-db" {
+							return fmt.Errorf("expected the dashboards to be deleted, found %s", hit.UID)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestFolderDeleteContents_otherFolders(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	client := fake.Client(t, fakeGrafana)
+
+	var folderResource *schema.Resource
+	for _, r := range grafana.Resources {
+		if r.Name == "grafana_folder" {
+			folderResource = r.Schema
+		}
+	}
+	require.NotNil(t, folderResource)
+
+	for _, folder := range []*models.CreateFolderCommand{
+		{UID: "parent", Title: "Parent"},
+		{UID: "child", Title: "Child", ParentUID: "parent"},
+		{UID: "other", Title: "Other"},
+	} {
+		_, err := client.GrafanaAPI.Folders.CreateFolder(folder)
+		require.NoError(t, err)
+	}
+	for _, folderUID := range []string{"child", "other"} {
+		_, err := client.GrafanaAPI.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+			UID: folderUID + "-panel", Name: "Panel", Kind: 1, FolderUID: folderUID, Model: map[string]any{"type": "text"},
+		})
+		require.NoError(t, err)
+		title := "Rule"
+		_, err = client.GrafanaAPI.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+			WithFolderUID(folderUID).
+			WithGroup("group").
+			WithBody(&models.AlertRuleGroup{Title: "group", Interval: 60, Rules: []*models.ProvisionedAlertRule{{Title: &title}}}))
+		require.NoError(t, err)
+	}
+
+	deleteFolder := func(uid string, attrs map[string]interface{}) diag.Diagnostics {
+		attrs["title"] = uid
+		d := schema.TestResourceDataRaw(t, folderResource.Schema, attrs)
+		d.SetId(grafana.MakeOrgResourceID(1, uid))
+		return folderResource.DeleteContext(context.Background(), d, client)
+	}
+
+	// The contents that the provider isn't allowed to list are skipped, with a warning
+	fakeGrafana.Forbidden = []string{"GET /api/v1/provisioning/alert-rules/export"}
+	diags := deleteFolder("other", map[string]interface{}{"prevent_destroy_if_not_empty": true})
+	require.True(t, diags.HasError())
+	require.Len(t, diags, 2)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Equal(t, "Skipped the alert rules of folder other", diags[0].Summary)
+	require.Contains(t, diags[0].Detail, "Grant the `alert.rules:read` permission on the folder")
+	require.Contains(t, diags[1].Summary, `It contains the following items: library panel "Panel"`)
+	require.NotContains(t, diags[1].Summary, "alert rule")
+
+	fakeGrafana.Forbidden = nil
+	diags = deleteFolder("parent", map[string]interface{}{"delete_contents": "all"})
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Deleted 2 items in folder parent", diags[0].Summary)
+
+	// The contents of other folders are kept
+	panels, err := client.GrafanaAPI.LibraryElements.GetLibraryElements(library_elements.NewGetLibraryElementsParams())
+	require.NoError(t, err)
+	require.Len(t, panels.Payload.Result.Elements, 1)
+	require.Equal(t, "other-panel", panels.Payload.Result.Elements[0].UID)
+	rules, err := client.GrafanaAPI.Provisioning.GetAlertRules()
+	require.NoError(t, err)
+	require.Len(t, rules.Payload, 1)
+	require.Equal(t, "other", *rules.Payload[0].FolderUID)
+}
+
+func TestFolderMoveContents(t *testing.T) {
+	fakeGrafana := fake.NewGrafana(t)
+	client := fake.Client(t, fakeGrafana)
+
+	var folderResource *schema.Resource
+	for _, r := range grafana.Resources {
+		if r.Name == "grafana_folder" {
+			folderResource = r.Schema
+		}
+	}
+	require.NotNil(t, folderResource)
+
+	for _, folder := range []*models.CreateFolderCommand{
+		{UID: "source", Title: "Source"},
+		{UID: "child", Title: "Child", ParentUID: "source"},
+		{UID: "target", Title: "Target"},
+	} {
+		_, err := client.GrafanaAPI.Folders.CreateFolder(folder)
+		require.NoError(t, err)
+	}
+	_, err := client.GrafanaAPI.Dashboards.PostDashboard(&models.SaveDashboardCommand{
+		Dashboard: map[string]any{"uid": "dashboard", "title": "Dashboard"},
+		FolderUID: "source",
+	})
+	require.NoError(t, err)
+	_, err = client.GrafanaAPI.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+		UID: "panel", Name: "Panel", Kind: 1, FolderUID: "source", Model: map[string]any{"type": "text"},
+	})
+	require.NoError(t, err)
+	title := "Rule"
+	group, err := client.GrafanaAPI.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+		WithFolderUID("source").
+		WithGroup("group").
+		WithBody(&models.AlertRuleGroup{Title: "group", Interval: 60, Rules: []*models.ProvisionedAlertRule{{Title: &title}}}))
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, folderResource.Schema, map[string]interface{}{"title": "Source", "move_contents_to": "target"})
+	d.SetId(grafana.MakeOrgResourceID(1, "source"))
+	diags := folderResource.DeleteContext(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Moved 4 items from folder source to folder target", diags[0].Summary)
+
+	_, err = client.GrafanaAPI.Folders.GetFolderByUID("source")
+	require.True(t, common.IsNotFoundError(err), "expected the folder to be deleted, got %v", err)
+	child, err := client.GrafanaAPI.Folders.GetFolderByUID("child")
+	require.NoError(t, err)
+	require.Equal(t, "target", child.Payload.ParentUID)
+	dashboard, err := client.GrafanaAPI.Dashboards.GetDashboardByUID("dashboard")
+	require.NoError(t, err)
+	require.Equal(t, "target", dashboard.Payload.Meta.FolderUID)
+	panel, err := client.GrafanaAPI.LibraryElements.GetLibraryElementByUID("panel")
+	require.NoError(t, err)
+	require.Equal(t, "target", panel.Payload.Result.FolderUID)
+	rule, err := client.GrafanaAPI.Provisioning.GetAlertRule(group.Payload.Rules[0].UID)
+	require.NoError(t, err)
+	require.Equal(t, "target", *rule.Payload.FolderUID)
+	require.Equal(t, "group", *rule.Payload.RuleGroup)
+}
+
+func TestFakeFolder_preventDestroyIfNotEmpty(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := `
+resource "grafana_folder" "test" {
+  uid                          = "test"
+  title                        = "Test"
+  prevent_destroy_if_not_empty = true
+}`
+	var ruleUID string
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					title := "Rule"
+					resp, err := client.Provisioning.PutAlertRuleGroup(provisioning.NewPutAlertRuleGroupParams().
+						WithFolderUID("test").
+						WithGroup("group").
+						WithBody(&models.AlertRuleGroup{Title: "group", Interval: 60, Rules: []*models.ProvisionedAlertRule{{Title: &title}}}))
+					require.NoError(t, err)
+					ruleUID = resp.Payload.Rules[0].UID
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`It contains the following items: alert rule "Rule"`),
+			},
+			{
+				PreConfig: func() {
+					_, err := client.Provisioning.DeleteAlertRule(provisioning.NewDeleteAlertRuleParams().WithUID(ruleUID))
+					require.NoError(t, err)
+					_, err = client.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+						UID: "panel", Name: "Panel", Kind: 1, FolderUID: "test", Model: map[string]any{"type": "text"},
+					})
+					require.NoError(t, err)
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`It contains the following items: library panel "Panel"`),
+			},
+			{
+				PreConfig: func() {
+					_, err := client.LibraryElements.DeleteLibraryElementByUID("panel")
+					require.NoError(t, err)
+				},
+				Config: config,
+			},
+		},
+	})
+}
+
+func TestFakeFolder_connection(t *testing.T) {
+	providerGrafana, otherGrafana := fake.NewGrafana(t), fake.NewGrafana(t)
+
+	fake.UnitTest(t, providerGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_folder" "test" {
+  uid   = "other-instance"
+  title = "Other Instance"
+
+  grafana_connection {
+    url  = "%s"
+    auth = "admin:admin"
+  }
+}`, otherGrafana.URL()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_folder.test", "url", otherGrafana.URL()+"/dashboards/f/other-instance/other-instance"),
+					func(s *terraform.State) error {
+						if _, err := fake.Client(t, otherGrafana).GrafanaAPI.Folders.GetFolderByUID("other-instance"); err != nil {
+							return fmt.Errorf("expected the folder in the instance of the connection: %w", err)
+						}
+						if _, err := fake.Client(t, providerGrafana).GrafanaAPI.Folders.GetFolderByUID("other-instance"); err == nil {
+							return fmt.Errorf("expected no folder in the instance of the provider")
+						}
+						return nil
+					},
+				),
+			},
+			// Resources in a stack are imported with the stack in the ID, since the configuration isn't available on import
+			{
+				ResourceName:  "grafana_folder.test",
+				ImportState:   true,
+				ImportStateId: "stack_slug=mystack/other-instance",
+				ExpectError:   regexp.MustCompile("the Cloud API client is required to connect to stack mystack"),
+			},
+		},
+	})
+}
+
 func TestAccFolder_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -166,6 +644,35 @@ resource grafana_folder child2 {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
 			},
+			// Test import using the folder path
+			{
+				ResourceName:            "grafana_folder.child2",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf(`folder:"Nested Test: Parent %[1]s/Nested Test: Child 1 %[1]s/Nested Test: Child 2 %[1]s"`, name),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+				ImportStatePersist:      true,
+			},
+			// Setting the path of the current parent folder doesn't replace the imported folder
+			{
+				Config: fmt.Sprintf(`
+resource grafana_folder parent {
+	title = "Nested Test: Parent %[1]s"
+}
+
+resource grafana_folder child1 {
+	title = "Nested Test: Child 1 %[1]s"
+	uid = "%[1]s-child1"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+resource grafana_folder child2 {
+	title = "Nested Test: Child 2 %[1]s"
+	parent_folder_path = "Nested Test: Parent %[1]s/Nested Test: Child 1 %[1]s"
+}
+`, name),
+				PlanOnly: true,
+			},
 		},
 	})
 }
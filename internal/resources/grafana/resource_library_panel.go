@@ -3,8 +3,11 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"strconv"
 
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -33,6 +36,20 @@ Manages Grafana library panels.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: checkDatasourceRefs(func(d *schema.ResourceDiff, refs *datasourceRefs) error {
+			config := d.GetRawConfig().GetAttr("model_json")
+			if !config.IsKnown() || config.IsNull() {
+				return nil
+			}
+			model, err := unmarshalLibraryPanelModelJSON(config.AsString())
+			if err != nil {
+				return nil
+			}
+			if err := refs.resolveModel(model); err != nil {
+				return fmt.Errorf("model_json: %w", err)
+			}
+			return nil
+		}),
 
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
@@ -108,6 +125,8 @@ Manages Grafana library panels.
 				Description: "Numerical IDs of Grafana dashboards containing the library panel.",
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 			},
+			"conflict_policy":     conflictPolicyAttribute(conflictPolicyFail),
+			"resolve_datasources": resolveDatasourcesAttribute("`model_json` (the panel and its queries)"),
 		},
 	}
 
@@ -134,13 +153,21 @@ func listLibraryPanels(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID
 	return ids, nil
 }
 
+// libraryPanelAPIFieldNames maps the fields of the library element API to the attributes of the resource.
+var libraryPanelAPIFieldNames = map[string]string{
+	"model": "model_json",
+}
+
 func createLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
 
 	panel := makeLibraryPanel(d)
+	if err := resolveModelDatasources(client, d, panel.Model); err != nil {
+		return diag.FromErr(err)
+	}
 	resp, err := client.LibraryElements.CreateLibraryElement(&panel)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating library panel", err, libraryPanelAPIFieldNames)
 	}
 	createdPanel := resp.Payload.Result
 	d.SetId(MakeOrgResourceID(createdPanel.OrgID, createdPanel.UID))
@@ -164,6 +191,9 @@ func readLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := unresolveModelDatasources(client, d, "model_json", remotePanelJSON); err != nil {
+		return diag.FromErr(err)
+	}
 	modelJSON := normalizeLibraryPanelModelJSON(remotePanelJSON)
 
 	d.SetId(MakeOrgResourceID(orgID, uid))
@@ -208,14 +238,61 @@ func updateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interf
 		Version: int64(d.Get("version").(int)),
 	}
 	_, body.FolderUID = SplitOrgResourceID(d.Get("folder_uid").(string))
+	if err := resolveModelDatasources(client, d, body.Model); err != nil {
+		return diag.FromErr(err)
+	}
 
-	resp, err := client.LibraryElements.UpdateLibraryElement(uid, &body)
+	// The API rejects updates if the version doesn't match the remote one
+	// Check it beforehand to give a meaningful error, or to use the remote version when overwriting
+	policy := getConflictPolicy(d, conflictPolicyFail)
+	currentResp, err := client.LibraryElements.GetLibraryElementByUID(uid)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	var diags diag.Diagnostics
+	if remotePanel := currentResp.Payload.Result; remotePanel.Version != body.Version {
+		stateModelJSON, _ := d.GetChange("model_json")
+		diags = conflictDiagnostics(
+			policy,
+			"library panel",
+			d.Id(),
+			fmt.Sprintf("version %d in state, version %d in Grafana", body.Version, remotePanel.Version),
+			"model_json",
+			libraryPanelRemoteChanges(stateModelJSON.(string), remotePanel.Model),
+		)
+		if diags.HasError() {
+			return diags
+		}
+		body.Version = remotePanel.Version
+	}
+
+	resp, err := client.LibraryElements.UpdateLibraryElement(uid, &body)
+	if err != nil {
+		if apiErr, ok := err.(runtime.ClientResponseStatus); ok && apiErr.IsCode(http.StatusPreconditionFailed) {
+			return append(diags, conflictDiagnostics(conflictPolicyFail, "library panel", d.Id(), "the version was changed while saving", "model_json", nil)...)
+		}
+		return append(diags, common.APIErrorDiagnostics("error updating library panel", err, libraryPanelAPIFieldNames)...)
+	}
 	updatedPanel := resp.Payload.Result
 	d.SetId(MakeOrgResourceID(updatedPanel.OrgID, updatedPanel.UID))
-	return readLibraryPanel(ctx, d, meta)
+	return append(diags, readLibraryPanel(ctx, d, meta)...)
+}
+
+// libraryPanelRemoteChanges lists the changes between the library panel model stored in the state and the remote one.
+func libraryPanelRemoteChanges(stateModelJSON string, remoteModel interface{}) []string {
+	stateModel, err := unmarshalLibraryPanelModelJSON(stateModelJSON)
+	if err != nil {
+		return nil
+	}
+	remoteModelJSON, err := json.Marshal(remoteModel)
+	if err != nil {
+		return nil
+	}
+	normalizedRemoteModel, err := unmarshalLibraryPanelModelJSON(normalizeLibraryPanelModelJSON(string(remoteModelJSON)))
+	if err != nil {
+		return nil
+	}
+	return common.JSONChanges(stateModel, normalizedRemoteModel)
 }
 
 func deleteLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
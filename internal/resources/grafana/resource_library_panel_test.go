@@ -6,11 +6,57 @@ import (
 
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
+func TestFakeLibraryPanel_conflictPolicy(t *testing.T) {
+	for _, tc := range conflictPolicyTestCases {
+		t.Run(conflictPolicyTestName(tc.policy, tc.editRemotely), func(t *testing.T) {
+			fakeGrafana := fake.NewGrafana(t)
+			client := fake.Client(t, fakeGrafana).GrafanaAPI
+			_, err := client.LibraryElements.CreateLibraryElement(&models.CreateLibraryElementCommand{
+				UID:   "conflict",
+				Name:  "Panel",
+				Kind:  1,
+				Model: map[string]interface{}{"title": "Initial", "type": "text"},
+			})
+			require.NoError(t, err)
+
+			server := fake.ProviderServer(t, fakeGrafana)
+			state, identity := testutils.ReadResource(t, server, "grafana_library_panel", "1:conflict")
+			if tc.editRemotely {
+				_, err := client.LibraryElements.UpdateLibraryElement("conflict", &models.PatchLibraryElementCommand{
+					Name:    "Panel",
+					Kind:    1,
+					Model:   map[string]interface{}{"title": "Edited in Grafana", "type": "text"},
+					Version: 1,
+				})
+				require.NoError(t, err)
+			}
+
+			diags := testutils.ApplyResourceChange(t, server, "grafana_library_panel", state, identity, map[string]tftypes.Value{
+				"name":            tftypes.NewValue(tftypes.String, "Panel"),
+				"model_json":      tftypes.NewValue(tftypes.String, `{"title":"From Terraform","type":"text"}`),
+				"conflict_policy": tftypes.NewValue(tftypes.String, tc.policy),
+			})
+			checkConflictDiagnostics(t, diags, tc.expectedSeverity, "title changed")
+
+			resp, err := client.LibraryElements.GetLibraryElementByUID("conflict")
+			require.NoError(t, err)
+			expectedTitle := "Edited in Grafana"
+			if tc.expectOverwrite {
+				expectedTitle = "From Terraform"
+			}
+			require.Equal(t, expectedTitle, resp.Payload.Result.Model.(map[string]interface{})["title"])
+		})
+	}
+}
+
 func TestAccLibraryPanel_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
 
@@ -13,6 +13,7 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -47,6 +48,10 @@ func resourceOrganization() *common.Resource {
 This resource represents an instance-scoped resource and uses Grafana's admin APIs.
 It does not work with API tokens or service accounts which are org-scoped.
 You must use basic auth.
+
+The ` + "`admins`, `editors`, `viewers` and `users_without_access`" + ` attributes manage the entire set of users of the organization:
+users that aren't listed in any of them are removed from the organization. Set ` + "`manage_users`" + ` to false to manage them
+with the ` + "`grafana_organization_user`" + ` resource instead.
 `,
 
 		CreateContext: CreateOrganization,
@@ -56,6 +61,10 @@ You must use basic auth.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			checkUnmanagedMembers("manage_users", organizationRoleAttributes...),
+			warnRemovedOrganizationUsers,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -104,6 +113,7 @@ A list of email addresses corresponding to users who should be given admin
 access to the organization. Note: users specified here must already exist in
 Grafana unless 'create_users' is set to true.
 `,
+				DiffSuppressFunc: suppressUnmanagedUsers,
 			},
 			"editors": {
 				Type:     schema.TypeSet,
@@ -116,6 +126,7 @@ A list of email addresses corresponding to users who should be given editor
 access to the organization. Note: users specified here must already exist in
 Grafana unless 'create_users' is set to true.
 `,
+				DiffSuppressFunc: suppressUnmanagedUsers,
 			},
 			"viewers": {
 				Type:     schema.TypeSet,
@@ -128,6 +139,7 @@ A list of email addresses corresponding to users who should be given viewer
 access to the organization. Note: users specified here must already exist in
 Grafana unless 'create_users' is set to true.
 `,
+				DiffSuppressFunc: suppressUnmanagedUsers,
 			},
 			"users_without_access": {
 				Type:     schema.TypeSet,
@@ -139,6 +151,19 @@ Grafana unless 'create_users' is set to true.
 A list of email addresses corresponding to users who should be given none access to the organization.
 Note: users specified here must already exist in Grafana, unless 'create_users' is
 set to true. This feature is only available in Grafana 10.2+.
+`,
+				DiffSuppressFunc: suppressUnmanagedUsers,
+			},
+			"manage_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == new || (old == "" && new == "true")
+				},
+				Description: `
+Whether the users of the organization are managed with the ` + "`admins`, `editors`, `viewers` and `users_without_access`" + ` attributes.
+Set it to false to manage them with the ` + "`grafana_organization_user`" + ` resource, or outside of Terraform. These attributes can't be set then.
 `,
 			},
 		},
@@ -187,8 +212,10 @@ func CreateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(*resp.Payload.OrgID, 10))
-	if err = UpdateUsers(d, meta); err != nil {
-		return diag.FromErr(err)
+	if manageMembers(d.GetRawConfig(), "manage_users") {
+		if err = UpdateUsers(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return ReadOrganization(ctx, d, meta)
@@ -227,8 +254,10 @@ func UpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 			return diag.FromErr(err)
 		}
 	}
-	if err := UpdateUsers(d, meta); err != nil {
-		return diag.FromErr(err)
+	if manageMembers(d.GetRawConfig(), "manage_users") {
+		if err := UpdateUsers(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
@@ -245,6 +274,42 @@ func DeleteOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 	return diag
 }
 
+var organizationRoleAttributes = []string{"admins", "editors", "viewers", "users_without_access"}
+
+// suppressUnmanagedUsers suppresses the diff of the role attributes when the users of the organization aren't managed by the resource.
+func suppressUnmanagedUsers(k, old, new string, d *schema.ResourceData) bool {
+	return !manageMembers(d.GetRawConfig(), "manage_users")
+}
+
+// warnRemovedOrganizationUsers adds a warning to the plan when users will be removed from the organization.
+// They may have been added with grafana_organization_user resources, which conflict with the role attributes.
+func warnRemovedOrganizationUsers(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !manageMembers(d.GetRawConfig(), "manage_users") {
+		return nil
+	}
+	oldUsers, newUsers := &schema.Set{F: schema.HashString}, &schema.Set{F: schema.HashString}
+	for _, attribute := range organizationRoleAttributes {
+		if !d.NewValueKnown(attribute) {
+			return nil
+		}
+		oldValue, newValue := d.GetChange(attribute)
+		oldUsers = oldUsers.Union(oldValue.(*schema.Set))
+		newUsers = newUsers.Union(newValue.(*schema.Set))
+	}
+	removed := setDifference(oldUsers, newUsers)
+	if len(removed) == 0 {
+		return nil
+	}
+	common.AddPlanWarning(ctx,
+		fmt.Sprintf("Users will be removed from organization %s", d.Id()),
+		fmt.Sprintf("The `admins`, `editors`, `viewers` and `users_without_access` attributes manage the entire set of users of the organization. These users aren't listed and will be removed: %s.\n"+
+			"If they are managed with grafana_organization_user resources, or outside of Terraform, set `manage_users` to false.",
+			strings.Join(removed, ", ")),
+		nil,
+	)
+	return nil
+}
+
 func ReadUsers(d *schema.ResourceData, meta interface{}) error {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
@@ -283,9 +348,8 @@ func UpdateUsers(d *schema.ResourceData, meta interface{}) error {
 }
 
 func collectUsers(d *schema.ResourceData) (map[string]OrgUser, map[string]OrgUser, error) {
-	roles := []string{"admins", "editors", "viewers", "users_without_access"}
 	stateUsers, configUsers := make(map[string]OrgUser), make(map[string]OrgUser)
-	for _, role := range roles {
+	for _, role := range organizationRoleAttributes {
 		roleName := getRoleName(role)
 		// Get the lists of users read in from Grafana state (old) and configured (new)
 		state, config := d.GetChange(role)
@@ -7,10 +7,59 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
+func TestFakeOrganization_removedUsersWarning(t *testing.T) {
+	server := fake.ProviderServer(t, fake.NewGrafana(t))
+	users := func(emails ...string) tftypes.Value {
+		var values []tftypes.Value
+		for _, email := range emails {
+			values = append(values, tftypes.NewValue(tftypes.String, email))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+	state := map[string]tftypes.Value{
+		"id":                   tftypes.NewValue(tftypes.String, "2"),
+		"org_id":               tftypes.NewValue(tftypes.Number, 2),
+		"name":                 tftypes.NewValue(tftypes.String, "fake-org"),
+		"admins":               users("admin-1@example.com"),
+		"editors":              users("editor-1@example.com"),
+		"viewers":              users("viewer-1@example.com"),
+		"users_without_access": users(),
+	}
+
+	// The admin becomes an editor, the other users are removed
+	unset := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil)
+	diags := testutils.PlanResourceChange(t, server, "grafana_organization", state, map[string]tftypes.Value{
+		"name":                 tftypes.NewValue(tftypes.String, "fake-org"),
+		"admins":               unset,
+		"editors":              users("admin-1@example.com"),
+		"viewers":              unset,
+		"users_without_access": unset,
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+	require.Equal(t, "Users will be removed from organization 2", diags[0].Summary)
+	require.Contains(t, diags[0].Detail, "These users aren't listed and will be removed: editor-1@example.com, viewer-1@example.com.")
+
+	// Users aren't removed when they aren't managed
+	diags = testutils.PlanResourceChange(t, server, "grafana_organization", state, map[string]tftypes.Value{
+		"name":                 tftypes.NewValue(tftypes.String, "fake-org"),
+		"admins":               unset,
+		"editors":              unset,
+		"viewers":              unset,
+		"users_without_access": unset,
+		"manage_users":         tftypes.NewValue(tftypes.Bool, false),
+	})
+	require.Empty(t, diags)
+}
+
 func TestAccOrganization_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -138,6 +187,25 @@ func TestAccOrganization_users(t *testing.T) {
 					),
 				),
 			},
+			// The users are managed by default, so leaving the role attributes unset also removes them
+			{
+				Config: testAccOrganizationConfig_usersCreate,
+				Check: resource.TestCheckResourceAttr(
+					"grafana_organization.test", "admins.#", "1",
+				),
+			},
+			{
+				Config: testAccOrganizationConfig_usersUnset,
+				Check: resource.ComposeTestCheckFunc(
+					orgCheckExists.exists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttr(
+						"grafana_organization.test", "admins.#", "0",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_organization.test", "editors.#", "0",
+					),
+				),
+			},
 		},
 	})
 }
@@ -370,6 +438,15 @@ resource "grafana_organization" "test" {
 `
 
 const testAccOrganizationConfig_usersRemove = `
+resource "grafana_organization" "test" {
+    name = "terraform-acc-test"
+    admin_user = "admin"
+    create_users = false
+    admins = []
+}
+`
+
+const testAccOrganizationConfig_usersUnset = `
 resource "grafana_organization" "test" {
     name = "terraform-acc-test"
     admin_user = "admin"
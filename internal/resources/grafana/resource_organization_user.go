@@ -0,0 +1,286 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	resourceOrganizationUserName = "grafana_organization_user"
+	resourceOrganizationUserID   = common.NewResourceID(common.OptionalIntIDField("orgID"), common.IntIDField("userID"))
+
+	organizationUserRoles = []string{"Admin", "Editor", "Viewer", "None"}
+
+	// Check interface
+	_ resource.ResourceWithImportState = (*resourceOrganizationUser)(nil)
+)
+
+func makeResourceOrganizationUser() *common.Resource {
+	return common.NewResource(
+		common.CategoryGrafanaOSS,
+		resourceOrganizationUserName,
+		resourceOrganizationUserID,
+		&resourceOrganizationUser{},
+	).WithLister(listerFunctionOrgResource(listOrganizationUsers))
+}
+
+type resourceOrganizationUserModel struct {
+	ID     types.String `tfsdk:"id"`
+	OrgID  types.String `tfsdk:"org_id"`
+	UserID types.String `tfsdk:"user_id"`
+	Role   types.String `tfsdk:"role"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
+}
+
+type resourceOrganizationUser struct {
+	basePluginFrameworkResource
+}
+
+func (r *resourceOrganizationUser) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = resourceOrganizationUserName
+}
+
+func (r *resourceOrganizationUser) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the membership of a single user in an organization, and its role. Conflicts with the ` + "`admins`, `editors`, `viewers` and `users_without_access`" + ` attributes of the "grafana_organization" resource which manage the entire set of users of an organization.
+Use this resource to add users to an organization that is managed elsewhere, for example when several configurations add their own users to a shared organization.
+Unlike the "grafana_organization" resource, it uses the organization APIs, so it can be used with a service account of the organization.
+
+If the user is already a member of the organization when the resource is created (for example, when users are automatically added to the organization when they sign up), the membership is adopted, its role is updated, and a warning is reported.
+
+To migrate from the attributes of the ` + "`grafana_organization`" + ` resource, add a ` + "`grafana_organization_user`" + ` resource and an ` + "`import`" + ` block for each user, then remove the ` + "`admins`, `editors`, `viewers` and `users_without_access`" + ` attributes from the ` + "`grafana_organization`" + ` resource and set its ` + "`manage_users`" + ` attribute to false.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/user-management/manage-org-users/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/org/#add-user-in-current-organization)
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": pluginFrameworkOrgIDAttribute(),
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user to add to the organization.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: common.AllowedValuesDescription("The role of the user in the organization", organizationUserRoles),
+				Validators: []validator.String{
+					stringvalidator.OneOf(organizationUserRoles...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(true),
+		},
+	}
+}
+
+func listOrganizationUsers(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
+	resp, err := client.Org.GetOrgUsersForCurrentOrg()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, user := range resp.Payload {
+		ids = append(ids, resourceOrganizationUserID.Make(orgID, user.UserID))
+	}
+	return ids, nil
+}
+
+func (r *resourceOrganizationUser) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	connection, id := splitConnectionImportID(req.ID)
+	data, diags := r.read(ctx, connection, id)
+	if diags != nil {
+		resp.Diagnostics = diags
+		return
+	}
+	if data == nil {
+		resp.Diagnostics.AddError("Resource not found", "Resource not found")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *resourceOrganizationUser) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data resourceOrganizationUserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, orgID, err := r.clientFromNewOrgResource(ctx, data.GrafanaConnection, data.OrgID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	userID, err := strconv.ParseInt(data.UserID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_id"), "Failed to parse user ID", err.Error())
+		return
+	}
+	role := data.Role.ValueString()
+
+	// A user that was added by another configuration (or by the grafana_organization resource, or when signing up) is adopted
+	orgUser, err := findOrganizationUser(client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get organization users", err.Error())
+		return
+	}
+	if orgUser != nil {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("User %d is already a member of organization %d with the %s role", userID, orgID, orgUser.Role),
+			"The existing membership is now managed by this resource. "+
+				"If the user is also listed in the `admins`, `editors`, `viewers` or `users_without_access` attributes of the grafana_organization resource, remove it from the list: "+
+				"these attributes manage the entire set of users of the organization and remove the users that aren't listed.",
+		)
+		if orgUser.Role != role {
+			if _, err := client.Org.UpdateOrgUserForCurrentOrg(userID, &models.UpdateOrgUserCommand{Role: role}); err != nil {
+				resp.Diagnostics.AddError("Failed to update organization user", err.Error())
+				return
+			}
+		}
+	} else {
+		// Users are added by login or email
+		userResp, err := client.Users.GetUserByID(userID)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("user_id"), "Failed to get user", err.Error())
+			return
+		}
+		if _, err := client.Org.AddOrgUserToCurrentOrg(&models.AddOrgUserCommand{LoginOrEmail: userResp.Payload.Login, Role: role}); err != nil {
+			resp.Diagnostics.AddError("Failed to add organization user", err.Error())
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	data.ID = types.StringValue(resourceOrganizationUserID.Make(orgID, userID))
+	data.OrgID = types.StringValue(strconv.FormatInt(orgID, 10))
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *resourceOrganizationUser) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform state data into the model
+	var data resourceOrganizationUserModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// Read from API
+	readData, diags := r.read(ctx, data.GrafanaConnection, data.ID.ValueString())
+	if diags != nil {
+		resp.Diagnostics = diags
+		return
+	}
+	if readData == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
+}
+
+func (r *resourceOrganizationUser) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only the role can be updated, other attributes require replacement
+	var data resourceOrganizationUserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, _, idFields, err := r.clientFromExistingOrgResource(ctx, data.GrafanaConnection, resourceOrganizationUserID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	userID := idFields[0].(int64)
+
+	if _, err := client.Org.UpdateOrgUserForCurrentOrg(userID, &models.UpdateOrgUserCommand{Role: data.Role.ValueString()}); err != nil {
+		resp.Diagnostics.AddError("Failed to update organization user", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *resourceOrganizationUser) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data resourceOrganizationUserModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	client, _, idFields, err := r.clientFromExistingOrgResource(ctx, data.GrafanaConnection, resourceOrganizationUserID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	userID := idFields[0].(int64)
+
+	_, err = client.Org.RemoveOrgUserForCurrentOrg(userID)
+	if err != nil && !common.IsNotFoundError(err) {
+		resp.Diagnostics.AddError("Failed to remove organization user", err.Error())
+	}
+}
+
+func (r *resourceOrganizationUser) read(ctx context.Context, connection []connectionModel, id string) (*resourceOrganizationUserModel, diag.Diagnostics) {
+	client, orgID, idFields, err := r.clientFromExistingOrgResource(ctx, connection, resourceOrganizationUserID, id)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
+	}
+	userID := idFields[0].(int64)
+
+	orgUser, err := findOrganizationUser(client, userID)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get organization users", err.Error())}
+	}
+	if orgUser == nil {
+		return nil, nil
+	}
+
+	return &resourceOrganizationUserModel{
+		ID:                types.StringValue(resourceOrganizationUserID.Make(orgID, userID)),
+		OrgID:             types.StringValue(strconv.FormatInt(orgID, 10)),
+		UserID:            types.StringValue(strconv.FormatInt(userID, 10)),
+		Role:              types.StringValue(orgUser.Role),
+		GrafanaConnection: connection,
+	}, nil
+}
+
+// findOrganizationUser returns the user of the organization of the client with the given ID, or nil if the user isn't a member of the organization.
+func findOrganizationUser(client *goapi.GrafanaHTTPAPI, userID int64) (*models.OrgUserDTO, error) {
+	resp, err := client.Org.GetOrgUsersForCurrentOrg()
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range resp.Payload {
+		if user.UserID == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,141 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeOrganizationUser(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	userIDs := createFakeUsers(t, client, "new-user", "existing-user")
+	// Users are added to the organization when they are created
+	_, err := client.Org.RemoveOrgUserForCurrentOrg(userIDs[0])
+	require.NoError(t, err)
+
+	config := func(newUserRole string, withExistingUser bool) string {
+		config := fmt.Sprintf(`
+resource "grafana_organization_user" "new" {
+	user_id = "%d"
+	role    = "%s"
+}
+`, userIDs[0], newUserRole)
+		if withExistingUser {
+			config += fmt.Sprintf(`
+resource "grafana_organization_user" "existing" {
+	user_id = "%d"
+	role    = "Admin"
+}
+`, userIDs[1])
+		}
+		return config
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("Editor", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_organization_user.new", "id", fmt.Sprintf("1:%d", userIDs[0])),
+					resource.TestCheckResourceAttr("grafana_organization_user.new", "org_id", "1"),
+					checkOrganizationUserRole(client, userIDs[0], "Editor"),
+					// The existing user is adopted, and its role is updated
+					checkOrganizationUserRole(client, userIDs[1], "Admin"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_organization_user.new"),
+				),
+			},
+			{
+				Config: config("Viewer", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_organization_user.new", "role", "Viewer"),
+					checkOrganizationUserRole(client, userIDs[0], "Viewer"),
+				),
+			},
+			{
+				ResourceName:      "grafana_organization_user.new",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("Viewer", false),
+				Check: resource.ComposeTestCheckFunc(
+					checkOrganizationUserRole(client, userIDs[0], "Viewer"),
+					checkOrganizationUserRole(client, userIDs[1], ""),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeOrganizationUser_connection(t *testing.T) {
+	providerGrafana, otherGrafana := fake.NewGrafana(t), fake.NewGrafana(t)
+	otherClient := fake.Client(t, otherGrafana).GrafanaAPI
+	userIDs := createFakeUsers(t, otherClient, "other-user")
+
+	config := func(role string) string {
+		return fmt.Sprintf(`
+resource "grafana_organization_user" "test" {
+	user_id = "%d"
+	role    = "%s"
+
+	grafana_connection {
+		url  = "%s"
+		auth = "admin:admin"
+	}
+}
+`, userIDs[0], role, otherGrafana.URL())
+	}
+
+	fake.UnitTest(t, providerGrafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("Editor"),
+				Check:  checkOrganizationUserRole(otherClient, userIDs[0], "Editor"),
+			},
+			{
+				Config: config("Viewer"),
+				Check:  checkOrganizationUserRole(otherClient, userIDs[0], "Viewer"),
+			},
+			// Resources with an url connection can't be imported, but resources in a stack can
+			{
+				ResourceName:  "grafana_organization_user.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("stack_slug=mystack/%d", userIDs[0]),
+				ExpectError:   regexp.MustCompile("the Cloud API client is required to connect to stack mystack"),
+			},
+			{
+				Config:  config("Viewer"),
+				Destroy: true,
+				Check:   checkOrganizationUserRole(otherClient, userIDs[0], ""),
+			},
+		},
+	})
+}
+
+// checkOrganizationUserRole checks the role of a user in the organization of the client. An empty role means that the user isn't a member of the organization.
+func checkOrganizationUserRole(client *goapi.GrafanaHTTPAPI, userID int64, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resp, err := client.Org.GetOrgUsersForCurrentOrg()
+		if err != nil {
+			return err
+		}
+		role := ""
+		for _, user := range resp.Payload {
+			if user.UserID == userID {
+				role = user.Role
+			}
+		}
+		if role != expected {
+			return fmt.Errorf("expected user %d to have the role %q, got %q", userID, expected, role)
+		}
+		return nil
+	}
+}
@@ -249,7 +249,8 @@ func resourceReport() *common.Resource {
 		"grafana_report",
 		orgResourceIDInt("id"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listReports))
+	).WithLister(listerFunctionOrgResource(listReports)).
+		WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func listReports(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -112,7 +112,7 @@ func resourceRole() *common.Resource {
 		"grafana_role",
 		orgResourceIDString("uid"),
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func CreateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
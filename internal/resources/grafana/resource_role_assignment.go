@@ -78,7 +78,7 @@ Manages the entire set of assignments for a role. Assignments that aren't specif
 		"grafana_role_assignment",
 		orgResourceIDString("roleUID"),
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func ReadRoleAssignments(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
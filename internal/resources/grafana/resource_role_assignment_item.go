@@ -33,7 +33,7 @@ func makeResourceRoleAssignmentItem() *common.Resource {
 		resourceRoleAssignmentItemName,
 		resourceRoleAssignmentItemID,
 		&resourceRoleAssignmentItem{},
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 type resourceRoleAssignmentItemModel struct {
@@ -43,6 +43,8 @@ type resourceRoleAssignmentItemModel struct {
 	TeamID           types.String `tfsdk:"team_id"`
 	UserID           types.String `tfsdk:"user_id"`
 	ServiceAccountID types.String `tfsdk:"service_account_id"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
 }
 
 type resourceRoleAssignmentItem struct {
@@ -110,13 +112,17 @@ func (r *resourceRoleAssignmentItem) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(false),
+		},
 	}
 }
 
 func (r *resourceRoleAssignmentItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceRoleAssignmentMutex.RLock()
 	defer resourceRoleAssignmentMutex.RUnlock()
-	data, diags := r.read(req.ID)
+	connection, id := splitConnectionImportID(req.ID)
+	data, diags := r.read(ctx, connection, id)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -137,7 +143,7 @@ func (r *resourceRoleAssignmentItem) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	client, orgID, err := r.clientFromNewOrgResource(data.OrgID.ValueString())
+	client, orgID, err := r.clientFromNewOrgResource(ctx, data.GrafanaConnection, data.OrgID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get client", err.Error())
 		return
@@ -211,7 +217,7 @@ func (r *resourceRoleAssignmentItem) Read(ctx context.Context, req resource.Read
 	// Read from API
 	resourceRoleAssignmentMutex.RLock()
 	defer resourceRoleAssignmentMutex.RUnlock()
-	readData, diags := r.read(data.ID.ValueString())
+	readData, diags := r.read(ctx, data.GrafanaConnection, data.ID.ValueString())
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -235,7 +241,7 @@ func (r *resourceRoleAssignmentItem) Delete(ctx context.Context, req resource.De
 	var data resourceRoleAssignmentItemModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	client, _, idFields, err := r.clientFromExistingOrgResource(resourceRoleAssignmentItemID, data.ID.ValueString())
+	client, _, idFields, err := r.clientFromExistingOrgResource(ctx, data.GrafanaConnection, resourceRoleAssignmentItemID, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get client", err.Error())
 		return
@@ -292,8 +298,8 @@ func (r *resourceRoleAssignmentItem) Delete(ctx context.Context, req resource.De
 	}
 }
 
-func (r *resourceRoleAssignmentItem) read(id string) (*resourceRoleAssignmentItemModel, diag.Diagnostics) {
-	client, orgID, idFields, err := r.clientFromExistingOrgResource(resourceRoleAssignmentItemID, id)
+func (r *resourceRoleAssignmentItem) read(ctx context.Context, connection []connectionModel, id string) (*resourceRoleAssignmentItemModel, diag.Diagnostics) {
+	client, orgID, idFields, err := r.clientFromExistingOrgResource(ctx, connection, resourceRoleAssignmentItemID, id)
 	if err != nil {
 		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
 	}
@@ -316,9 +322,10 @@ func (r *resourceRoleAssignmentItem) read(id string) (*resourceRoleAssignmentIte
 
 	// Find the assignment
 	data := &resourceRoleAssignmentItemModel{
-		ID:      types.StringValue(id),
-		OrgID:   types.StringValue(strconv.FormatInt(orgID, 10)),
-		RoleUID: types.StringValue(roleUID),
+		ID:                types.StringValue(id),
+		OrgID:             types.StringValue(strconv.FormatInt(orgID, 10)),
+		RoleUID:           types.StringValue(roleUID),
+		GrafanaConnection: connection,
 	}
 	switch assignmentType {
 	case "team":
@@ -62,7 +62,8 @@ func resourceServiceAccount() *common.Resource {
 		"grafana_service_account",
 		orgResourceIDInt("id"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listServiceAccounts))
+	).WithLister(listerFunctionOrgResource(listServiceAccounts)).
+		WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func listServiceAccounts(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -50,7 +50,7 @@ Manages the entire set of permissions for a service account. Permissions that ar
 		"grafana_service_account_permission",
 		orgResourceIDInt("serviceAccountID"),
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.2.4"})
 }
 
 func resourceServiceAccountPermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
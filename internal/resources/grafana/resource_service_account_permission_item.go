@@ -45,6 +45,8 @@ type resourceServiceAccountPermissionItemModel struct {
 	User             types.String `tfsdk:"user"`
 	Permission       types.String `tfsdk:"permission"`
 	ServiceAccountID types.String `tfsdk:"service_account_id"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
 }
 
 // Framework doesn't support embedding a base struct: https://github.com/hashicorp/terraform-plugin-framework/issues/242
@@ -55,6 +57,8 @@ func (m *resourceServiceAccountPermissionItemModel) ToBase() *resourcePermission
 		Team:       m.Team,
 		User:       m.User,
 		Permission: m.Permission,
+
+		GrafanaConnection: m.GrafanaConnection,
 	}
 }
 
@@ -65,6 +69,7 @@ func (m *resourceServiceAccountPermissionItemModel) SetFromBase(base *resourcePe
 	m.Team = base.Team
 	m.User = base.User
 	m.Permission = base.Permission
+	m.GrafanaConnection = base.GrafanaConnection
 }
 
 type resourceServiceAccountPermissionItem struct{ resourcePermissionBase }
@@ -87,6 +92,9 @@ func (r *resourceServiceAccountPermissionItem) Schema(ctx context.Context, req r
 				},
 			},
 		}),
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(true),
+		},
 	}
 
 	// Role is not supported for service account permissions
@@ -105,7 +113,8 @@ func (r *resourceServiceAccountPermissionItem) Schema(ctx context.Context, req r
 }
 
 func (r *resourceServiceAccountPermissionItem) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	readData, diags := r.readItem(req.ID, r.serviceAccountQuery)
+	connection, id := splitConnectionImportID(req.ID)
+	readData, diags := r.readItem(ctx, connection, id, r.serviceAccountQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -128,7 +137,7 @@ func (r *resourceServiceAccountPermissionItem) Create(ctx context.Context, req r
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -144,7 +153,7 @@ func (r *resourceServiceAccountPermissionItem) Read(ctx context.Context, req res
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	// Read from API
-	readData, diags := r.readItem(data.ID.ValueString(), r.serviceAccountQuery)
+	readData, diags := r.readItem(ctx, data.GrafanaConnection, data.ID.ValueString(), r.serviceAccountQuery)
 	if diags != nil {
 		resp.Diagnostics = diags
 		return
@@ -168,7 +177,7 @@ func (r *resourceServiceAccountPermissionItem) Update(ctx context.Context, req r
 		return
 	}
 	base := data.ToBase()
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), base); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), base); diags != nil {
 		resp.Diagnostics = diags
 		return
 	}
@@ -184,7 +193,7 @@ func (r *resourceServiceAccountPermissionItem) Delete(ctx context.Context, req r
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	data.Permission = types.StringValue("")
 
-	if diags := r.writeItem(data.ServiceAccountID.ValueString(), data.ToBase()); diags != nil {
+	if diags := r.writeItem(ctx, data.ServiceAccountID.ValueString(), data.ToBase()); diags != nil {
 		resp.Diagnostics = diags
 	}
 }
@@ -3,7 +3,9 @@ package grafana
 import (
 	"context"
 	"strconv"
+	"time"
 
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
@@ -16,14 +18,18 @@ func resourceServiceAccountToken() *common.Resource {
 		Description: `
 **Note:** This resource is available only with Grafana 9.1+.
 
+The token can be rotated periodically with the ` + "`rotation`" + ` block. During the overlap period of a rotation, both the new key (` + "`key`" + `) and the previous key (` + "`previous_key`" + `) are valid, so that consumers can switch to the new key without downtime.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api)`,
 
 		CreateContext: serviceAccountTokenCreate,
 		ReadContext:   serviceAccountTokenRead,
+		UpdateContext: serviceAccountTokenUpdate,
 		DeleteContext: serviceAccountTokenDelete,
+		CustomizeDiff: common.CustomizeTokenRotationDiff,
 
-		Schema: map[string]*schema.Schema{
+		Schema: common.WithTokenRotation(map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -46,7 +52,7 @@ func resourceServiceAccountToken() *common.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
-				Description: "The key of the service account token.",
+				Description: "The key of the service account token. When the token is rotated, this is the key of the current token.",
 			},
 			"expiration": {
 				Type:        schema.TypeString,
@@ -58,7 +64,7 @@ func resourceServiceAccountToken() *common.Resource {
 				Computed:    true,
 				Description: "The status of the service account token.",
 			},
-		},
+		}),
 	}
 
 	return common.NewLegacySDKResource(
@@ -66,7 +72,7 @@ func resourceServiceAccountToken() *common.Resource {
 		"grafana_service_account_token",
 		nil,
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{MinVersion: "9.1.0"})
 }
 
 func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -77,25 +83,18 @@ func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	name := d.Get("name").(string)
-	ttl := d.Get("seconds_to_live").(int)
-
-	request := models.AddServiceAccountTokenCommand{
-		Name:          name,
-		SecondsToLive: int64(ttl),
-	}
-	params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&request)
-	response, err := c.ServiceAccounts.CreateToken(params)
+	createToken := serviceAccountTokenCreateFunc(c, serviceAccountID, d.Get("seconds_to_live").(int))
+	id, key, err := createToken(d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	token := response.Payload
 
-	d.SetId(strconv.FormatInt(token.ID, 10))
-	err = d.Set("key", token.Key)
+	d.SetId(id)
+	err = d.Set("key", key)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	// Fill the true resource's state by performing a read
 	return serviceAccountTokenRead(ctx, d, m)
@@ -118,29 +117,45 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	previousID := d.Get("previous_token_id").(string)
+	previousFound := false
+	var current *models.TokenDTO
 	for _, key := range response.Payload {
 		if id == key.ID {
-			d.SetId(strconv.FormatInt(key.ID, 10))
-			err = d.Set("name", key.Name)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			if !key.Expiration.IsZero() {
-				err = d.Set("expiration", key.Expiration.String())
-				if err != nil {
-					return diag.FromErr(err)
-				}
-			}
-			err = d.Set("has_expired", key.HasExpired)
+			current = key
+		}
+		if previousID == strconv.FormatInt(key.ID, 10) {
+			previousFound = true
+		}
+	}
+	if current == nil {
+		return common.WarnMissing("service account token", d)
+	}
 
+	d.SetId(strconv.FormatInt(current.ID, 10))
+	err = d.Set("name", common.TokenName(d.Get("name").(string), current.Name))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !current.Expiration.IsZero() {
+		err = d.Set("expiration", current.Expiration.String())
+		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
+	if !current.Created.IsZero() {
+		d.Set("created_at", time.Time(current.Created).UTC().Format(time.RFC3339))
+	}
+	if !previousFound {
+		d.Set("previous_key", "")
+		d.Set("previous_token_id", "")
+	}
+	err = d.Set("has_expired", current.HasExpired)
 
-	return common.WarnMissing("service account token", d)
+	return diag.FromErr(err)
 }
 
-func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func serviceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
 	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
@@ -148,12 +163,52 @@ func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	id, err := strconv.ParseInt(d.Id(), 10, 32)
+	createToken := serviceAccountTokenCreateFunc(c, serviceAccountID, d.Get("seconds_to_live").(int))
+	if err := common.RotateToken(d, createToken, serviceAccountTokenDeleteFunc(c, serviceAccountID)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return serviceAccountTokenRead(ctx, d, m)
+}
+
+func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
+	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, err = c.ServiceAccounts.DeleteToken(id, serviceAccountID)
+	deleteToken := serviceAccountTokenDeleteFunc(c, serviceAccountID)
+	if err := common.DeletePreviousToken(d, deleteToken); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return diag.FromErr(err)
+	return diag.FromErr(deleteToken(d.Id()))
+}
+
+func serviceAccountTokenCreateFunc(c *goapi.GrafanaHTTPAPI, serviceAccountID int64, ttl int) common.TokenCreateFunc {
+	return func(name string) (string, string, error) {
+		request := models.AddServiceAccountTokenCommand{
+			Name:          name,
+			SecondsToLive: int64(ttl),
+		}
+		params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&request)
+		response, err := c.ServiceAccounts.CreateToken(params)
+		if err != nil {
+			return "", "", err
+		}
+		return strconv.FormatInt(response.Payload.ID, 10), response.Payload.Key, nil
+	}
+}
+
+func serviceAccountTokenDeleteFunc(c *goapi.GrafanaHTTPAPI, serviceAccountID int64) common.TokenDeleteFunc {
+	return func(idStr string) error {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return err
+		}
+		_, err = c.ServiceAccounts.DeleteToken(id, serviceAccountID)
+		return err
+	}
 }
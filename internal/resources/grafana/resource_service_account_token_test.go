@@ -2,10 +2,14 @@ package grafana_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -110,6 +114,114 @@ func TestAccServiceAccountToken_inOrg(t *testing.T) {
 	})
 }
 
+func TestFakeServiceAccountToken_rotation(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := func(overlap string) string {
+		return fmt.Sprintf(`
+resource "grafana_service_account_token" "test" {
+	name               = "rotated"
+	service_account_id = "1"
+
+	rotation {
+		rotate_after = "720h"
+		overlap      = %q
+	}
+}`, overlap)
+	}
+
+	var firstKey, secondKey string
+	saveKey := func(key *string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			*key = s.RootModule().Resources["grafana_service_account_token.test"].Primary.Attributes["key"]
+			return nil
+		}
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		CheckDestroy: checkFakeServiceAccountTokens(client),
+		Steps: []resource.TestStep{
+			{
+				Config: config("24h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "created_at"),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_key", ""),
+					checkFakeServiceAccountTokens(client, "rotated"),
+					saveKey(&firstKey),
+				),
+			},
+			// The token is rotated when it is older than rotate_after, and the previous token is kept during the overlap
+			{
+				PreConfig: func() { grafana.AgeServiceAccountTokens(721 * time.Hour) },
+				Config:    config("24h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "name", "rotated"),
+					resource.TestCheckResourceAttrPtr("grafana_service_account_token.test", "previous_key", &firstKey),
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "previous_token_id"),
+					checkFakeServiceAccountTokens(client, "rotated", "rotated-*"),
+					saveKey(&secondKey),
+					func(s *terraform.State) error {
+						if secondKey == firstKey {
+							return fmt.Errorf("expected the key to change after the rotation")
+						}
+						return nil
+					},
+				),
+			},
+			// The previous token is deleted after the overlap
+			{
+				PreConfig: func() { grafana.AgeServiceAccountTokens(25 * time.Hour) },
+				Config:    config("24h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("grafana_service_account_token.test", "key", &secondKey),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_key", ""),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_token_id", ""),
+					checkFakeServiceAccountTokens(client, "rotated-*"),
+				),
+			},
+			// Without overlap, the previous token is deleted as soon as the new one is created
+			{
+				PreConfig: func() { grafana.AgeServiceAccountTokens(721 * time.Hour) },
+				Config:    config("0s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_key", ""),
+					checkFakeServiceAccountTokens(client, "rotated-*"),
+					func(s *terraform.State) error {
+						if key := s.RootModule().Resources["grafana_service_account_token.test"].Primary.Attributes["key"]; key == secondKey {
+							return fmt.Errorf("expected the key to change after the rotation")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// checkFakeServiceAccountTokens checks the names of the tokens of the service account 1 of a fake Grafana server.
+// A name ending with `-*` matches the names of rotated tokens.
+func checkFakeServiceAccountTokens(client *goapi.GrafanaHTTPAPI, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resp, err := client.ServiceAccounts.ListTokens(1)
+		if err != nil {
+			return err
+		}
+		if len(resp.Payload) != len(expected) {
+			return fmt.Errorf("expected %d tokens, got %d", len(expected), len(resp.Payload))
+		}
+		for i, token := range resp.Payload {
+			if prefix, ok := strings.CutSuffix(expected[i], "*"); ok && strings.HasPrefix(token.Name, prefix) {
+				continue
+			}
+			if token.Name != expected[i] {
+				return fmt.Errorf("expected token %q, got %q", expected[i], token.Name)
+			}
+		}
+		return nil
+	}
+}
+
 func checkServiceAccountTokens(sa *models.ServiceAccountDTO, expectNames []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := grafanaTestClient().WithOrgID(sa.OrgID)
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/teams"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -45,8 +49,13 @@ func resourceTeam() *common.Resource {
 		UpdateContext: UpdateTeam,
 		DeleteContext: DeleteTeam,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithLookup("team", resolveTeamName),
 		},
+		CustomizeDiff: customdiff.All(
+			checkUnmanagedMembers("manage_members", "members"),
+			checkUnmanagedMembers("manage_preferences", "preferences"),
+			warnRemovedTeamMembers,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"org_id": orgIDAttribute(),
@@ -74,14 +83,27 @@ func resourceTeam() *common.Resource {
 				Description: `
 A set of email addresses corresponding to users who should be given membership
 to the team. Note: users specified here must already exist in Grafana.
+Members that aren't listed are removed from the team, unless ` + "`manage_members`" + ` is set to false.
 `,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					if (new == "[]" && old == "") || (new == "" && old == "[]") {
 						return true
 					}
-					return false
+					return !manageMembers(d.GetRawConfig(), "manage_members")
 				},
 			},
+			"manage_members": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == new || (old == "" && new == "true")
+				},
+				Description: `
+Whether the members of the team are managed with the ` + "`members`" + ` attribute.
+Set it to false to manage them with the ` + "`grafana_team_member`" + ` resource, or outside of Terraform. ` + "`members`" + ` can't be set then.
+`,
+			},
 			"ignore_externally_synced_members": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -94,10 +116,26 @@ Ignores team members that have been added to team by [Team Sync](https://grafana
 Team Sync can be provisioned using [grafana_team_external_group resource](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/team_external_group).
 `,
 			},
-			"preferences": {
-				Type:     schema.TypeList,
+			"manage_preferences": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				MaxItems: 1,
+				Default:  true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == new || (old == "" && new == "true")
+				},
+				Description: `
+Whether the preferences of the team are managed with the ` + "`preferences`" + ` block. If the block isn't set, the preferences are reset.
+Set it to false to manage them with the ` + "`grafana_team_preferences`" + ` resource, or outside of Terraform. ` + "`preferences`" + ` can't be set then.
+`,
+			},
+			"preferences": {
+				Type:       schema.TypeList,
+				Optional:   true,
+				MaxItems:   1,
+				Deprecated: "Use the `grafana_team_preferences` resource instead, and set `manage_preferences` to false.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return !manageMembers(d.GetRawConfig(), "manage_preferences")
+				},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"theme": {
@@ -158,7 +196,8 @@ Team Sync can be provisioned using [grafana_team_external_group resource](https:
 		"grafana_team",
 		orgResourceIDInt("id"),
 		schema,
-	).WithLister(listerFunctionOrgResource(listTeams))
+	).WithLister(listerFunctionOrgResource(listTeams)).
+		WithImportLookups("team:{{ name }}")
 }
 
 func listTeams(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
@@ -199,8 +238,10 @@ func CreateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 	d.SetId(MakeOrgResourceID(orgID, teamID))
 	d.Set("team_id", teamID)
-	if err = UpdateMembers(client, d); err != nil {
-		return diag.FromErr(err)
+	if manageMembers(d.GetRawConfig(), "manage_members") {
+		if err = UpdateMembers(client, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := updateTeamPreferences(client, teamID, d); err != nil {
@@ -290,8 +331,10 @@ func UpdateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			return diag.FromErr(err)
 		}
 	}
-	if err := UpdateMembers(client, d); err != nil {
-		return diag.FromErr(err)
+	if manageMembers(d.GetRawConfig(), "manage_members") {
+		if err := UpdateMembers(client, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := updateTeamPreferences(client, teamID, d); err != nil {
@@ -315,6 +358,9 @@ func DeleteTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 }
 
 func updateTeamPreferences(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData) diag.Diagnostics {
+	if !manageMembers(d.GetRawConfig(), "manage_preferences") {
+		return nil
+	}
 	if d.IsNewResource() || d.HasChanges("preferences.0.theme", "preferences.0.home_dashboard_uid", "preferences.0.timezone", "preferences.0.week_start") {
 		body := models.UpdatePrefsCmd{
 			Theme:            d.Get("preferences.0.theme").(string),
@@ -463,6 +509,60 @@ func applyMemberChanges(client *goapi.GrafanaHTTPAPI, teamID int64, changes []Me
 	return nil
 }
 
+// warnRemovedTeamMembers adds a warning to the plan when members of the team will be removed.
+// They may have been added with grafana_team_member resources, which conflict with the `members` attribute.
+func warnRemovedTeamMembers(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !manageMembers(d.GetRawConfig(), "manage_members") || !d.HasChange("members") || !d.NewValueKnown("members") {
+		return nil
+	}
+	oldMembers, newMembers := d.GetChange("members")
+	removed := setDifference(oldMembers.(*schema.Set), newMembers.(*schema.Set))
+	if len(removed) == 0 {
+		return nil
+	}
+	common.AddPlanWarning(ctx,
+		fmt.Sprintf("Members will be removed from team %s", d.Id()),
+		fmt.Sprintf("The `members` attribute manages the entire set of members of the team. These members aren't listed and will be removed: %s.\n"+
+			"If they are managed with grafana_team_member resources, or outside of Terraform, set `manage_members` to false.",
+			strings.Join(removed, ", ")),
+		cty.GetAttrPath("members"),
+	)
+	return nil
+}
+
+// checkUnmanagedMembers returns a CustomizeDiff function that fails when the given member attributes are set
+// while the members aren't managed by the resource, according to the given boolean attribute.
+func checkUnmanagedMembers(manageAttribute string, attributes ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if manageMembers(d.GetRawConfig(), manageAttribute) {
+			return nil
+		}
+		for _, attribute := range attributes {
+			if !d.GetRawConfig().GetAttr(attribute).IsNull() {
+				return fmt.Errorf("`%s` can't be set when `%s` is false", attribute, manageAttribute)
+			}
+		}
+		return nil
+	}
+}
+
+// manageMembers returns whether the members (or the preferences) are managed by the resource, according to the given boolean attribute of the configuration.
+// The attribute defaults to true. It's read from the configuration, since it isn't in the state of resources created before it was added.
+func manageMembers(config cty.Value, attribute string) bool {
+	value := config.GetAttr(attribute)
+	return value.IsNull() || !value.IsKnown() || value.True()
+}
+
+// setDifference returns the sorted elements of a set of strings which aren't in another set.
+func setDifference(a, b *schema.Set) []string {
+	var elems []string
+	for _, elem := range a.Difference(b).List() {
+		elems = append(elems, elem.(string))
+	}
+	sort.Strings(elems)
+	return elems
+}
+
 func getTeamByID(client *goapi.GrafanaHTTPAPI, teamID int64) (*models.TeamDTO, error) {
 	resp, err := client.Teams.GetTeamByID(strconv.FormatInt(teamID, 10))
 	if err != nil {
@@ -54,7 +54,7 @@ func resourceTeamExternalGroup() *common.Resource {
 		"grafana_team_external_group",
 		orgResourceIDInt("teamID"),
 		schema,
-	)
+	).WithRequirements(common.GrafanaRequirements{Enterprise: true})
 }
 
 func CreateTeamExternalGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,266 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	resourceTeamMemberName = "grafana_team_member"
+	resourceTeamMemberID   = common.NewResourceID(common.OptionalIntIDField("orgID"), common.IntIDField("teamID"), common.IntIDField("userID"))
+
+	// Check interface
+	_ resource.ResourceWithImportState = (*resourceTeamMember)(nil)
+)
+
+func makeResourceTeamMember() *common.Resource {
+	return common.NewResource(
+		common.CategoryGrafanaOSS,
+		resourceTeamMemberName,
+		resourceTeamMemberID,
+		&resourceTeamMember{},
+	).WithLister(listerFunctionOrgResource(listTeamMembers))
+}
+
+type resourceTeamMemberModel struct {
+	ID     types.String `tfsdk:"id"`
+	OrgID  types.String `tfsdk:"org_id"`
+	TeamID types.String `tfsdk:"team_id"`
+	UserID types.String `tfsdk:"user_id"`
+
+	GrafanaConnection []connectionModel `tfsdk:"grafana_connection"`
+}
+
+type resourceTeamMember struct {
+	basePluginFrameworkResource
+}
+
+func (r *resourceTeamMember) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = resourceTeamMemberName
+}
+
+func (r *resourceTeamMember) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a single member of a team. Conflicts with the ` + "`members`" + ` attribute of the "grafana_team" resource which manages the entire set of members of a team.
+Use this resource to add users to a team that is managed elsewhere, for example when several configurations add their own users to a shared team.
+
+If the user is already a member of the team when the resource is created, the membership is adopted and a warning is reported.
+
+To migrate from the ` + "`members`" + ` attribute, add a ` + "`grafana_team_member`" + ` resource and an ` + "`import`" + ` block for each member, then remove the ` + "`members`" + ` attribute from the ` + "`grafana_team`" + ` resource and set its ` + "`manage_members`" + ` attribute to false.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/team-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/team/#add-team-member)
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org_id": pluginFrameworkOrgIDAttribute(),
+			"team_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the team.",
+				PlanModifiers: []planmodifier.String{
+					&orgScopedAttributePlanModifier{},
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user to add to the team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"grafana_connection": frameworkConnectionBlock(false),
+		},
+	}
+}
+
+func listTeamMembers(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
+	teamIDs, err := listTeams(ctx, client, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, teamID := range teamIDs {
+		_, teamIDStr := SplitOrgResourceID(teamID)
+		resp, err := client.Teams.GetTeamMembers(teamIDStr)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range resp.Payload {
+			// The admin and the members synced from an auth provider are skipped, as in the grafana_team resource
+			if member.Email == "admin@localhost" || len(member.Labels) > 0 {
+				continue
+			}
+			ids = append(ids, resourceTeamMemberID.Make(orgID, member.TeamID, member.UserID))
+		}
+	}
+	return ids, nil
+}
+
+func (r *resourceTeamMember) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	connection, id := splitConnectionImportID(req.ID)
+	data, diags := r.read(ctx, connection, id)
+	if diags != nil {
+		resp.Diagnostics = diags
+		return
+	}
+	if data == nil {
+		resp.Diagnostics.AddError("Resource not found", "Resource not found")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *resourceTeamMember) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data resourceTeamMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, orgID, err := r.clientFromNewOrgResource(ctx, data.GrafanaConnection, data.OrgID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	_, teamIDStr := SplitOrgResourceID(data.TeamID.ValueString())
+	teamID, err := strconv.ParseInt(teamIDStr, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("team_id"), "Failed to parse team ID", err.Error())
+		return
+	}
+	userID, err := strconv.ParseInt(data.UserID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_id"), "Failed to parse user ID", err.Error())
+		return
+	}
+
+	// A member that was added by another configuration (or by the `members` attribute of a team) is adopted
+	member, err := findTeamMember(client, teamID, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get team members", err.Error())
+		return
+	}
+	if member != nil {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("User %d is already a member of team %d", userID, teamID),
+			"The existing membership is now managed by this resource. "+
+				"If the user is also listed in the `members` attribute of the grafana_team resource, remove it from the list: "+
+				"the `members` attribute manages the entire set of members of the team and removes the members that aren't listed.",
+		)
+	} else if _, err := client.Teams.AddTeamMember(teamIDStr, &models.AddTeamMemberCommand{UserID: userID}); err != nil {
+		resp.Diagnostics.AddError("Failed to add team member", err.Error())
+		return
+	}
+
+	// Save data into Terraform state
+	data.ID = types.StringValue(resourceTeamMemberID.Make(orgID, teamID, userID))
+	data.OrgID = types.StringValue(strconv.FormatInt(orgID, 10))
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *resourceTeamMember) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read Terraform state data into the model
+	var data resourceTeamMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// Read from API
+	readData, diags := r.read(ctx, data.GrafanaConnection, data.ID.ValueString())
+	if diags != nil {
+		resp.Diagnostics = diags
+		return
+	}
+	if readData == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, readData)...)
+}
+
+func (r *resourceTeamMember) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Update shouldn't happen as all attributes require replacement
+	resp.Diagnostics.AddError("Update not supported", "Update not supported")
+}
+
+func (r *resourceTeamMember) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read Terraform prior state data into the model
+	var data resourceTeamMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	client, _, idFields, err := r.clientFromExistingOrgResource(ctx, data.GrafanaConnection, resourceTeamMemberID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get client", err.Error())
+		return
+	}
+	teamID, userID := idFields[0].(int64), idFields[1].(int64)
+
+	_, err = client.Teams.RemoveTeamMember(userID, strconv.FormatInt(teamID, 10))
+	if err != nil && !common.IsNotFoundError(err) {
+		resp.Diagnostics.AddError("Failed to remove team member", err.Error())
+	}
+}
+
+func (r *resourceTeamMember) read(ctx context.Context, connection []connectionModel, id string) (*resourceTeamMemberModel, diag.Diagnostics) {
+	client, orgID, idFields, err := r.clientFromExistingOrgResource(ctx, connection, resourceTeamMemberID, id)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get client", err.Error())}
+	}
+	teamID, userID := idFields[0].(int64), idFields[1].(int64)
+
+	member, err := findTeamMember(client, teamID, userID)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Failed to get team members", err.Error())}
+	}
+	if member == nil {
+		return nil, nil
+	}
+
+	return &resourceTeamMemberModel{
+		ID:                types.StringValue(resourceTeamMemberID.Make(orgID, teamID, userID)),
+		OrgID:             types.StringValue(strconv.FormatInt(orgID, 10)),
+		TeamID:            types.StringValue(strconv.FormatInt(teamID, 10)),
+		UserID:            types.StringValue(strconv.FormatInt(userID, 10)),
+		GrafanaConnection: connection,
+	}, nil
+}
+
+// findTeamMember returns the member of a team with the given user ID, or nil if the user isn't a member of the team.
+func findTeamMember(client *goapi.GrafanaHTTPAPI, teamID, userID int64) (*models.TeamMemberDTO, error) {
+	resp, err := client.Teams.GetTeamMembers(strconv.FormatInt(teamID, 10))
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range resp.Payload {
+		if member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,116 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeTeamMember(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	userIDs := createFakeUsers(t, client, "member-1", "member-2", "member-3")
+
+	config := func(members ...int) string {
+		config := `
+resource "grafana_team" "test" {
+	name           = "shared-team"
+	manage_members = false
+}
+`
+		for _, member := range members {
+			config += fmt.Sprintf(`
+resource "grafana_team_member" "member_%[1]d" {
+	team_id = grafana_team.test.id
+	user_id = "%[2]d"
+}
+`, member, userIDs[member-1])
+		}
+		return config
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config(1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("grafana_team_member.member_1", "id", regexp.MustCompile(`^1:\d+:\d+$`)),
+					resource.TestCheckResourceAttr("grafana_team_member.member_1", "org_id", "1"),
+					checkTeamMembers(client, "grafana_team.test", "member-1@example.com", "member-2@example.com"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_team_member.member_1"),
+				),
+			},
+			{
+				ResourceName:      "grafana_team_member.member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Members added outside of this configuration are kept: the team doesn't manage its members
+			{
+				PreConfig: func() {
+					resp, err := client.Teams.SearchTeams(nil)
+					require.NoError(t, err)
+					_, err = client.Teams.AddTeamMember(strconv.FormatInt(resp.Payload.Teams[0].ID, 10), &models.AddTeamMemberCommand{UserID: userIDs[2]})
+					require.NoError(t, err)
+				},
+				Config: config(1),
+				Check:  checkTeamMembers(client, "grafana_team.test", "member-1@example.com", "member-3@example.com"),
+			},
+			// An existing member is adopted
+			{
+				Config: config(1, 3),
+				Check:  checkTeamMembers(client, "grafana_team.test", "member-1@example.com", "member-3@example.com"),
+			},
+			{
+				Config: config(),
+				Check:  checkTeamMembers(client, "grafana_team.test"),
+			},
+		},
+	})
+}
+
+// createFakeUsers creates users in a fake Grafana server, and returns their IDs. The email of the users is `<login>@example.com`.
+func createFakeUsers(t *testing.T, client *goapi.GrafanaHTTPAPI, logins ...string) []int64 {
+	t.Helper()
+
+	var ids []int64
+	for _, login := range logins {
+		resp, err := client.AdminUsers.AdminCreateUser(&models.AdminCreateUserForm{Login: login, Email: login + "@example.com", Name: login, Password: "password"})
+		require.NoError(t, err)
+		ids = append(ids, resp.Payload.ID)
+	}
+	return ids
+}
+
+// checkTeamMembers checks the emails of the members of a team in Grafana.
+func checkTeamMembers(client *goapi.GrafanaHTTPAPI, teamResource string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[teamResource]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", teamResource)
+		}
+		resp, err := client.Teams.GetTeamMembers(rs.Primary.Attributes["team_id"])
+		if err != nil {
+			return err
+		}
+		var emails []string
+		for _, member := range resp.Payload {
+			emails = append(emails, member.Email)
+		}
+		slices.Sort(emails)
+		if !slices.Equal(emails, expected) {
+			return fmt.Errorf("expected team members %v, got %v", expected, emails)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTeamPreferences() *common.Resource {
+	schema := &schema.Resource{
+		Description: `
+Manages the preferences of a team. Replaces the deprecated ` + "`preferences`" + ` block of the ` + "`grafana_team`" + ` resource. Set ` + "`manage_preferences`" + ` to false on the ` + "`grafana_team`" + ` resource of the team, so that it doesn't reset the preferences.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/team-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/team/#update-team-preferences)
+`,
+
+		CreateContext: CreateTeamPreferences,
+		ReadContext:   ReadTeamPreferences,
+		UpdateContext: UpdateTeamPreferences,
+		DeleteContext: DeleteTeamPreferences,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: preferencesSchema("team", map[string]*schema.Schema{
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the team.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+		}),
+	}
+
+	return common.NewLegacySDKResource(
+		common.CategoryGrafanaOSS,
+		"grafana_team_preferences",
+		orgResourceIDInt("teamID"),
+		schema,
+	).WithLister(listerFunctionOrgResource(listTeams))
+}
+
+func CreateTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, teamIDStr := SplitOrgResourceID(d.Get("team_id").(string))
+	teamID, _ := strconv.ParseInt(teamIDStr, 10, 64)
+	d.SetId(MakeOrgResourceID(orgID, teamID))
+
+	return UpdateTeamPreferences(ctx, d, meta)
+}
+
+func ReadTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+	teamID, _ := strconv.ParseInt(idStr, 10, 64)
+
+	resp, err := client.Teams.GetTeamPreferences(idStr)
+	if err, shouldReturn := common.CheckReadError("team preferences", d, err); shouldReturn {
+		return err
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, teamID))
+	d.Set("team_id", d.Id())
+	setPreferences(d, resp.Payload)
+
+	return nil
+}
+
+func UpdateTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	if _, err := client.Teams.UpdateTeamPreferences(idStr, preferencesFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadTeamPreferences(ctx, d, meta)
+}
+
+func DeleteTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	_, err := client.Teams.UpdateTeamPreferences(idStr, &models.UpdatePrefsCmd{})
+	diag, _ := common.CheckReadError("team preferences", d, err)
+	return diag
+}
+
+// preferencesSchema returns the schema of a preferences resource, with the preference attributes of the given owner (team, user...) and the given extra attributes.
+func preferencesSchema(owner string, attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	preferences := map[string]*schema.Schema{
+		"theme": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The default theme for this " + owner + ". Available values are `light`, `dark`, `system`, or an empty string for the default theme.",
+			ValidateFunc: validation.StringInSlice([]string{"light", "dark", "system", ""}, false),
+		},
+		"home_dashboard_uid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The UID of the home dashboard for this " + owner + ".",
+		},
+		"timezone": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The default timezone for this " + owner + ". Available values are `utc`, `browser`, or an empty string for the default.",
+			ValidateFunc: validation.StringInSlice([]string{"utc", "browser", ""}, false),
+		},
+		"week_start": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The default week start day for this " + owner + ". Available values are `sunday`, `monday`, `saturday`, or an empty string for the default.",
+			ValidateFunc: validation.StringInSlice([]string{"sunday", "monday", "saturday", ""}, false),
+		},
+		"language": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The default language for this " + owner + ", ex: `en-US`, `fr-FR`. An empty string uses the default language.",
+		},
+		"query_history_home_tab": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The tab displayed by default in the query history of Explore, for this " + owner + ". Available values are `query`, `starred`, or an empty string for the default.",
+			ValidateFunc: validation.StringInSlice([]string{"query", "starred", ""}, false),
+		},
+	}
+	for name, attribute := range attributes {
+		preferences[name] = attribute
+	}
+	return preferences
+}
+
+func preferencesFromResourceData(d *schema.ResourceData) *models.UpdatePrefsCmd {
+	prefs := &models.UpdatePrefsCmd{
+		Theme:            d.Get("theme").(string),
+		HomeDashboardUID: d.Get("home_dashboard_uid").(string),
+		Timezone:         d.Get("timezone").(string),
+		WeekStart:        d.Get("week_start").(string),
+		Language:         d.Get("language").(string),
+	}
+	if homeTab := d.Get("query_history_home_tab").(string); homeTab != "" {
+		prefs.QueryHistory = &models.QueryHistoryPreference{HomeTab: homeTab}
+	}
+	return prefs
+}
+
+func setPreferences(d *schema.ResourceData, prefs *models.Preferences) {
+	d.Set("theme", prefs.Theme)
+	d.Set("home_dashboard_uid", prefs.HomeDashboardUID)
+	d.Set("timezone", prefs.Timezone)
+	d.Set("week_start", prefs.WeekStart)
+	d.Set("language", prefs.Language)
+	homeTab := ""
+	if prefs.QueryHistory != nil {
+		homeTab = prefs.QueryHistory.HomeTab
+	}
+	d.Set("query_history_home_tab", homeTab)
+}
@@ -0,0 +1,70 @@
+package grafana_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFakeTeamPreferences(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	config := func(theme, homeTab string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard" "home" {
+	config_json = jsonencode({
+		uid   = "team-home"
+		title = "Team Home"
+	})
+}
+
+# Otherwise, the team resets the preferences
+resource "grafana_team" "test" {
+	name               = "preferences-team"
+	manage_preferences = false
+}
+
+resource "grafana_team_preferences" "test" {
+	team_id                = grafana_team.test.id
+	theme                  = %q
+	timezone               = "utc"
+	week_start             = "monday"
+	language               = "fr-FR"
+	query_history_home_tab = %q
+	home_dashboard_uid     = grafana_dashboard.home.uid
+}`, theme, homeTab)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("dark", "starred"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_team_preferences.test", "id", "grafana_team.test", "id"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "theme", "dark"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "timezone", "utc"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "week_start", "monday"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "language", "fr-FR"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "query_history_home_tab", "starred"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "home_dashboard_uid", "team-home"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_team_preferences.test"),
+				),
+			},
+			{
+				Config: config("light", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "theme", "light"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.test", "query_history_home_tab", ""),
+				),
+			},
+			{
+				ResourceName:      "grafana_team_preferences.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -10,10 +10,184 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
+func TestFakeTeam_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamDefinition("fake-team", nil, true, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("grafana_team.test", "id", defaultOrgIDRegexp),
+					resource.TestCheckResourceAttr("grafana_team.test", "name", "fake-team"),
+					resource.TestCheckResourceAttr("grafana_team.test", "email", "fake-team@example.com"),
+					resource.TestCheckResourceAttr("grafana_team.test", "preferences.0.theme", "dark"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_team.test"),
+				),
+			},
+			{
+				Config: testAccTeamDefinition("fake-team-updated", nil, true, nil),
+				Check:  resource.TestCheckResourceAttr("grafana_team.test", "name", "fake-team-updated"),
+			},
+			{
+				ResourceName:            "grafana_team.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
+			},
+		},
+	})
+}
+
+func TestFakeTeam_members(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	userIDs := createFakeUsers(t, client, "member-1", "member-2")
+
+	config := func(members string) string {
+		return fmt.Sprintf(`
+resource "grafana_team" "test" {
+	name = "fake-team"
+	%s
+}`, members)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config(`members = ["member-1@example.com", "member-2@example.com"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_team.test", "members.#", "2"),
+					checkTeamMembers(client, "grafana_team.test", "member-1@example.com", "member-2@example.com"),
+				),
+			},
+			// An empty list removes all the members
+			{
+				Config: config(`members = []`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_team.test", "members.#", "0"),
+					checkTeamMembers(client, "grafana_team.test"),
+				),
+			},
+			// Without manage_members, the members aren't managed
+			{
+				PreConfig: func() {
+					resp, err := client.Teams.SearchTeams(nil)
+					require.NoError(t, err)
+					_, err = client.Teams.AddTeamMember(strconv.FormatInt(resp.Payload.Teams[0].ID, 10), &models.AddTeamMemberCommand{UserID: userIDs[0]})
+					require.NoError(t, err)
+				},
+				Config: config("manage_members = false"),
+				Check:  checkTeamMembers(client, "grafana_team.test", "member-1@example.com"),
+			},
+			{
+				Config:      config("manage_members = false\n\tmembers = [\"member-1@example.com\"]"),
+				ExpectError: regexp.MustCompile("`members` can't be set when `manage_members` is false"),
+			},
+			// Without the attribute, all the members are removed
+			{
+				Config: config(""),
+				Check:  checkTeamMembers(client, "grafana_team.test"),
+			},
+		},
+	})
+}
+
+func TestFakeTeam_removedMembersWarning(t *testing.T) {
+	server := fake.ProviderServer(t, fake.NewGrafana(t))
+	members := func(emails ...string) tftypes.Value {
+		var values []tftypes.Value
+		for _, email := range emails {
+			values = append(values, tftypes.NewValue(tftypes.String, email))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+	state := map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1:1"),
+		"org_id":  tftypes.NewValue(tftypes.String, "1"),
+		"team_id": tftypes.NewValue(tftypes.Number, 1),
+		"name":    tftypes.NewValue(tftypes.String, "fake-team"),
+		"members": members("member-1@example.com", "member-2@example.com", "member-3@example.com"),
+	}
+	config := func(members tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "fake-team"),
+			"members": members,
+		}
+	}
+	unset := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil)
+
+	diags := testutils.PlanResourceChange(t, server, "grafana_team", state, config(members("member-1@example.com")))
+	require.Len(t, diags, 1)
+	require.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+	require.Equal(t, "Members will be removed from team 1:1", diags[0].Summary)
+	require.Contains(t, diags[0].Detail, "These members aren't listed and will be removed: member-2@example.com, member-3@example.com.")
+
+	diags = testutils.PlanResourceChange(t, server, "grafana_team", state, config(members()))
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Detail, "will be removed: member-1@example.com, member-2@example.com, member-3@example.com.")
+
+	diags = testutils.PlanResourceChange(t, server, "grafana_team", state, config(unset))
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Detail, "will be removed: member-1@example.com, member-2@example.com, member-3@example.com.")
+
+	// Members aren't removed when they aren't managed
+	unmanaged := config(unset)
+	unmanaged["manage_members"] = tftypes.NewValue(tftypes.Bool, false)
+	diags = testutils.PlanResourceChange(t, server, "grafana_team", state, unmanaged)
+	require.Empty(t, diags)
+}
+
+func TestFakeTeam_removedPreferences(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+	server := fake.ProviderServer(t, grafana)
+
+	resp, err := client.Teams.CreateTeam(&models.CreateTeamCommand{Name: "fake-team"})
+	require.NoError(t, err)
+	teamID := strconv.FormatInt(resp.Payload.TeamID, 10)
+	setPreferences := func() {
+		_, err := client.Teams.UpdateTeamPreferences(teamID, &models.UpdatePrefsCmd{Theme: "dark", Timezone: "utc"})
+		require.NoError(t, err)
+	}
+	checkTheme := func(expected string) {
+		prefs, err := client.Teams.GetTeamPreferences(teamID)
+		require.NoError(t, err)
+		require.Equal(t, expected, prefs.Payload.Theme)
+	}
+	preferencesType := tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"theme":              tftypes.String,
+		"home_dashboard_uid": tftypes.String,
+		"timezone":           tftypes.String,
+		"week_start":         tftypes.String,
+	}}}
+	config := map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, "fake-team"),
+		"preferences": tftypes.NewValue(preferencesType, nil),
+	}
+
+	// Removing the block resets the preferences
+	setPreferences()
+	state, identity := testutils.ReadResource(t, server, "grafana_team", "1:"+teamID)
+	require.Empty(t, testutils.ApplyResourceChange(t, server, "grafana_team", state, identity, config))
+	checkTheme("")
+
+	// The preferences aren't reset when they aren't managed, ex: by grafana_team_preferences
+	setPreferences()
+	state, identity = testutils.ReadResource(t, server, "grafana_team", "1:"+teamID)
+	config["manage_preferences"] = tftypes.NewValue(tftypes.Bool, false)
+	require.Empty(t, testutils.ApplyResourceChange(t, server, "grafana_team", state, identity, config))
+	checkTheme("dark")
+}
+
 func TestAccTeam_basic(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -52,6 +226,14 @@ func TestAccTeam_basic(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
 			},
+			// Test import using the team name
+			{
+				ResourceName:            "grafana_team.test",
+				ImportState:             true,
+				ImportStateId:           "team:" + teamNameUpdated,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_externally_synced_members"},
+			},
 		},
 	})
 }
@@ -32,7 +32,7 @@ You must use basic auth.
 		UpdateContext: UpdateUser,
 		DeleteContext: DeleteUser,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalStateWithLookup("user", resolveUserLogin),
 		},
 		Schema: map[string]*schema.Schema{
 			"user_id": {
@@ -77,7 +77,8 @@ You must use basic auth.
 		"grafana_user",
 		resourceUserID,
 		schema,
-	).WithLister(listerFunction(listUsers))
+	).WithLister(listerFunction(listUsers)).
+		WithImportLookups("user:{{ login }}")
 }
 
 func listUsers(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
@@ -0,0 +1,133 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserPreferences() *common.Resource {
+	schema := &schema.Resource{
+		Description: `
+Manages the preferences of the user that the provider is authenticated as, in an organization.
+Grafana only allows users to manage their own preferences, so the preferences of other users can't be managed.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/user-management/user-preferences/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/preferences/#update-current-user-prefs)
+`,
+
+		CreateContext: CreateUserPreferences,
+		ReadContext:   ReadUserPreferences,
+		UpdateContext: UpdateUserPreferences,
+		DeleteContext: DeleteUserPreferences,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: preferencesSchema("user", map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the user that the provider is authenticated as.",
+			},
+		}),
+	}
+
+	return common.NewLegacySDKResource(
+		common.CategoryGrafanaOSS,
+		"grafana_user_preferences",
+		orgResourceIDInt("userID"),
+		schema,
+	).WithLister(listerFunctionOrgResource(listUserPreferences))
+}
+
+func listUserPreferences(ctx context.Context, client *goapi.GrafanaHTTPAPI, orgID int64) ([]string, error) {
+	userID, err := signedInUserID(client)
+	if err != nil {
+		return nil, err
+	}
+	return []string{MakeOrgResourceID(orgID, userID)}, nil
+}
+
+func CreateUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	userID, err := signedInUserID(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(MakeOrgResourceID(orgID, userID))
+
+	return UpdateUserPreferences(ctx, d, meta)
+}
+
+func ReadUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, err := userPreferencesClient(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.UserPreferences.GetUserPreferences()
+	if err, shouldReturn := common.CheckReadError("user preferences", d, err); shouldReturn {
+		return err
+	}
+
+	_, userIDStr := SplitOrgResourceID(d.Id())
+	userID, _ := strconv.ParseInt(userIDStr, 10, 64)
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("user_id", userID)
+	setPreferences(d, resp.Payload)
+
+	return nil
+}
+
+func UpdateUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := userPreferencesClient(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.UserPreferences.UpdateUserPreferences(preferencesFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadUserPreferences(ctx, d, meta)
+}
+
+func DeleteUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := userPreferencesClient(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.UserPreferences.UpdateUserPreferences(&models.UpdatePrefsCmd{})
+	return diag.FromErr(err)
+}
+
+// userPreferencesClient returns the client of the organization of the user preferences with the given ID.
+// It fails if the preferences belong to another user than the one that the provider is authenticated as, since they can't be managed.
+func userPreferencesClient(meta interface{}, id string) (*goapi.GrafanaHTTPAPI, int64, error) {
+	client, orgID, userIDStr := OAPIClientFromExistingOrgResource(meta, id)
+	userID, err := signedInUserID(client)
+	if err != nil {
+		return nil, 0, err
+	}
+	if strconv.FormatInt(userID, 10) != userIDStr {
+		return nil, 0, fmt.Errorf("the preferences of user %s can only be managed with the credentials of this user, but the provider is authenticated as user %d", userIDStr, userID)
+	}
+	return client, orgID, nil
+}
+
+func signedInUserID(client *goapi.GrafanaHTTPAPI) (int64, error) {
+	resp, err := client.SignedInUser.GetSignedInUser()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the user that the provider is authenticated as: %w", err)
+	}
+	return resp.Payload.ID, nil
+}
@@ -0,0 +1,70 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeUserPreferences(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+	client := fake.Client(t, grafana).GrafanaAPI
+
+	config := func(theme string) string {
+		return fmt.Sprintf(`
+resource "grafana_user_preferences" "test" {
+	theme                  = %q
+	timezone               = "browser"
+	week_start             = "sunday"
+	language               = "en-US"
+	query_history_home_tab = "query"
+}`, theme)
+	}
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		CheckDestroy: func(s *terraform.State) error {
+			resp, err := client.UserPreferences.GetUserPreferences()
+			require.NoError(t, err)
+			if resp.Payload.Theme != "" {
+				return fmt.Errorf("expected the preferences to be reset, got theme %q", resp.Payload.Theme)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("dark"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "id", "1:1"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "org_id", "1"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "user_id", "1"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "theme", "dark"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "language", "en-US"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.test", "query_history_home_tab", "query"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_user_preferences.test"),
+				),
+			},
+			{
+				Config: config("system"),
+				Check:  resource.TestCheckResourceAttr("grafana_user_preferences.test", "theme", "system"),
+			},
+			{
+				ResourceName:      "grafana_user_preferences.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The preferences of another user can't be managed
+			{
+				ResourceName:  "grafana_user_preferences.test",
+				ImportState:   true,
+				ImportStateId: "1:42",
+				ExpectError:   regexp.MustCompile(`the preferences of user 42 can only be managed with the credentials of this user`),
+			},
+		},
+	})
+}
@@ -79,6 +79,14 @@ func TestAccUser_basic(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"password"},
 			},
+			// Test import using the login
+			{
+				ResourceName:            "grafana_user.test",
+				ImportState:             true,
+				ImportStateId:           "user:ttu",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
 		},
 	})
 }
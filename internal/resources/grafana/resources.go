@@ -29,6 +29,8 @@ func addValidationToSchema(r *schema.Resource) {
 	if r == nil {
 		return
 	}
+	defer addConnectionToSchema(r)
+
 	createFn := r.CreateContext
 	readFn := r.ReadContext
 	updateFn := r.UpdateContext
@@ -70,6 +72,34 @@ func addValidationToSchema(r *schema.Resource) {
 	}
 }
 
+// addConnectionToSchema adds the `grafana_connection` block, which overrides the client of the provider. It wraps the validation, so that the client of the connection is validated.
+func addConnectionToSchema(r *schema.Resource) {
+	connection := connectionAttribute()
+	if r.CreateContext != nil && r.UpdateContext == nil {
+		// Resources that can't be updated are recreated when the credentials change
+		connection.ForceNew = true
+		for _, s := range connection.Elem.(*schema.Resource).Schema {
+			s.ForceNew = true
+		}
+	}
+	r.Schema["grafana_connection"] = connection
+	if r.ReadContext != nil {
+		r.ReadContext = withConnection(r.ReadContext)
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = withConnection(r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = withConnection(r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = withConnection(r.DeleteContext)
+	}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		r.Importer.StateContext = withConnectionImporter(r.Importer.StateContext)
+	}
+}
+
 func addValidationToDataSources(dataSources ...*common.DataSource) []*common.DataSource {
 	for _, d := range dataSources {
 		addValidationToSchema(d.Schema)
@@ -84,21 +114,27 @@ func addValidationToResources(resources ...*common.Resource) []*common.Resource
 	return resources
 }
 
-var DataSources = addValidationToDataSources(
-	datasourceDashboard(),
-	datasourceDashboards(),
-	datasourceDatasource(),
-	datasourceFolder(),
-	datasourceFolders(),
-	datasourceLibraryPanel(),
-	datasourceLibraryPanels(),
-	datasourceUser(),
-	datasourceUsers(),
-	datasourceRole(),
-	datasourceServiceAccount(),
-	datasourceTeam(),
-	datasourceOrganization(),
-	datasourceOrganizationPreferences(),
+var DataSources = append(
+	addValidationToDataSources(
+		datasourceDashboard(),
+		datasourceDashboardVersions(),
+		datasourceDashboards(),
+		datasourceDatasource(),
+		datasourceFolder(),
+		datasourceFolders(),
+		datasourceLibraryPanel(),
+		datasourceLibraryPanels(),
+		datasourceUser(),
+		datasourceUsers(),
+		datasourceRole(),
+		datasourceServiceAccount(),
+		datasourceTeam(),
+		datasourceOrganization(),
+		datasourceOrganizationPreferences(),
+		datasourceServerInfo(),
+	),
+	// Offline data sources don't call the Grafana API, so they need neither its client nor a `grafana_connection` block
+	datasourceDashboardModel(),
 )
 
 var Resources = addValidationToResources(
@@ -107,6 +143,8 @@ var Resources = addValidationToResources(
 	makeResourceDatasourcePermissionItem(),
 	makeResourceRoleAssignmentItem(),
 	makeResourceServiceAccountPermissionItem(),
+	makeResourceOrganizationUser(),
+	makeResourceTeamMember(),
 	resourceAnnotation(),
 	resourceContactPoint(),
 	resourceDashboard(),
@@ -130,9 +168,21 @@ var Resources = addValidationToResources(
 	resourceRuleGroup(),
 	resourceTeam(),
 	resourceTeamExternalGroup(),
+	resourceTeamPreferences(),
 	resourceServiceAccountToken(),
 	resourceServiceAccount(),
 	resourceServiceAccountPermission(),
 	resourceSSOSettings(),
 	resourceUser(),
+	resourceUserPreferences(),
 )
+
+var Actions = []*common.Action{
+	makeActionContactPointSendTestNotification(),
+	makeActionDashboardVersionRestore(),
+	makeActionReportSend(),
+	makeActionRuleGroupPause(),
+	makeActionServiceAccountTokenRevoke(),
+	makeActionServiceAccountTokenRotate(),
+	makeActionUserPasswordReset(),
+}
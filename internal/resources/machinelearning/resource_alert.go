@@ -228,7 +228,7 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 		alert, err = r.mlapi.NewOutlierAlert(ctx, data.OutlierID.ValueString(), alert)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to create resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorFrameworkDiagnostics("Unable to create resource", err, nil)...)
 		return
 	}
 
@@ -292,7 +292,7 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 		_, err = r.mlapi.UpdateOutlierAlert(ctx, data.OutlierID.ValueString(), alert)
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorFrameworkDiagnostics("Unable to Update Resource", err, nil)...)
 		return
 	}
 
@@ -330,7 +330,7 @@ func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		err = r.mlapi.DeleteOutlierAlert(ctx, data.OutlierID.ValueString(), data.ID.ValueString())
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Delete Resource", err.Error())
+		resp.Diagnostics.Append(common.APIErrorFrameworkDiagnostics("Unable to Delete Resource", err, nil)...)
 	}
 }
 
@@ -349,7 +349,7 @@ func (r *alertResource) read(ctx context.Context, model resourceAlertModel) (*re
 		alert, err = r.mlapi.OutlierAlert(ctx, model.OutlierID.ValueString(), model.ID.ValueString())
 	}
 	if err != nil {
-		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Unable to read resource", err.Error())}
+		return nil, common.APIErrorFrameworkDiagnostics("Unable to read resource", err, nil)
 	}
 
 	data := &resourceAlertModel{}
@@ -132,7 +132,7 @@ func resourceHolidayCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	holiday, err = c.NewHoliday(ctx, holiday)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating ML holiday", err, nil)
 	}
 	d.SetId(holiday.ID)
 	return resourceHolidayRead(ctx, d, meta)
@@ -172,7 +172,7 @@ func resourceHolidayUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	_, err = c.UpdateHoliday(ctx, job)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating ML holiday", err, nil)
 	}
 	return resourceHolidayRead(ctx, d, meta)
 }
@@ -180,7 +180,7 @@ func resourceHolidayUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourceHolidayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*common.Client).MLAPI
 	err := c.DeleteHoliday(ctx, d.Id())
-	return diag.FromErr(err)
+	return common.APIErrorDiagnostics("error deleting ML holiday", err, nil)
 }
 
 func makeMLHoliday(d *schema.ResourceData) (mlapi.Holiday, error) {
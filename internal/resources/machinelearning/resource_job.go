@@ -127,7 +127,7 @@ func resourceJobCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	job, err = c.NewJob(ctx, job)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating ML job", err, nil)
 	}
 	d.SetId(job.ID)
 	return resourceJobRead(ctx, d, meta)
@@ -167,7 +167,7 @@ func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	_, err = c.UpdateJob(ctx, job)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating ML job", err, nil)
 	}
 	return resourceJobRead(ctx, d, meta)
 }
@@ -175,7 +175,7 @@ func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 func resourceJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*common.Client).MLAPI
 	err := c.DeleteJob(ctx, d.Id())
-	return diag.FromErr(err)
+	return common.APIErrorDiagnostics("error deleting ML job", err, nil)
 }
 
 func makeMLJob(d *schema.ResourceData, meta interface{}) (mlapi.Job, error) {
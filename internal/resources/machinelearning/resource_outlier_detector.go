@@ -145,7 +145,7 @@ func resourceOutlierCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	outlier, err = c.NewOutlierDetector(ctx, outlier)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating ML outlier detector", err, nil)
 	}
 	d.SetId(outlier.ID)
 	return resourceOutlierRead(ctx, d, meta)
@@ -178,7 +178,7 @@ func resourceOutlierUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	_, err = c.UpdateOutlierDetector(ctx, outlier)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating ML outlier detector", err, nil)
 	}
 	return resourceOutlierRead(ctx, d, meta)
 }
@@ -186,7 +186,7 @@ func resourceOutlierUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourceOutlierDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*common.Client).MLAPI
 	err := c.DeleteOutlierDetector(ctx, d.Id())
-	return diag.FromErr(err)
+	return common.APIErrorDiagnostics("error deleting ML outlier detector", err, nil)
 }
 
 func convertToSetStructure(al mlapi.OutlierAlgorithm) []interface{} {
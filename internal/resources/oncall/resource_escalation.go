@@ -343,7 +343,7 @@ func resourceEscalationCreate(ctx context.Context, d *schema.ResourceData, clien
 
 	escalation, _, err := client.Escalations.CreateEscalation(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating escalation", err, nil)
 	}
 
 	d.SetId(escalation.ID)
@@ -481,7 +481,7 @@ func resourceEscalationUpdate(ctx context.Context, d *schema.ResourceData, clien
 
 	escalation, _, err := client.Escalations.UpdateEscalation(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating escalation", err, nil)
 	}
 
 	d.SetId(escalation.ID)
@@ -67,7 +67,7 @@ func resourceEscalationChainCreate(ctx context.Context, d *schema.ResourceData,
 
 	escalationChain, _, err := client.EscalationChains.CreateEscalationChain(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating escalation chain", err, nil)
 	}
 
 	d.SetId(escalationChain.ID)
@@ -101,7 +101,7 @@ func resourceEscalationChainUpdate(ctx context.Context, d *schema.ResourceData,
 
 	escalationChain, _, err := client.EscalationChains.UpdateEscalationChain(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating escalation chain", err, nil)
 	}
 
 	d.SetId(escalationChain.ID)
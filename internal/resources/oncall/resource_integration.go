@@ -308,7 +308,7 @@ func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, clie
 
 	integration, _, err := client.Integrations.CreateIntegration(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating integration", err, nil)
 	}
 
 	d.SetId(integration.ID)
@@ -331,7 +331,7 @@ func resourceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, clie
 
 	integration, _, err := client.Integrations.UpdateIntegration(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating integration", err, nil)
 	}
 
 	d.SetId(integration.ID)
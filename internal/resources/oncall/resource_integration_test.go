@@ -7,11 +7,35 @@ import (
 	onCallAPI "github.com/grafana/amixr-api-go-client"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestFakeOnCallIntegration_basic(t *testing.T) {
+	oncall := fake.NewOnCall(t)
+
+	fake.UnitTest(t, oncall, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOnCallIntegrationConfig("test-fake", "grafana", ``),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "id"),
+					resource.TestCheckResourceAttrSet("grafana_oncall_integration.test-acc-integration", "link"),
+					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", "test-fake"),
+					resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "type", "grafana"),
+					testutils.CheckListerWithClient(fake.Client(t, oncall), "grafana_oncall_integration.test-acc-integration"),
+				),
+			},
+			{
+				Config: testAccOnCallIntegrationConfig("test-fake-updated", "grafana", ``),
+				Check:  resource.TestCheckResourceAttr("grafana_oncall_integration.test-acc-integration", "name", "test-fake-updated"),
+			},
+		},
+	})
+}
+
 func TestAccOnCallIntegration_basic(t *testing.T) {
 	testutils.CheckCloudInstanceTestsEnabled(t)
 
@@ -193,7 +193,7 @@ func resourceOutgoingWebhookCreate(ctx context.Context, d *schema.ResourceData,
 
 	outgoingWebhook, _, err := client.Webhooks.CreateWebhook(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating outgoing webhook", err, nil)
 	}
 
 	d.SetId(outgoingWebhook.ID)
@@ -297,7 +297,7 @@ func resourceOutgoingWebhookUpdate(ctx context.Context, d *schema.ResourceData,
 
 	outgoingWebhook, _, err := client.Webhooks.UpdateWebhook(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating outgoing webhook", err, nil)
 	}
 
 	d.SetId(outgoingWebhook.ID)
@@ -172,7 +172,7 @@ func resourceRouteCreate(ctx context.Context, d *schema.ResourceData, client *on
 
 	route, _, err := client.Routes.CreateRoute(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating route", err, nil)
 	}
 
 	d.SetId(route.ID)
@@ -234,7 +234,7 @@ func resourceRouteUpdate(ctx context.Context, d *schema.ResourceData, client *on
 
 	route, _, err := client.Routes.UpdateRoute(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating route", err, nil)
 	}
 
 	d.SetId(route.ID)
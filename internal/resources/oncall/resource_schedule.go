@@ -179,7 +179,7 @@ func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, client
 
 	schedule, _, err := client.Schedules.CreateSchedule(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating schedule", err, nil)
 	}
 
 	d.SetId(schedule.ID)
@@ -242,7 +242,7 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, client
 
 	schedule, _, err := client.Schedules.UpdateSchedule(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating schedule", err, nil)
 	}
 
 	d.SetId(schedule.ID)
@@ -319,7 +319,7 @@ func resourceOnCallShiftCreate(ctx context.Context, d *schema.ResourceData, clie
 
 	onCallShift, _, err := client.OnCallShifts.CreateOnCallShift(createOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating on-call shift", err, nil)
 	}
 
 	d.SetId(onCallShift.ID)
@@ -446,7 +446,7 @@ func resourceOnCallShiftUpdate(ctx context.Context, d *schema.ResourceData, clie
 
 	onCallShift, _, err := client.OnCallShifts.UpdateOnCallShift(d.Id(), updateOptions)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating on-call shift", err, nil)
 	}
 
 	d.SetId(onCallShift.ID)
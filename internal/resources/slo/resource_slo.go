@@ -641,18 +641,5 @@ func setTerraformState(d *schema.ResourceData, slo slo.SloV00Slo) {
 }
 
 func apiError(action string, err error) diag.Diagnostics {
-	if err == nil {
-		return nil
-	}
-	detail := err.Error()
-	if err, ok := err.(*slo.GenericOpenAPIError); ok {
-		detail += "\n" + string(err.Body())
-	}
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  action,
-			Detail:   detail,
-		},
-	}
+	return common.APIErrorDiagnostics(action, err, nil)
 }
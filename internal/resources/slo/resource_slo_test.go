@@ -9,11 +9,38 @@ import (
 	slo "github.com/grafana/slo-openapi-client/go"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestFakeSlo_basic(t *testing.T) {
+	grafana := fake.NewGrafana(t)
+
+	fake.UnitTest(t, grafana, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_slo/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_slo.test", "id"),
+					resource.TestCheckResourceAttr("grafana_slo.test", "name", "Terraform Testing"),
+					resource.TestCheckResourceAttr("grafana_slo.test", "objectives.0.value", "0.995"),
+					resource.TestCheckResourceAttr("grafana_slo.test", "alerting.0.fastburn.0.annotation.#", "2"),
+					testutils.CheckListerWithClient(fake.Client(t, grafana), "grafana_slo.test"),
+				),
+			},
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_slo/resource_update.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_slo.test", "name", "Updated - Terraform Testing"),
+					resource.TestCheckResourceAttr("grafana_slo.test", "objectives.0.window", "7d"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceSlo(t *testing.T) {
 	testutils.CheckCloudInstanceTestsEnabled(t)
 
@@ -0,0 +1,124 @@
+package syntheticmonitoring
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+	smapi "github.com/grafana/synthetic-monitoring-api-go-client"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var actionCheckRunName = "grafana_synthetic_monitoring_check_run"
+
+func makeActionCheckRun() *common.Action {
+	return common.NewAction(
+		common.CategorySyntheticMonitoring,
+		actionCheckRunName,
+		&actionCheckRun{},
+	)
+}
+
+type actionCheckRunModel struct {
+	CheckID types.String `tfsdk:"check_id"`
+	Probes  types.List   `tfsdk:"probes"`
+}
+
+type actionCheckRun struct {
+	client *smapi.Client
+}
+
+func (a *actionCheckRun) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Configure is called multiple times (sometimes when ProviderData is not yet available), we only want to configure once
+	if req.ProviderData == nil || a.client != nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*common.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *common.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client.SMAPI
+}
+
+func (a *actionCheckRun) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = actionCheckRunName
+}
+
+func (a *actionCheckRun) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Runs a Synthetic Monitoring check once, right now, like the "Test" button of the Grafana UI.
+The check runs from its probes, or from the given ones, and its results are sent to the logs of the stack.
+
+* [Official documentation](https://grafana.com/docs/grafana-cloud/testing/synthetic-monitoring/)
+`,
+		Attributes: map[string]schema.Attribute{
+			"check_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the check, ex: the `id` attribute of a `grafana_synthetic_monitoring_check` resource.",
+			},
+			"probes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "The IDs of the probes to run the check from. Defaults to the probes of the check.",
+			},
+		},
+	}
+}
+
+func (a *actionCheckRun) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data actionCheckRunModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.client == nil {
+		resp.Diagnostics.AddError("Failed to run the check", "the SM client is required for this action. Set the sm_access_token provider attribute")
+		return
+	}
+	id, err := strconv.ParseInt(data.CheckID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid check ID", err.Error())
+		return
+	}
+
+	check, err := a.client.GetCheck(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get the check", err.Error())
+		return
+	}
+	if !data.Probes.IsNull() {
+		check.Probes = nil
+		resp.Diagnostics.Append(data.Probes.ElementsAs(ctx, &check.Probes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The ad-hoc check API isn't part of the SM client
+	httpResp, err := a.client.PostJSON(ctx, "/check/adhoc", true, check)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to run the check", fmt.Sprintf("sending ad-hoc check request: %v", err))
+		return
+	}
+	var result sm.AdHocCheck
+	if err := smapi.ValidateResponse("ad-hoc check request", httpResp, &result); err != nil {
+		resp.Diagnostics.AddError("Failed to run the check", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started ad-hoc check %s of %s from %d probes, its results are sent to the logs of the stack", result.Id, check.Job, len(result.Probes)),
+	})
+}
@@ -0,0 +1,46 @@
+package syntheticmonitoring_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeActionCheckRun(t *testing.T) {
+	smFake := fake.NewSyntheticMonitoring(t)
+	client := fake.Client(t, smFake).SMAPI
+
+	probes, err := client.ListProbes(context.Background())
+	require.NoError(t, err)
+	check, err := client.AddCheck(context.Background(), sm.Check{
+		Job:       "HTTP Defaults",
+		Target:    "https://grafana.com",
+		Frequency: 60000,
+		Timeout:   3000,
+		Probes:    []int64{probes[0].Id},
+		Settings:  sm.CheckSettings{Http: &sm.HttpSettings{}},
+	})
+	require.NoError(t, err)
+
+	server := fake.ProviderServer(t, smFake)
+	messages, diags := testutils.InvokeAction(t, server, "grafana_synthetic_monitoring_check_run", map[string]tftypes.Value{
+		"check_id": tftypes.NewValue(tftypes.String, strconv.FormatInt(check.Id, 10)),
+	})
+	require.Empty(t, diags)
+	require.Len(t, messages, 1)
+	require.Contains(t, messages[0], "from 1 probes")
+
+	// Unknown probe
+	_, diags = testutils.InvokeAction(t, server, "grafana_synthetic_monitoring_check_run", map[string]tftypes.Value{
+		"check_id": tftypes.NewValue(tftypes.String, strconv.FormatInt(check.Id, 10)),
+		"probes":   tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{tftypes.NewValue(tftypes.Number, 1234)}),
+	})
+	require.Len(t, diags, 1)
+	require.Equal(t, "Failed to run the check", diags[0].Summary)
+}
@@ -818,7 +818,7 @@ func resourceCheckCreate(ctx context.Context, d *schema.ResourceData, c *smapi.C
 	}
 	res, err := c.AddCheck(ctx, *chk)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating check", err, nil)
 	}
 	d.SetId(strconv.FormatInt(res.Id, 10))
 	d.Set("tenant_id", res.TenantId)
@@ -1155,7 +1155,7 @@ func resourceCheckUpdate(ctx context.Context, d *schema.ResourceData, c *smapi.C
 	}
 	_, err = c.UpdateCheck(ctx, *chk)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating check", err, nil)
 	}
 	return resourceCheckRead(ctx, d, c)
 }
@@ -10,12 +10,38 @@ import (
 
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils/fake"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 )
 
+func TestFakeCheck_http(t *testing.T) {
+	sm := fake.NewSyntheticMonitoring(t)
+
+	fake.UnitTest(t, sm, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_synthetic_monitoring_check/http_basic.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.http", "id"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.http", "job", "HTTP Defaults"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.http", "target", "https://grafana.com"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.http", "probes.#", "1"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.http", "labels.foo", "bar"),
+					testutils.CheckListerWithClient(fake.Client(t, sm), "grafana_synthetic_monitoring_check.http"),
+				),
+			},
+			{
+				ResourceName:      "grafana_synthetic_monitoring_check.http",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccResourceCheck_dns(t *testing.T) {
 	testutils.CheckCloudInstanceTestsEnabled(t)
 
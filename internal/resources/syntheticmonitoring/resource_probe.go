@@ -143,7 +143,7 @@ func resourceProbeCreate(ctx context.Context, d *schema.ResourceData, c *smapi.C
 	p := makeProbe(d)
 	res, token, err := c.AddProbe(ctx, *p)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error creating probe", err, nil)
 	}
 	d.SetId(strconv.FormatInt(res.Id, 10))
 	d.Set("tenant_id", res.TenantId)
@@ -193,7 +193,7 @@ func resourceProbeUpdate(ctx context.Context, d *schema.ResourceData, c *smapi.C
 	p := makeProbe(d)
 	_, err := c.UpdateProbe(ctx, *p)
 	if err != nil {
-		return diag.FromErr(err)
+		return common.APIErrorDiagnostics("error updating probe", err, nil)
 	}
 	return resourceProbeRead(ctx, d, c)
 }
@@ -30,3 +30,7 @@ var Resources = []*common.Resource{
 	resourceCheck(),
 	resourceProbe(),
 }
+
+var Actions = []*common.Action{
+	makeActionCheckRun(),
+}
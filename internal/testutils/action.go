@@ -0,0 +1,76 @@
+package testutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// InvokeAction plans then invokes an action of the provider, with the given attributes set in its config and all others null.
+// It returns the progress messages and the diagnostics of the action.
+// Actions can't be tested with Terraform test cases, since they are only supported by Terraform 1.14+, so they are called through the provider protocol.
+func InvokeAction(t *testing.T, server tfprotov5.ProviderServer, actionType string, attributes map[string]tftypes.Value) ([]string, []*tfprotov5.Diagnostic) {
+	t.Helper()
+
+	ctx := context.Background()
+	actionServer, ok := server.(tfprotov5.ProviderServerWithActions)
+	if !ok {
+		t.Fatalf("the provider server does not support actions")
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get the provider schema: %v", err)
+	}
+	schema, ok := schemas.ActionSchemas[actionType]
+	if !ok {
+		t.Fatalf("action %s not found", actionType)
+	}
+
+	objectType := schema.Schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	config, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("failed to create the action config: %v", err)
+	}
+
+	planResp, err := actionServer.PlanAction(ctx, &tfprotov5.PlanActionRequest{ActionType: actionType, Config: &config})
+	if err != nil {
+		t.Fatalf("failed to plan action %s: %v", actionType, err)
+	}
+	if hasError(planResp.Diagnostics) {
+		return nil, planResp.Diagnostics
+	}
+
+	stream, err := actionServer.InvokeAction(ctx, &tfprotov5.InvokeActionRequest{ActionType: actionType, Config: &config})
+	if err != nil {
+		t.Fatalf("failed to invoke action %s: %v", actionType, err)
+	}
+	var messages []string
+	var diags []*tfprotov5.Diagnostic
+	for event := range stream.Events {
+		switch e := event.Type.(type) {
+		case tfprotov5.ProgressInvokeActionEventType:
+			messages = append(messages, e.Message)
+		case tfprotov5.CompletedInvokeActionEventType:
+			diags = append(diags, e.Diagnostics...)
+		}
+	}
+	return messages, diags
+}
+
+func hasError(diags []*tfprotov5.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+// Package fake provides in-memory stand-ins for the Grafana, SLO, OnCall and Synthetic Monitoring APIs.
+// They implement the subset of the APIs used by the provider, so that resources and listers can be tested
+// with `go test`, without a live instance or network access.
+//
+// Example:
+//
+//	grafana := fake.NewGrafana(t)
+//	fake.UnitTest(t, grafana, resource.TestCase{
+//		...
+//	})
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Server is a fake API server that can be used to configure the provider.
+type Server interface {
+	// URL is the base URL of the server.
+	URL() string
+	// providerConfig returns the provider attributes needed to use the server.
+	providerConfig() map[string]string
+}
+
+// UnitTest runs the test case with a provider configured to use the given fake server, like resource.UnitTest.
+// Since Terraform runs the test steps, the test is skipped if it isn't installed, rather than downloading it.
+func UnitTest(t *testing.T, server Server, c resource.TestCase) {
+	t.Helper()
+
+	if _, err := exec.LookPath("terraform"); err != nil && os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		t.Skip("terraform must be installed to run tests with fake servers")
+	}
+	c.ProtoV5ProviderFactories = testutils.ProtoV5ProviderFactoriesWithConfig(config([]Server{server}))
+	resource.UnitTest(t, c)
+}
+
+// ProviderServer returns a provider server configured to use the given fake servers, ex: to test actions through the provider protocol.
+func ProviderServer(t testing.TB, servers ...Server) tfprotov5.ProviderServer {
+	t.Helper()
+
+	server, err := testutils.ProtoV5ProviderFactoriesWithConfig(config(servers))["grafana"]()
+	if err != nil {
+		t.Fatalf("failed to create the provider server: %v", err)
+	}
+	return server
+}
+
+// Client returns a client of the given fake servers, ex: to call listers or to check the state of the fake servers through the API.
+func Client(t testing.TB, servers ...Server) *common.Client {
+	t.Helper()
+
+	var cfg provider.ProviderConfig
+	for k, v := range config(servers) {
+		switch k {
+		case "url":
+			cfg.URL = types.StringValue(v)
+		case "auth":
+			cfg.Auth = types.StringValue(v)
+		case "sm_url":
+			cfg.SMURL = types.StringValue(v)
+		case "sm_access_token":
+			cfg.SMAccessToken = types.StringValue(v)
+		case "oncall_url":
+			cfg.OncallURL = types.StringValue(v)
+		case "oncall_access_token":
+			cfg.OncallAccessToken = types.StringValue(v)
+		}
+	}
+	if err := cfg.SetDefaults(); err != nil {
+		t.Fatalf("failed to set the client defaults: %v", err)
+	}
+	client, err := provider.CreateClients(cfg)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	return client
+}
+
+func config(servers []Server) map[string]string {
+	config := map[string]string{}
+	for _, s := range servers {
+		for k, v := range s.providerConfig() {
+			config[k] = v
+		}
+	}
+	return config
+}
+
+// server is the base of the fake servers: an HTTP server with a router and a lock for the in-memory state.
+type server struct {
+	mu     sync.Mutex
+	http   *httptest.Server
+	routes []route
+	nextID int64
+
+	// Forbidden lists the routes that are denied with a 403, as the method and the pattern of their handler, ex: `GET /api/library-elements`.
+	// It can be changed during a test, ex: to check how missing permissions are reported.
+	Forbidden []string
+}
+
+type handlerFunc func(r *http.Request, params map[string]string) (int, any)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+func (s *server) start(t testing.TB) {
+	s.http = httptest.NewServer(s)
+	t.Cleanup(s.http.Close)
+}
+
+func (s *server) URL() string {
+	return s.http.URL
+}
+
+// handle registers a handler. Path segments in braces are parameters, ex: `/api/folders/{uid}`.
+// Handlers are called with the lock held, and their response is encoded as JSON.
+func (s *server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	pathFound := false
+	for _, route := range s.routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		pathFound = true
+		if route.method != r.Method {
+			continue
+		}
+
+		s.mu.Lock()
+		var status int
+		var body any
+		if slices.Contains(s.Forbidden, route.method+" /"+strings.Join(route.segments, "/")) {
+			status, body = http.StatusForbidden, errorBody("You'll need additional permissions to perform this action")
+		} else {
+			status, body = route.handler(r, params)
+		}
+		s.mu.Unlock()
+		writeJSON(w, status, body)
+		return
+	}
+
+	if pathFound {
+		writeJSON(w, http.StatusMethodNotAllowed, errorBody("method not allowed"))
+		return
+	}
+	writeJSON(w, http.StatusNotFound, errorBody("not found"))
+}
+
+func (r route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// newID returns a new unique numeric ID. It must be called with the lock held.
+func (s *server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// newUID returns a new unique string ID. It must be called with the lock held.
+func (s *server) newUID(prefix string) string {
+	return fmt.Sprintf("%s%d", prefix, s.newID())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func readJSON(r *http.Request, v any) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func errorBody(message string) map[string]any {
+	return map[string]any{"message": message}
+}
+
+func badRequest(err error) (int, any) {
+	return http.StatusBadRequest, errorBody(err.Error())
+}
+
+func notFound(kind string) (int, any) {
+	return http.StatusNotFound, errorBody(kind + " not found")
+}
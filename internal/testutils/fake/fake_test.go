@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// request sends a request to the handler of a fake server, without going through the network.
+// It returns the status code and the decoded JSON body of the response.
+func request(t *testing.T, h http.Handler, method, path string, body any) (int, any) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, &reqBody))
+
+	var respBody any
+	if rec.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBody))
+	}
+	return rec.Code, respBody
+}
+
+func TestServerRouting(t *testing.T) {
+	s := &server{}
+	s.handle("GET", "/api/things/{uid}", func(r *http.Request, params map[string]string) (int, any) {
+		return http.StatusOK, map[string]any{"uid": params["uid"]}
+	})
+	s.handle("GET", "/api/things/{uid}/parts/{id}", func(r *http.Request, params map[string]string) (int, any) {
+		return http.StatusOK, map[string]any{"uid": params["uid"], "id": params["id"]}
+	})
+	// The first matching route wins, so fixed segments must be registered before parameters
+	s.handle("GET", "/api/things/special", func(r *http.Request, params map[string]string) (int, any) {
+		return http.StatusTeapot, nil
+	})
+
+	status, body := request(t, s, "GET", "/api/things/abc", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, map[string]any{"uid": "abc"}, body)
+
+	status, body = request(t, s, "GET", "/api/things/abc/parts/1", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, map[string]any{"uid": "abc", "id": "1"}, body)
+
+	status, _ = request(t, s, "GET", "/api/things/special", nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status, body = request(t, s, "DELETE", "/api/things/abc", nil)
+	require.Equal(t, http.StatusMethodNotAllowed, status)
+	require.Equal(t, errorBody("method not allowed"), body)
+
+	status, body = request(t, s, "GET", "/api/other", nil)
+	require.Equal(t, http.StatusNotFound, status)
+	require.Equal(t, errorBody("not found"), body)
+
+	s.Forbidden = []string{"GET /api/things/{uid}"}
+	status, _ = request(t, s, "GET", "/api/things/abc", nil)
+	require.Equal(t, http.StatusForbidden, status)
+	status, _ = request(t, s, "GET", "/api/things/abc/parts/1", nil)
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestServerIDs(t *testing.T) {
+	s := &server{}
+	require.Equal(t, int64(1), s.newID())
+	require.Equal(t, int64(2), s.newID())
+	require.Equal(t, "folder3", s.newUID("folder"))
+}
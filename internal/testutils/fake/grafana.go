@@ -0,0 +1,1224 @@
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// Grafana is a fake Grafana server, with a single organization.
+// It implements the folder, dashboard (with versions), library panel, search, annotation, data source, user, org user, team, service account token and alerting provisioning APIs, as well as the SLO plugin API.
+type Grafana struct {
+	server
+
+	// Version, Edition and FeatureToggles are returned by the health and frontend settings endpoints.
+	// They can be changed before the provider is configured.
+	Version        string
+	Edition        string
+	FeatureToggles []string
+
+	folders         map[string]*models.Folder
+	dashboards      map[string]*dashboard
+	libraryElements map[string]*models.LibraryElementDTO
+	dataSources     map[string]*models.DataSource
+	users           map[int64]*models.UserProfileDTO
+	orgRoles        map[int64]string
+	userPreferences map[int64]models.Preferences
+	teams           map[int64]*team
+	serviceAccounts map[int64]*models.ServiceAccountDTO
+	// Tokens are stored by service account ID, and any ID is accepted, even without a service account.
+	serviceAccountTokens map[int64]map[int64]*models.TokenDTO
+	annotations          []*models.Annotation
+	alerting
+	slos map[string]map[string]any
+}
+
+// adminUserID is the ID of the user that the provider is authenticated as.
+const adminUserID int64 = 1
+
+type dashboard struct {
+	id        int64
+	folderUID string
+	model     map[string]any
+	versions  []*models.DashboardVersionMeta
+}
+
+type team struct {
+	models.TeamDTO
+	preferences models.Preferences
+	members     map[int64]bool
+}
+
+// NewGrafana starts a fake Grafana server, which is stopped at the end of the test.
+func NewGrafana(t testing.TB) *Grafana {
+	g := &Grafana{
+		Version:              "11.3.0",
+		Edition:              "Open Source",
+		folders:              map[string]*models.Folder{},
+		dashboards:           map[string]*dashboard{},
+		libraryElements:      map[string]*models.LibraryElementDTO{},
+		dataSources:          map[string]*models.DataSource{},
+		users:                map[int64]*models.UserProfileDTO{},
+		orgRoles:             map[int64]string{},
+		userPreferences:      map[int64]models.Preferences{},
+		teams:                map[int64]*team{},
+		serviceAccounts:      map[int64]*models.ServiceAccountDTO{},
+		serviceAccountTokens: map[int64]map[int64]*models.TokenDTO{},
+		alerting:             newAlerting(),
+		slos:                 map[string]map[string]any{},
+	}
+
+	// The provider is authenticated as the admin user, the first user of the organization
+	g.users[adminUserID] = &models.UserProfileDTO{ID: adminUserID, UID: "admin", Login: "admin", Email: "admin@localhost", Name: "admin", OrgID: 1, IsGrafanaAdmin: true}
+	g.orgRoles[adminUserID] = "Admin"
+	g.nextID = adminUserID
+
+	g.handle("GET", "/api/health", g.getHealth)
+	g.handle("GET", "/api/frontend/settings", g.getFrontendSettings)
+	g.handle("GET", "/api/org", g.getOrg)
+	g.handle("GET", "/api/orgs", g.searchOrgs)
+	g.handle("GET", "/api/org/users", g.getOrgUsers)
+	g.handle("POST", "/api/org/users", g.addOrgUser)
+	g.handle("PATCH", "/api/org/users/{userId}", g.updateOrgUser)
+	g.handle("DELETE", "/api/org/users/{userId}", g.removeOrgUser)
+	g.handle("POST", "/api/admin/users", g.createUser)
+	g.handle("GET", "/api/users/{id}", g.getUser)
+	g.handle("GET", "/api/user", g.getSignedInUser)
+	g.handle("GET", "/api/user/preferences", g.getUserPreferences)
+	g.handle("PUT", "/api/user/preferences", g.updateUserPreferences)
+
+	g.handle("GET", "/api/folders", g.listFolders)
+	g.handle("POST", "/api/folders", g.createFolder)
+	g.handle("GET", "/api/folders/id/{id}", g.getFolderByID)
+	g.handle("GET", "/api/folders/{uid}", g.getFolder)
+	g.handle("PUT", "/api/folders/{uid}", g.updateFolder)
+	g.handle("DELETE", "/api/folders/{uid}", g.deleteFolder)
+	g.handle("POST", "/api/folders/{uid}/move", g.moveFolder)
+
+	g.handle("GET", "/api/search", g.search)
+	g.handle("POST", "/api/dashboards/db", g.saveDashboard)
+	g.handle("GET", "/api/dashboards/uid/{uid}", g.getDashboard)
+	g.handle("DELETE", "/api/dashboards/uid/{uid}", g.deleteDashboard)
+	g.handle("GET", "/api/dashboards/uid/{uid}/versions", g.listDashboardVersions)
+	g.handle("POST", "/api/dashboards/uid/{uid}/restore", g.restoreDashboardVersion)
+
+	g.handle("GET", "/api/library-elements", g.listLibraryElements)
+	g.handle("POST", "/api/library-elements", g.createLibraryElement)
+	g.handle("GET", "/api/library-elements/{uid}", g.getLibraryElement)
+	g.handle("PATCH", "/api/library-elements/{uid}", g.updateLibraryElement)
+	g.handle("GET", "/api/library-elements/{uid}/connections", g.getLibraryElementConnections)
+	g.handle("DELETE", "/api/library-elements/{uid}", g.deleteLibraryElement)
+
+	g.handle("GET", "/api/datasources", g.listDataSources)
+	g.handle("POST", "/api/datasources", g.createDataSource)
+	g.handle("GET", "/api/datasources/uid/{uid}", g.getDataSourceByUID)
+	g.handle("PUT", "/api/datasources/uid/{uid}", g.updateDataSource)
+	g.handle("DELETE", "/api/datasources/uid/{uid}", g.deleteDataSource)
+	g.handle("GET", "/api/datasources/name/{name}", g.getDataSourceByName)
+	g.handle("GET", "/api/datasources/uid/{uid}/health", g.checkDataSourceHealth)
+	g.handle("GET", "/api/datasources/{id}", g.getDataSourceByID)
+
+	g.handle("GET", "/api/annotations", g.listAnnotations)
+	g.handle("POST", "/api/annotations", g.createAnnotation)
+
+	g.handle("GET", "/api/teams/search", g.searchTeams)
+	g.handle("POST", "/api/teams", g.createTeam)
+	g.handle("GET", "/api/teams/{id}", g.getTeam)
+	g.handle("PUT", "/api/teams/{id}", g.updateTeam)
+	g.handle("DELETE", "/api/teams/{id}", g.deleteTeam)
+	g.handle("GET", "/api/teams/{id}/members", g.getTeamMembers)
+	g.handle("POST", "/api/teams/{id}/members", g.addTeamMember)
+	g.handle("DELETE", "/api/teams/{id}/members/{userId}", g.removeTeamMember)
+	g.handle("GET", "/api/teams/{id}/groups", g.getTeamGroups)
+	g.handle("GET", "/api/teams/{id}/preferences", g.getTeamPreferences)
+	g.handle("PUT", "/api/teams/{id}/preferences", g.updateTeamPreferences)
+
+	g.handle("GET", "/api/serviceaccounts/search", g.searchServiceAccounts)
+	g.handle("POST", "/api/serviceaccounts", g.createServiceAccount)
+	g.handle("DELETE", "/api/serviceaccounts/{id}", g.deleteServiceAccount)
+	g.handle("GET", "/api/serviceaccounts/{id}/tokens", g.listServiceAccountTokens)
+	g.handle("POST", "/api/serviceaccounts/{id}/tokens", g.createServiceAccountToken)
+	g.handle("DELETE", "/api/serviceaccounts/{id}/tokens/{tokenId}", g.deleteServiceAccountToken)
+
+	g.handleAlerting()
+	g.handleSLO()
+
+	g.start(t)
+	return g
+}
+
+func (g *Grafana) providerConfig() map[string]string {
+	return map[string]string{
+		"url":  g.URL(),
+		"auth": "admin:admin",
+	}
+}
+
+func (g *Grafana) getHealth(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, models.HealthResponse{Version: g.Version, Commit: "fake", Database: "ok"}
+}
+
+func (g *Grafana) getFrontendSettings(r *http.Request, _ map[string]string) (int, any) {
+	toggles := map[string]bool{}
+	for _, toggle := range g.FeatureToggles {
+		toggles[toggle] = true
+	}
+	return http.StatusOK, map[string]any{
+		"buildInfo":      map[string]any{"version": g.Version, "edition": g.Edition},
+		"featureToggles": toggles,
+	}
+}
+
+func (g *Grafana) getOrg(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, models.OrgDetailsDTO{ID: 1, Name: "Main Org."}
+}
+
+func (g *Grafana) searchOrgs(r *http.Request, _ map[string]string) (int, any) {
+	if !firstPage(r) {
+		return http.StatusOK, []*models.OrgDTO{}
+	}
+	return http.StatusOK, []*models.OrgDTO{{ID: 1, Name: "Main Org."}}
+}
+
+func (g *Grafana) getOrgUsers(r *http.Request, _ map[string]string) (int, any) {
+	users := []*models.OrgUserDTO{}
+	for _, u := range sortedValues(g.users) {
+		if role, ok := g.orgRoles[u.ID]; ok {
+			users = append(users, &models.OrgUserDTO{UserID: u.ID, OrgID: 1, Login: u.Login, Email: u.Email, Name: u.Name, Role: role})
+		}
+	}
+	return http.StatusOK, users
+}
+
+func (g *Grafana) addOrgUser(r *http.Request, _ map[string]string) (int, any) {
+	var body models.AddOrgUserCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	for _, u := range g.users {
+		if u.Login != body.LoginOrEmail && u.Email != body.LoginOrEmail {
+			continue
+		}
+		if _, ok := g.orgRoles[u.ID]; ok {
+			return http.StatusConflict, errorBody("User is already member of this organization")
+		}
+		g.orgRoles[u.ID] = body.Role
+		return http.StatusOK, map[string]any{"message": "User added to organization", "userId": u.ID}
+	}
+	return notFound("user")
+}
+
+// orgUser returns the ID of the user of the organization identified by the `userId` parameter.
+func (g *Grafana) orgUser(params map[string]string) (int64, bool) {
+	id, err := strconv.ParseInt(params["userId"], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	_, ok := g.orgRoles[id]
+	return id, ok
+}
+
+func (g *Grafana) updateOrgUser(r *http.Request, params map[string]string) (int, any) {
+	id, ok := g.orgUser(params)
+	if !ok {
+		return notFound("user")
+	}
+	var body models.UpdateOrgUserCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	g.orgRoles[id] = body.Role
+	return http.StatusOK, errorBody("Organization user updated")
+}
+
+// removeOrgUser removes a user from the organization and from its teams.
+func (g *Grafana) removeOrgUser(r *http.Request, params map[string]string) (int, any) {
+	id, ok := g.orgUser(params)
+	if !ok {
+		return notFound("user")
+	}
+	delete(g.orgRoles, id)
+	for _, t := range g.teams {
+		delete(t.members, id)
+	}
+	return http.StatusOK, errorBody("User removed from organization")
+}
+
+func (g *Grafana) getUser(r *http.Request, params map[string]string) (int, any) {
+	id, _ := strconv.ParseInt(params["id"], 10, 64)
+	u, ok := g.users[id]
+	if !ok {
+		return notFound("user")
+	}
+	return http.StatusOK, u
+}
+
+func (g *Grafana) getSignedInUser(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, g.users[adminUserID]
+}
+
+func (g *Grafana) getUserPreferences(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, g.userPreferences[adminUserID]
+}
+
+func (g *Grafana) updateUserPreferences(r *http.Request, _ map[string]string) (int, any) {
+	var body models.UpdatePrefsCmd
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	g.userPreferences[adminUserID] = preferences(body)
+	return http.StatusOK, errorBody("Preferences updated")
+}
+
+// preferences returns the preferences set by an update command. Preferences that aren't set are reset.
+func preferences(body models.UpdatePrefsCmd) models.Preferences {
+	return models.Preferences{
+		HomeDashboardUID: body.HomeDashboardUID,
+		Theme:            body.Theme,
+		Timezone:         body.Timezone,
+		WeekStart:        body.WeekStart,
+		Language:         body.Language,
+		QueryHistory:     body.QueryHistory,
+	}
+}
+
+// createUser creates a user, which is added to the organization as a viewer, as with the default `auto_assign_org` setting.
+func (g *Grafana) createUser(r *http.Request, _ map[string]string) (int, any) {
+	var body models.AdminCreateUserForm
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	for _, u := range g.users {
+		if u.Login == body.Login || u.Email == body.Email {
+			return http.StatusPreconditionFailed, errorBody("User with email '" + body.Email + "' or username '" + body.Login + "' already exists")
+		}
+	}
+	u := &models.UserProfileDTO{ID: g.newID(), Login: body.Login, Email: body.Email, Name: body.Name, OrgID: 1}
+	u.UID = g.newUID("user")
+	g.users[u.ID] = u
+	g.orgRoles[u.ID] = "Viewer"
+	return http.StatusOK, map[string]any{"id": u.ID, "uid": u.UID, "message": "User created"}
+}
+
+// Folders
+
+func (g *Grafana) listFolders(r *http.Request, _ map[string]string) (int, any) {
+	parentUID := r.URL.Query().Get("parentUid")
+	hits := []*models.FolderSearchHit{}
+	if !firstPage(r) {
+		return http.StatusOK, hits
+	}
+	for _, f := range sortedValues(g.folders) {
+		if f.ParentUID == parentUID {
+			hits = append(hits, &models.FolderSearchHit{ID: f.ID, UID: f.UID, Title: f.Title, ParentUID: f.ParentUID})
+		}
+	}
+	return http.StatusOK, hits
+}
+
+func (g *Grafana) createFolder(r *http.Request, _ map[string]string) (int, any) {
+	var body models.CreateFolderCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.UID == "" {
+		body.UID = g.newUID("folder")
+	}
+	if _, ok := g.folders[body.UID]; ok {
+		return http.StatusConflict, errorBody("a folder with the same uid already exists")
+	}
+	if body.ParentUID != "" && g.folders[body.ParentUID] == nil {
+		return notFound("parent folder")
+	}
+	folder := &models.Folder{
+		ID:        g.newID(),
+		UID:       body.UID,
+		Title:     body.Title,
+		ParentUID: body.ParentUID,
+		OrgID:     1,
+		URL:       "/dashboards/f/" + body.UID + "/" + slugify(body.Title),
+		Version:   1,
+	}
+	g.folders[folder.UID] = folder
+	return http.StatusOK, folder
+}
+
+func (g *Grafana) getFolder(r *http.Request, params map[string]string) (int, any) {
+	folder, ok := g.folders[params["uid"]]
+	if !ok {
+		return notFound("folder")
+	}
+	return http.StatusOK, g.withParents(folder)
+}
+
+// withParents returns a copy of the folder with its parent folders, from the root, like Grafana with nested folders.
+func (g *Grafana) withParents(folder *models.Folder) *models.Folder {
+	result := *folder
+	result.Parents = []*models.Folder{}
+	for parent := g.folders[folder.ParentUID]; parent != nil; parent = g.folders[parent.ParentUID] {
+		result.Parents = append([]*models.Folder{parent}, result.Parents...)
+	}
+	return &result
+}
+
+func (g *Grafana) getFolderByID(r *http.Request, params map[string]string) (int, any) {
+	for _, folder := range g.folders {
+		if strconv.FormatInt(folder.ID, 10) == params["id"] {
+			return http.StatusOK, g.withParents(folder)
+		}
+	}
+	return notFound("folder")
+}
+
+func (g *Grafana) updateFolder(r *http.Request, params map[string]string) (int, any) {
+	folder, ok := g.folders[params["uid"]]
+	if !ok {
+		return notFound("folder")
+	}
+	var body models.UpdateFolderCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if !body.Overwrite && body.Version != folder.Version {
+		return http.StatusConflict, errorBody("the folder has been changed by someone else")
+	}
+	folder.Title = body.Title
+	folder.URL = "/dashboards/f/" + folder.UID + "/" + slugify(body.Title)
+	folder.Version++
+	return http.StatusOK, folder
+}
+
+func (g *Grafana) moveFolder(r *http.Request, params map[string]string) (int, any) {
+	folder, ok := g.folders[params["uid"]]
+	if !ok {
+		return notFound("folder")
+	}
+	var body models.MoveFolderCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.ParentUID != "" && g.folders[body.ParentUID] == nil {
+		return http.StatusBadRequest, errorBody("parent folder not found")
+	}
+	// Like in Grafana, a folder can't be moved into itself or one of its subfolders
+	for parent := g.folders[body.ParentUID]; parent != nil; parent = g.folders[parent.ParentUID] {
+		if parent.UID == folder.UID {
+			return http.StatusConflict, errorBody("a folder can't be moved into one of its subfolders")
+		}
+	}
+	folder.ParentUID = body.ParentUID
+	return http.StatusOK, g.withParents(folder)
+}
+
+func (g *Grafana) deleteFolder(r *http.Request, params map[string]string) (int, any) {
+	folder, ok := g.folders[params["uid"]]
+	if !ok {
+		return notFound("folder")
+	}
+	// Like in Grafana, the contents of the folder are deleted with it, except library panels
+	if g.hasLibraryElements(folder.UID) {
+		return http.StatusBadRequest, errorBody("folder could not be deleted because it contains library elements in use")
+	}
+	for uid, f := range g.folders {
+		if f.ParentUID == folder.UID {
+			g.deleteFolder(r, map[string]string{"uid": uid})
+		}
+	}
+	for uid, d := range g.dashboards {
+		if d.folderUID == folder.UID {
+			delete(g.dashboards, uid)
+		}
+	}
+	g.deleteFolderRules(folder.UID)
+	delete(g.folders, folder.UID)
+	return http.StatusOK, map[string]any{"id": folder.ID, "title": folder.Title, "message": "Folder deleted"}
+}
+
+// hasLibraryElements returns true if the folder or one of its subfolders contains library panels.
+func (g *Grafana) hasLibraryElements(folderUID string) bool {
+	for _, element := range g.libraryElements {
+		if element.FolderUID == folderUID {
+			return true
+		}
+	}
+	for _, f := range g.folders {
+		if f.ParentUID == folderUID && g.hasLibraryElements(f.UID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dashboards
+
+func (g *Grafana) search(r *http.Request, _ map[string]string) (int, any) {
+	query := r.URL.Query()
+	hits := []*models.Hit{}
+	if !firstPage(r) {
+		return http.StatusOK, hits
+	}
+
+	matches := func(hit *models.Hit) bool {
+		if t := query.Get("type"); t != "" && string(hit.Type) != t {
+			return false
+		}
+		if q := query.Get("query"); q != "" && !strings.Contains(strings.ToLower(hit.Title), strings.ToLower(q)) {
+			return false
+		}
+		if uids := query["dashboardUIDs"]; len(uids) > 0 && !slices.Contains(uids, hit.UID) {
+			return false
+		}
+		if uids := query["folderUIDs"]; len(uids) > 0 && !slices.Contains(uids, hit.FolderUID) {
+			return false
+		}
+		for _, tag := range query["tag"] {
+			if !slices.Contains(hit.Tags, tag) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, f := range sortedValues(g.folders) {
+		hit := &models.Hit{ID: f.ID, UID: f.UID, Title: f.Title, Type: "dash-folder", FolderUID: f.ParentUID, URL: f.URL, Tags: []string{}}
+		if matches(hit) {
+			hits = append(hits, hit)
+		}
+	}
+	for _, d := range sortedValues(g.dashboards) {
+		hit := &models.Hit{ID: d.id, UID: d.uid(), Title: d.title(), Type: "dash-db", FolderUID: d.folderUID, URL: d.url(), Tags: d.tags()}
+		if matches(hit) {
+			hits = append(hits, hit)
+		}
+	}
+	return http.StatusOK, hits
+}
+
+func (g *Grafana) saveDashboard(r *http.Request, _ map[string]string) (int, any) {
+	var body struct {
+		Dashboard map[string]any `json:"dashboard"`
+		FolderUID string         `json:"folderUid"`
+		Overwrite bool           `json:"overwrite"`
+		Message   string         `json:"message"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.Dashboard == nil {
+		return badRequest(fmt.Errorf("dashboard is required"))
+	}
+	if body.FolderUID != "" && g.folders[body.FolderUID] == nil {
+		return http.StatusBadRequest, errorBody("folder not found")
+	}
+
+	d := &dashboard{model: body.Dashboard, folderUID: body.FolderUID}
+	if id, ok := d.model["id"].(float64); ok && id != 0 && d.uid() == "" {
+		// Dashboards without UIDs are matched by ID
+		for uid, existing := range g.dashboards {
+			if float64(existing.id) == id {
+				d.model["uid"] = uid
+			}
+		}
+	}
+	if d.uid() == "" {
+		d.model["uid"] = g.newUID("dashboard")
+	}
+	version := 1.0
+	if existing, ok := g.dashboards[d.uid()]; ok {
+		if !body.Overwrite && d.model["version"] != existing.model["version"] {
+			return http.StatusPreconditionFailed, map[string]any{"status": "version-mismatch", "message": "The dashboard has been changed by someone else"}
+		}
+		d.id = existing.id
+		d.versions = existing.versions
+		version = existing.model["version"].(float64) + 1
+	} else {
+		d.id = g.newID()
+	}
+	d.model["id"] = d.id
+	d.model["version"] = version
+	d.addVersion(body.Message, 0)
+	g.dashboards[d.uid()] = d
+
+	return http.StatusOK, map[string]any{
+		"id":        d.id,
+		"uid":       d.uid(),
+		"url":       d.url(),
+		"status":    "success",
+		"version":   version,
+		"slug":      slugify(d.title()),
+		"folderUid": d.folderUID,
+	}
+}
+
+func (g *Grafana) getDashboard(r *http.Request, params map[string]string) (int, any) {
+	d, ok := g.dashboards[params["uid"]]
+	if !ok {
+		return notFound("dashboard")
+	}
+	meta := models.DashboardMeta{
+		FolderUID: d.folderUID,
+		URL:       d.url(),
+		Slug:      slugify(d.title()),
+		Version:   int64(d.model["version"].(float64)),
+		Type:      "db",
+	}
+	if folder, ok := g.folders[d.folderUID]; ok {
+		meta.FolderID = folder.ID
+		meta.FolderTitle = folder.Title
+		meta.FolderURL = folder.URL
+	}
+	return http.StatusOK, map[string]any{"dashboard": d.model, "meta": meta}
+}
+
+func (g *Grafana) listAnnotations(r *http.Request, _ map[string]string) (int, any) {
+	tags := r.URL.Query()["tags"]
+	annotations := []*models.Annotation{}
+	for _, a := range g.annotations {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return !slices.Contains(a.Tags, tag) }) {
+			annotations = append(annotations, a)
+		}
+	}
+	return http.StatusOK, annotations
+}
+
+func (g *Grafana) createAnnotation(r *http.Request, _ map[string]string) (int, any) {
+	var body models.PostAnnotationsCmd
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.Text == nil {
+		return badRequest(fmt.Errorf("text is required"))
+	}
+	a := &models.Annotation{
+		ID:           g.newID(),
+		DashboardUID: body.DashboardUID,
+		PanelID:      body.PanelID,
+		Text:         *body.Text,
+		Tags:         body.Tags,
+		Time:         body.Time,
+		TimeEnd:      body.TimeEnd,
+	}
+	g.annotations = append(g.annotations, a)
+	return http.StatusOK, map[string]any{"id": a.ID, "message": "Annotation added"}
+}
+
+func (g *Grafana) deleteDashboard(r *http.Request, params map[string]string) (int, any) {
+	d, ok := g.dashboards[params["uid"]]
+	if !ok {
+		return notFound("dashboard")
+	}
+	delete(g.dashboards, d.uid())
+	return http.StatusOK, map[string]any{"id": d.id, "title": d.title(), "message": "Dashboard deleted"}
+}
+
+func (g *Grafana) listDashboardVersions(r *http.Request, params map[string]string) (int, any) {
+	d, ok := g.dashboards[params["uid"]]
+	if !ok {
+		return notFound("dashboard")
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	versions := []*models.DashboardVersionMeta{}
+	for i := len(d.versions) - 1; i >= 0 && (limit <= 0 || len(versions) < limit); i-- {
+		version := *d.versions[i]
+		version.Data = nil
+		versions = append(versions, &version)
+	}
+	return http.StatusOK, versions
+}
+
+func (g *Grafana) restoreDashboardVersion(r *http.Request, params map[string]string) (int, any) {
+	var body models.RestoreDashboardVersionCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	d, ok := g.dashboards[params["uid"]]
+	if !ok {
+		return notFound("dashboard")
+	}
+	i := slices.IndexFunc(d.versions, func(v *models.DashboardVersionMeta) bool { return v.Version == body.Version })
+	if i < 0 {
+		return notFound("dashboard version")
+	}
+
+	version := d.model["version"].(float64) + 1
+	d.model = maps.Clone(d.versions[i].Data.(map[string]any))
+	d.model["version"] = version
+	d.addVersion(fmt.Sprintf("Restored from version %d", body.Version), body.Version)
+	return http.StatusOK, map[string]any{
+		"id":        d.id,
+		"uid":       d.uid(),
+		"url":       d.url(),
+		"status":    "success",
+		"version":   version,
+		"title":     d.title(),
+		"folderUid": d.folderUID,
+	}
+}
+
+// addVersion records the current model of the dashboard in its version history.
+func (d *dashboard) addVersion(message string, restoredFrom int64) {
+	version := int64(d.model["version"].(float64))
+	d.versions = append(d.versions, &models.DashboardVersionMeta{
+		ID:            int64(len(d.versions) + 1),
+		DashboardID:   d.id,
+		UID:           d.uid(),
+		Version:       version,
+		ParentVersion: version - 1,
+		RestoredFrom:  restoredFrom,
+		Created:       strfmt.DateTime(time.Now().UTC()),
+		CreatedBy:     "admin",
+		Message:       message,
+		Data:          maps.Clone(d.model),
+	})
+}
+
+// Library panels
+
+func (g *Grafana) listLibraryElements(r *http.Request, _ map[string]string) (int, any) {
+	elements := []*models.LibraryElementDTO{}
+	if firstPage(r) {
+		elements = sortedValues(g.libraryElements)
+	}
+	// The folder filter is a comma separated list of folder IDs
+	if filter := r.URL.Query().Get("folderFilter"); filter != "" {
+		folderIDs := strings.Split(filter, ",")
+		elements = slices.DeleteFunc(elements, func(element *models.LibraryElementDTO) bool {
+			folder := g.folders[element.FolderUID]
+			return folder == nil || !slices.Contains(folderIDs, strconv.FormatInt(folder.ID, 10))
+		})
+	}
+	return http.StatusOK, models.LibraryElementSearchResponse{Result: &models.LibraryElementSearchResult{
+		Elements:   elements,
+		Page:       1,
+		PerPage:    int64(len(g.libraryElements)),
+		TotalCount: int64(len(g.libraryElements)),
+	}}
+}
+
+func (g *Grafana) createLibraryElement(r *http.Request, _ map[string]string) (int, any) {
+	var body models.CreateLibraryElementCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.FolderUID != "" && g.folders[body.FolderUID] == nil {
+		return http.StatusBadRequest, errorBody("folder not found")
+	}
+	if body.UID == "" {
+		body.UID = g.newUID("library-panel")
+	}
+	if _, ok := g.libraryElements[body.UID]; ok {
+		return http.StatusBadRequest, errorBody("library element with that uid already exists")
+	}
+	now := strfmt.DateTime(time.Now().UTC())
+	element := &models.LibraryElementDTO{
+		ID:        g.newID(),
+		UID:       body.UID,
+		Name:      body.Name,
+		Kind:      body.Kind,
+		Model:     body.Model,
+		FolderUID: body.FolderUID,
+		OrgID:     1,
+		Version:   1,
+		Meta:      &models.LibraryElementDTOMeta{Created: now, Updated: now},
+	}
+	g.libraryElements[element.UID] = element
+	return http.StatusOK, g.libraryElementResponse(element)
+}
+
+func (g *Grafana) getLibraryElement(r *http.Request, params map[string]string) (int, any) {
+	element, ok := g.libraryElements[params["uid"]]
+	if !ok {
+		return notFound("library element")
+	}
+	return http.StatusOK, g.libraryElementResponse(element)
+}
+
+func (g *Grafana) updateLibraryElement(r *http.Request, params map[string]string) (int, any) {
+	var body models.PatchLibraryElementCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	existing, ok := g.libraryElements[params["uid"]]
+	if !ok {
+		return notFound("library element")
+	}
+	if body.Version != existing.Version {
+		return http.StatusPreconditionFailed, errorBody("the library element has been changed by someone else")
+	}
+	if body.FolderUID != "" && g.folders[body.FolderUID] == nil {
+		return http.StatusBadRequest, errorBody("folder not found")
+	}
+
+	// The element is replaced rather than modified, responses that are being sent keep the previous version
+	element := *existing
+	element.Name = body.Name
+	element.Kind = body.Kind
+	element.Model = body.Model
+	element.FolderUID = body.FolderUID
+	element.Version++
+	element.Meta = &models.LibraryElementDTOMeta{Created: existing.Meta.Created, Updated: strfmt.DateTime(time.Now().UTC())}
+	g.libraryElements[element.UID] = &element
+	return http.StatusOK, g.libraryElementResponse(&element)
+}
+
+func (g *Grafana) getLibraryElementConnections(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := g.libraryElements[params["uid"]]; !ok {
+		return notFound("library element")
+	}
+	// Dashboards aren't parsed for library panels, so elements are never connected
+	return http.StatusOK, models.LibraryElementConnectionsResponse{Result: []*models.LibraryElementConnectionDTO{}}
+}
+
+// libraryElementResponse returns the API response of a library element, with the fields that Grafana derives from its model and its folder.
+func (g *Grafana) libraryElementResponse(element *models.LibraryElementDTO) models.LibraryElementResponse {
+	result := *element
+	if model, ok := element.Model.(map[string]any); ok {
+		result.Type, _ = model["type"].(string)
+		result.Description, _ = model["description"].(string)
+	}
+	meta := *element.Meta
+	meta.FolderUID = element.FolderUID
+	if folder, ok := g.folders[element.FolderUID]; ok {
+		meta.FolderName = folder.Title
+	}
+	result.Meta = &meta
+	return models.LibraryElementResponse{Result: &result}
+}
+
+func (g *Grafana) deleteLibraryElement(r *http.Request, params map[string]string) (int, any) {
+	element, ok := g.libraryElements[params["uid"]]
+	if !ok {
+		return notFound("library element")
+	}
+	delete(g.libraryElements, element.UID)
+	return http.StatusOK, map[string]any{"id": element.ID, "message": "Library element deleted"}
+}
+
+func (d *dashboard) uid() string {
+	uid, _ := d.model["uid"].(string)
+	return uid
+}
+
+func (d *dashboard) title() string {
+	title, _ := d.model["title"].(string)
+	return title
+}
+
+func (d *dashboard) url() string {
+	return "/d/" + d.uid() + "/" + slugify(d.title())
+}
+
+func (d *dashboard) tags() []string {
+	tags := []string{}
+	if list, ok := d.model["tags"].([]any); ok {
+		for _, tag := range list {
+			if s, ok := tag.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+	}
+	return tags
+}
+
+// Data sources
+
+func (g *Grafana) listDataSources(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(g.dataSources)
+}
+
+func (g *Grafana) createDataSource(r *http.Request, _ map[string]string) (int, any) {
+	var body models.AddDataSourceCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	for _, ds := range g.dataSources {
+		if ds.Name == body.Name {
+			return http.StatusConflict, errorBody("data source with the same name already exists")
+		}
+	}
+	if body.UID == "" {
+		body.UID = g.newUID("datasource")
+	}
+	if _, ok := g.dataSources[body.UID]; ok {
+		return http.StatusConflict, errorBody("data source with the same uid already exists")
+	}
+
+	ds := &models.DataSource{ID: g.newID(), UID: body.UID, OrgID: 1, Version: 1}
+	setDataSource(ds, models.UpdateDataSourceCommand{
+		Name: body.Name, Type: body.Type, Access: body.Access, URL: body.URL, User: body.User, Database: body.Database,
+		BasicAuth: body.BasicAuth, BasicAuthUser: body.BasicAuthUser, WithCredentials: body.WithCredentials,
+		IsDefault: body.IsDefault, JSONData: body.JSONData, SecureJSONData: body.SecureJSONData,
+	})
+	g.dataSources[ds.UID] = ds
+	return http.StatusOK, map[string]any{"datasource": ds, "id": ds.ID, "name": ds.Name, "message": "Datasource added"}
+}
+
+func (g *Grafana) getDataSourceByUID(r *http.Request, params map[string]string) (int, any) {
+	ds, ok := g.dataSources[params["uid"]]
+	if !ok {
+		return notFound("data source")
+	}
+	return http.StatusOK, ds
+}
+
+func (g *Grafana) getDataSourceByName(r *http.Request, params map[string]string) (int, any) {
+	for _, ds := range g.dataSources {
+		if ds.Name == params["name"] {
+			return http.StatusOK, ds
+		}
+	}
+	return notFound("data source")
+}
+
+func (g *Grafana) getDataSourceByID(r *http.Request, params map[string]string) (int, any) {
+	for _, ds := range g.dataSources {
+		if strconv.FormatInt(ds.ID, 10) == params["id"] {
+			return http.StatusOK, ds
+		}
+	}
+	return notFound("data source")
+}
+
+func (g *Grafana) updateDataSource(r *http.Request, params map[string]string) (int, any) {
+	ds, ok := g.dataSources[params["uid"]]
+	if !ok {
+		return notFound("data source")
+	}
+	var body models.UpdateDataSourceCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	setDataSource(ds, body)
+	ds.Version++
+	return http.StatusOK, map[string]any{"datasource": ds, "id": ds.ID, "name": ds.Name, "message": "Datasource updated"}
+}
+
+// checkDataSourceHealth reports that the data sources are healthy, unless their URL contains "unhealthy".
+func (g *Grafana) checkDataSourceHealth(r *http.Request, params map[string]string) (int, any) {
+	ds, ok := g.dataSources[params["uid"]]
+	if !ok {
+		return notFound("data source")
+	}
+	if strings.Contains(ds.URL, "unhealthy") {
+		return http.StatusBadRequest, map[string]any{"status": "ERROR", "message": "Post \"" + ds.URL + "\": connection refused"}
+	}
+	return http.StatusOK, map[string]any{"status": "OK", "message": "Data source is working"}
+}
+
+func (g *Grafana) deleteDataSource(r *http.Request, params map[string]string) (int, any) {
+	ds, ok := g.dataSources[params["uid"]]
+	if !ok {
+		return notFound("data source")
+	}
+	delete(g.dataSources, ds.UID)
+	return http.StatusOK, map[string]any{"id": ds.ID, "message": "Data source deleted"}
+}
+
+func setDataSource(ds *models.DataSource, body models.UpdateDataSourceCommand) {
+	ds.Name = body.Name
+	ds.Type = body.Type
+	ds.Access = body.Access
+	ds.URL = body.URL
+	ds.User = body.User
+	ds.Database = body.Database
+	ds.BasicAuth = body.BasicAuth
+	ds.BasicAuthUser = body.BasicAuthUser
+	ds.WithCredentials = body.WithCredentials
+	ds.IsDefault = body.IsDefault
+	ds.JSONData = body.JSONData
+	// Secure fields are write-only, only their presence is returned
+	ds.SecureJSONFields = map[string]bool{}
+	for k := range body.SecureJSONData {
+		ds.SecureJSONFields[k] = true
+	}
+}
+
+// Teams
+
+func (g *Grafana) searchTeams(r *http.Request, _ map[string]string) (int, any) {
+	query := r.URL.Query()
+	teams := []*models.TeamDTO{}
+	if firstPage(r) {
+		for _, t := range sortedValues(g.teams) {
+			if name := query.Get("name"); name != "" && t.Name != name {
+				continue
+			}
+			if q := query.Get("query"); q != "" && !strings.Contains(strings.ToLower(t.Name), strings.ToLower(q)) {
+				continue
+			}
+			teams = append(teams, &t.TeamDTO)
+		}
+	}
+	return http.StatusOK, models.SearchTeamQueryResult{Teams: teams, TotalCount: int64(len(teams)), Page: 1, PerPage: 1000}
+}
+
+func (g *Grafana) createTeam(r *http.Request, _ map[string]string) (int, any) {
+	var body models.CreateTeamCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	for _, t := range g.teams {
+		if t.Name == body.Name {
+			return http.StatusConflict, errorBody("Team name taken")
+		}
+	}
+	t := &team{TeamDTO: models.TeamDTO{ID: g.newID(), Name: body.Name, Email: body.Email, OrgID: 1}, members: map[int64]bool{}}
+	t.UID = g.newUID("team")
+	g.teams[t.ID] = t
+	return http.StatusOK, map[string]any{"teamId": t.ID, "uid": t.UID, "message": "Team created"}
+}
+
+func (g *Grafana) team(params map[string]string) (*team, bool) {
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	t, ok := g.teams[id]
+	return t, ok
+}
+
+func (g *Grafana) getTeam(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	return http.StatusOK, t.TeamDTO
+}
+
+func (g *Grafana) updateTeam(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	var body models.UpdateTeamCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	t.Name = body.Name
+	t.Email = body.Email
+	return http.StatusOK, errorBody("Team updated")
+}
+
+func (g *Grafana) deleteTeam(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	delete(g.teams, t.ID)
+	return http.StatusOK, errorBody("Team deleted")
+}
+
+func (g *Grafana) getTeamMembers(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	members := []*models.TeamMemberDTO{}
+	for _, u := range sortedValues(g.users) {
+		if t.members[u.ID] {
+			members = append(members, &models.TeamMemberDTO{UserID: u.ID, TeamID: t.ID, OrgID: 1, Login: u.Login, Email: u.Email, Name: u.Name})
+		}
+	}
+	return http.StatusOK, members
+}
+
+func (g *Grafana) addTeamMember(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	var body models.AddTeamMemberCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if _, ok := g.orgRoles[body.UserID]; !ok {
+		return notFound("user")
+	}
+	if t.members[body.UserID] {
+		return http.StatusBadRequest, errorBody("User is already added to this team")
+	}
+	t.members[body.UserID] = true
+	return http.StatusOK, errorBody("Member added to Team")
+}
+
+func (g *Grafana) removeTeamMember(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	id, _ := strconv.ParseInt(params["userId"], 10, 64)
+	if !t.members[id] {
+		return notFound("team member")
+	}
+	delete(t.members, id)
+	return http.StatusOK, errorBody("Team member removed")
+}
+
+func (g *Grafana) getTeamGroups(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := g.team(params); !ok {
+		return notFound("team")
+	}
+	return http.StatusOK, []*models.TeamGroupDTO{}
+}
+
+func (g *Grafana) getTeamPreferences(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	return http.StatusOK, t.preferences
+}
+
+func (g *Grafana) updateTeamPreferences(r *http.Request, params map[string]string) (int, any) {
+	t, ok := g.team(params)
+	if !ok {
+		return notFound("team")
+	}
+	var body models.UpdatePrefsCmd
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	t.preferences = preferences(body)
+	return http.StatusOK, errorBody("Preferences updated")
+}
+
+// Service accounts
+
+func (g *Grafana) searchServiceAccounts(r *http.Request, _ map[string]string) (int, any) {
+	serviceAccounts := []*models.ServiceAccountDTO{}
+	if firstPage(r) {
+		for _, sa := range sortedValues(g.serviceAccounts) {
+			if q := r.URL.Query().Get("query"); q != "" && !strings.Contains(strings.ToLower(sa.Name), strings.ToLower(q)) {
+				continue
+			}
+			serviceAccounts = append(serviceAccounts, sa)
+		}
+	}
+	return http.StatusOK, models.SearchOrgServiceAccountsResult{ServiceAccounts: serviceAccounts, TotalCount: int64(len(serviceAccounts)), Page: 1, PerPage: 1000}
+}
+
+func (g *Grafana) createServiceAccount(r *http.Request, _ map[string]string) (int, any) {
+	var body models.CreateServiceAccountForm
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	for _, sa := range g.serviceAccounts {
+		if sa.Name == body.Name {
+			return http.StatusBadRequest, errorBody("service account already exists")
+		}
+	}
+	sa := &models.ServiceAccountDTO{ID: g.newID(), Name: body.Name, Login: "sa-" + body.Name, Role: body.Role, IsDisabled: body.IsDisabled, OrgID: 1}
+	g.serviceAccounts[sa.ID] = sa
+	return http.StatusCreated, sa
+}
+
+func (g *Grafana) deleteServiceAccount(r *http.Request, params map[string]string) (int, any) {
+	id, _ := strconv.ParseInt(params["id"], 10, 64)
+	if _, ok := g.serviceAccounts[id]; !ok {
+		return notFound("service account")
+	}
+	delete(g.serviceAccounts, id)
+	delete(g.serviceAccountTokens, id)
+	return http.StatusOK, errorBody("Service account deleted")
+}
+
+// Service account tokens
+
+// AgeServiceAccountTokens moves the creation date of all the service account tokens back by the given duration, ex: to test their rotation.
+func (g *Grafana) AgeServiceAccountTokens(age time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, tokens := range g.serviceAccountTokens {
+		for _, token := range tokens {
+			token.Created = strfmt.DateTime(time.Time(token.Created).Add(-age))
+		}
+	}
+}
+
+func (g *Grafana) listServiceAccountTokens(r *http.Request, params map[string]string) (int, any) {
+	id, _ := strconv.ParseInt(params["id"], 10, 64)
+	tokens := sortedValues(g.serviceAccountTokens[id])
+	for _, token := range tokens {
+		token.HasExpired = !time.Time(token.Expiration).IsZero() && time.Time(token.Expiration).Before(time.Now())
+	}
+	return http.StatusOK, tokens
+}
+
+func (g *Grafana) createServiceAccountToken(r *http.Request, params map[string]string) (int, any) {
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		return badRequest(err)
+	}
+	var body models.AddServiceAccountTokenCommand
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if g.serviceAccountTokens[id] == nil {
+		g.serviceAccountTokens[id] = map[int64]*models.TokenDTO{}
+	}
+	for _, token := range g.serviceAccountTokens[id] {
+		if token.Name == body.Name {
+			return http.StatusConflict, errorBody("service account token with given name already exists in the organization")
+		}
+	}
+	token := &models.TokenDTO{ID: g.newID(), Name: body.Name, Created: strfmt.DateTime(time.Now())}
+	if body.SecondsToLive > 0 {
+		token.Expiration = strfmt.DateTime(time.Now().Add(time.Duration(body.SecondsToLive) * time.Second))
+	}
+	g.serviceAccountTokens[id][token.ID] = token
+	return http.StatusOK, models.NewAPIKeyResult{ID: token.ID, Name: token.Name, Key: fmt.Sprintf("glsa_%s", g.newUID("key"))}
+}
+
+func (g *Grafana) deleteServiceAccountToken(r *http.Request, params map[string]string) (int, any) {
+	id, _ := strconv.ParseInt(params["id"], 10, 64)
+	tokenID, _ := strconv.ParseInt(params["tokenId"], 10, 64)
+	if _, ok := g.serviceAccountTokens[id][tokenID]; !ok {
+		return notFound("service account token")
+	}
+	delete(g.serviceAccountTokens[id], tokenID)
+	return http.StatusOK, errorBody("Service account token deleted")
+}
+
+// firstPage returns true if the first page of results is requested. Pages start at 1, but 0 is also the first page.
+// The fake servers return all results in the first page, and no results in the next ones.
+func firstPage(r *http.Request) bool {
+	page := r.URL.Query().Get("page")
+	return page == "" || page == "0" || page == "1"
+}
+
+// slugify returns the slug used by Grafana in URLs, ex: `My Dashboard` -> `my-dashboard`.
+func slugify(title string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, title), "-")
+}
+
+// sortedValues returns the values of the map sorted by key, so that the responses are deterministic.
+func sortedValues[K int64 | string, V any](m map[K]V) []V {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	values := make([]V, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
@@ -0,0 +1,472 @@
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"sort"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+const provenanceAPI = "api"
+
+// redactedSetting replaces the secure settings of contact points in the responses of the provisioning API
+const redactedSetting = "[REDACTED]"
+
+// secureContactPointSettings are the secure settings of some integration types, which are redacted like in Grafana
+var secureContactPointSettings = map[string][]string{
+	"discord":   {"url"},
+	"pagerduty": {"integration_key"},
+	"slack":     {"url", "token"},
+	"webhook":   {"password", "authorization_credentials"},
+}
+
+// alerting is the state of the alerting provisioning API of the fake Grafana server.
+type alerting struct {
+	contactPoints map[string]*models.EmbeddedContactPoint
+	templates     map[string]*models.NotificationTemplate
+	muteTimings   map[string]*models.MuteTimeInterval
+	policy        *models.Route
+	rules         map[string]*models.ProvisionedAlertRule
+	ruleIntervals map[string]int64
+}
+
+func newAlerting() alerting {
+	return alerting{
+		contactPoints: map[string]*models.EmbeddedContactPoint{},
+		templates:     map[string]*models.NotificationTemplate{},
+		muteTimings:   map[string]*models.MuteTimeInterval{},
+		policy:        defaultPolicy(),
+		rules:         map[string]*models.ProvisionedAlertRule{},
+		ruleIntervals: map[string]int64{},
+	}
+}
+
+func defaultPolicy() *models.Route {
+	return &models.Route{
+		Receiver: "grafana-default-email",
+		GroupBy:  []string{"grafana_folder", "alertname"},
+	}
+}
+
+func (g *Grafana) handleAlerting() {
+	g.handle("GET", "/api/v1/provisioning/contact-points", g.listContactPoints)
+	g.handle("POST", "/api/v1/provisioning/contact-points", g.createContactPoint)
+	g.handle("PUT", "/api/v1/provisioning/contact-points/{uid}", g.updateContactPoint)
+	g.handle("DELETE", "/api/v1/provisioning/contact-points/{uid}", g.deleteContactPoint)
+	g.handle("POST", "/api/alertmanager/grafana/config/api/v1/receivers/test", g.testReceivers)
+
+	g.handle("GET", "/api/v1/provisioning/templates", g.listTemplates)
+	g.handle("GET", "/api/v1/provisioning/templates/{name}", g.getTemplate)
+	g.handle("PUT", "/api/v1/provisioning/templates/{name}", g.putTemplate)
+	g.handle("DELETE", "/api/v1/provisioning/templates/{name}", g.deleteTemplate)
+
+	g.handle("GET", "/api/v1/provisioning/mute-timings", g.listMuteTimings)
+	g.handle("POST", "/api/v1/provisioning/mute-timings", g.createMuteTiming)
+	g.handle("GET", "/api/v1/provisioning/mute-timings/{name}", g.getMuteTiming)
+	g.handle("PUT", "/api/v1/provisioning/mute-timings/{name}", g.updateMuteTiming)
+	g.handle("DELETE", "/api/v1/provisioning/mute-timings/{name}", g.deleteMuteTiming)
+
+	g.handle("GET", "/api/v1/provisioning/policies", g.getPolicy)
+	g.handle("PUT", "/api/v1/provisioning/policies", g.putPolicy)
+	g.handle("DELETE", "/api/v1/provisioning/policies", g.resetPolicy)
+
+	g.handle("GET", "/api/v1/provisioning/alert-rules", g.listRules)
+	g.handle("GET", "/api/v1/provisioning/alert-rules/export", g.exportRules)
+	g.handle("GET", "/api/v1/provisioning/alert-rules/{uid}", g.getRule)
+	g.handle("PUT", "/api/v1/provisioning/alert-rules/{uid}", g.updateRule)
+	g.handle("DELETE", "/api/v1/provisioning/alert-rules/{uid}", g.deleteRule)
+	g.handle("GET", "/api/v1/provisioning/folder/{folderUID}/rule-groups/{group}", g.getRuleGroup)
+	g.handle("PUT", "/api/v1/provisioning/folder/{folderUID}/rule-groups/{group}", g.putRuleGroup)
+	g.handle("DELETE", "/api/v1/provisioning/folder/{folderUID}/rule-groups/{group}", g.deleteRuleGroup)
+}
+
+// provenance returns the provenance of provisioned resources, which can be disabled with a header.
+func provenance(r *http.Request) string {
+	if r.Header.Get("X-Disable-Provenance") != "" {
+		return ""
+	}
+	return provenanceAPI
+}
+
+// Contact points
+
+func (g *Grafana) listContactPoints(r *http.Request, _ map[string]string) (int, any) {
+	name := r.URL.Query().Get("name")
+	contactPoints := []*models.EmbeddedContactPoint{}
+	for _, cp := range sortedValues(g.contactPoints) {
+		if name == "" || cp.Name == name {
+			contactPoints = append(contactPoints, redactContactPoint(cp))
+		}
+	}
+	return http.StatusOK, contactPoints
+}
+
+// redactContactPoint returns a copy of the contact point where the secure settings are redacted.
+func redactContactPoint(cp *models.EmbeddedContactPoint) *models.EmbeddedContactPoint {
+	settings, ok := cp.Settings.(map[string]any)
+	if !ok || cp.Type == nil {
+		return cp
+	}
+	redacted := *cp
+	redactedSettings := maps.Clone(settings)
+	for _, key := range secureContactPointSettings[*cp.Type] {
+		if _, ok := redactedSettings[key]; ok {
+			redactedSettings[key] = redactedSetting
+		}
+	}
+	redacted.Settings = redactedSettings
+	return &redacted
+}
+
+// mergeSecureSettings sets the missing secure settings from the stored settings, like Grafana does on updates and tests.
+func mergeSecureSettings(integrationType string, settings map[string]any, stored any) {
+	storedSettings, _ := stored.(map[string]any)
+	for _, key := range secureContactPointSettings[integrationType] {
+		if _, ok := settings[key]; !ok && storedSettings[key] != nil {
+			settings[key] = storedSettings[key]
+		}
+	}
+}
+
+func (g *Grafana) createContactPoint(r *http.Request, _ map[string]string) (int, any) {
+	var body models.EmbeddedContactPoint
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.UID == "" {
+		body.UID = g.newUID("contactpoint")
+	}
+	if _, ok := g.contactPoints[body.UID]; ok {
+		return http.StatusBadRequest, errorBody("a contact point with the same uid already exists")
+	}
+	body.Provenance = provenance(r)
+	g.contactPoints[body.UID] = &body
+	return http.StatusAccepted, body
+}
+
+func (g *Grafana) updateContactPoint(r *http.Request, params map[string]string) (int, any) {
+	existing, ok := g.contactPoints[params["uid"]]
+	if !ok {
+		return notFound("contact point")
+	}
+	var body models.EmbeddedContactPoint
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if settings, ok := body.Settings.(map[string]any); ok && body.Type != nil {
+		// Redacted values keep the stored secure settings
+		for k, v := range settings {
+			if v == redactedSetting {
+				delete(settings, k)
+			}
+		}
+		mergeSecureSettings(*body.Type, settings, existing.Settings)
+	}
+	body.UID = params["uid"]
+	body.Provenance = provenance(r)
+	g.contactPoints[body.UID] = &body
+	return http.StatusAccepted, errorBody("contactpoint updated")
+}
+
+func (g *Grafana) deleteContactPoint(r *http.Request, params map[string]string) (int, any) {
+	delete(g.contactPoints, params["uid"])
+	return http.StatusAccepted, errorBody("contactpoint deleted")
+}
+
+// testReceivers pretends to send test notifications. Integrations of existing contact points succeed, others fail.
+// Like in Grafana, the secure settings that aren't sent are taken from the stored integration with the same UID.
+// Integrations that still have redacted settings fail, since the notification would be sent with the redacted value.
+func (g *Grafana) testReceivers(r *http.Request, _ map[string]string) (int, any) {
+	type integration struct {
+		UID      string         `json:"uid"`
+		Name     string         `json:"name"`
+		Type     string         `json:"type"`
+		Settings map[string]any `json:"settings,omitempty"`
+		Status   string         `json:"status"`
+		Error    string         `json:"error,omitempty"`
+	}
+	var body struct {
+		Receivers []struct {
+			Name         string        `json:"name"`
+			Integrations []integration `json:"grafana_managed_receiver_configs"`
+		} `json:"receivers"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	status := http.StatusOK
+	for _, receiver := range body.Receivers {
+		for i, integration := range receiver.Integrations {
+			stored, ok := g.contactPoints[integration.UID]
+			if !ok {
+				receiver.Integrations[i].Status = "failed"
+				receiver.Integrations[i].Error = "integration not found"
+				status = http.StatusMultiStatus
+				continue
+			}
+			if integration.Settings == nil {
+				integration.Settings = map[string]any{}
+			}
+			mergeSecureSettings(integration.Type, integration.Settings, stored.Settings)
+			if hasRedactedSetting(integration.Settings) {
+				receiver.Integrations[i].Status = "failed"
+				receiver.Integrations[i].Error = "the notification was sent with a redacted secure setting"
+				status = http.StatusMultiStatus
+				continue
+			}
+			receiver.Integrations[i].Status = "ok"
+			// Secure settings aren't returned
+			receiver.Integrations[i].Settings = nil
+		}
+	}
+	return status, body
+}
+
+func hasRedactedSetting(settings map[string]any) bool {
+	for _, v := range settings {
+		if v == redactedSetting {
+			return true
+		}
+	}
+	return false
+}
+
+// Templates
+
+func (g *Grafana) listTemplates(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(g.templates)
+}
+
+func (g *Grafana) getTemplate(r *http.Request, params map[string]string) (int, any) {
+	template, ok := g.templates[params["name"]]
+	if !ok {
+		return notFound("template")
+	}
+	return http.StatusOK, template
+}
+
+func (g *Grafana) putTemplate(r *http.Request, params map[string]string) (int, any) {
+	var body models.NotificationTemplateContent
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	template := &models.NotificationTemplate{Name: params["name"], Template: body.Template, Provenance: models.Provenance(provenance(r))}
+	g.templates[template.Name] = template
+	return http.StatusAccepted, template
+}
+
+func (g *Grafana) deleteTemplate(r *http.Request, params map[string]string) (int, any) {
+	delete(g.templates, params["name"])
+	return http.StatusNoContent, nil
+}
+
+// Mute timings
+
+func (g *Grafana) listMuteTimings(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(g.muteTimings)
+}
+
+func (g *Grafana) getMuteTiming(r *http.Request, params map[string]string) (int, any) {
+	muteTiming, ok := g.muteTimings[params["name"]]
+	if !ok {
+		return notFound("mute timing")
+	}
+	return http.StatusOK, muteTiming
+}
+
+func (g *Grafana) createMuteTiming(r *http.Request, _ map[string]string) (int, any) {
+	var body models.MuteTimeInterval
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if _, ok := g.muteTimings[body.Name]; ok {
+		return http.StatusBadRequest, errorBody("a mute timing with the same name already exists")
+	}
+	g.muteTimings[body.Name] = &body
+	return http.StatusCreated, body
+}
+
+func (g *Grafana) updateMuteTiming(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := g.muteTimings[params["name"]]; !ok {
+		return notFound("mute timing")
+	}
+	var body models.MuteTimeInterval
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	body.Name = params["name"]
+	g.muteTimings[body.Name] = &body
+	return http.StatusAccepted, body
+}
+
+func (g *Grafana) deleteMuteTiming(r *http.Request, params map[string]string) (int, any) {
+	delete(g.muteTimings, params["name"])
+	return http.StatusNoContent, nil
+}
+
+// Notification policies
+
+func (g *Grafana) getPolicy(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, g.policy
+}
+
+func (g *Grafana) putPolicy(r *http.Request, _ map[string]string) (int, any) {
+	var body models.Route
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	body.Provenance = models.Provenance(provenance(r))
+	g.policy = &body
+	return http.StatusAccepted, errorBody("policies updated")
+}
+
+func (g *Grafana) resetPolicy(r *http.Request, _ map[string]string) (int, any) {
+	g.policy = defaultPolicy()
+	return http.StatusAccepted, errorBody("policies resetted")
+}
+
+// Alert rules
+
+func (g *Grafana) listRules(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(g.rules)
+}
+
+// exportRules only supports the JSON export of the rules of a folder, the only one used by the provider.
+// Like Grafana, it returns a 404 if the folder has no rules.
+func (g *Grafana) exportRules(r *http.Request, _ map[string]string) (int, any) {
+	folderUID := r.URL.Query().Get("folderUid")
+	export := models.AlertingFileExport{APIVersion: 1}
+	groups := map[string]*models.AlertRuleGroupExport{}
+	for _, rule := range sortedValues(g.rules) {
+		if folderUID != "" && *rule.FolderUID != folderUID {
+			continue
+		}
+		key := *rule.FolderUID + "/" + *rule.RuleGroup
+		group, ok := groups[key]
+		if !ok {
+			group = &models.AlertRuleGroupExport{Name: *rule.RuleGroup, OrgID: 1}
+			if folder := g.folders[*rule.FolderUID]; folder != nil {
+				group.Folder = folder.Title
+			}
+			groups[key] = group
+			export.Groups = append(export.Groups, group)
+		}
+		group.Rules = append(group.Rules, &models.AlertRuleExport{UID: rule.UID, Title: *rule.Title})
+	}
+	if len(export.Groups) == 0 {
+		return notFound("alert rules")
+	}
+	return http.StatusOK, export
+}
+
+func (g *Grafana) getRule(r *http.Request, params map[string]string) (int, any) {
+	rule, ok := g.rules[params["uid"]]
+	if !ok {
+		return notFound("alert rule")
+	}
+	return http.StatusOK, rule
+}
+
+func (g *Grafana) updateRule(r *http.Request, params map[string]string) (int, any) {
+	existing, ok := g.rules[params["uid"]]
+	if !ok {
+		return notFound("alert rule")
+	}
+	var body models.ProvisionedAlertRule
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	body.UID = existing.UID
+	body.ID = existing.ID
+	body.OrgID = existing.OrgID
+	body.Provenance = models.Provenance(provenance(r))
+	g.rules[body.UID] = &body
+	return http.StatusOK, body
+}
+
+func (g *Grafana) deleteRule(r *http.Request, params map[string]string) (int, any) {
+	delete(g.rules, params["uid"])
+	return http.StatusNoContent, nil
+}
+
+func (g *Grafana) getRuleGroup(r *http.Request, params map[string]string) (int, any) {
+	group := g.ruleGroup(params["folderUID"], params["group"])
+	if len(group.Rules) == 0 {
+		return notFound("rule group")
+	}
+	return http.StatusOK, group
+}
+
+func (g *Grafana) putRuleGroup(r *http.Request, params map[string]string) (int, any) {
+	folderUID, title := params["folderUID"], params["group"]
+	if g.folders[folderUID] == nil {
+		return http.StatusBadRequest, errorBody("folder does not exist")
+	}
+	var body models.AlertRuleGroup
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+
+	// The rules of the group are replaced by the given ones
+	g.deleteRules(func(rule *models.ProvisionedAlertRule) bool {
+		return *rule.FolderUID == folderUID && *rule.RuleGroup == title
+	})
+	orgID := int64(1)
+	for _, rule := range body.Rules {
+		if rule.UID == "" {
+			rule.UID = g.newUID("rule")
+		}
+		rule.ID = g.newID()
+		rule.OrgID = &orgID
+		rule.FolderUID = &folderUID
+		rule.RuleGroup = &title
+		rule.Provenance = models.Provenance(provenance(r))
+		g.rules[rule.UID] = rule
+	}
+	g.ruleIntervals[folderUID+"/"+title] = body.Interval
+
+	return http.StatusOK, g.ruleGroup(folderUID, title)
+}
+
+func (g *Grafana) deleteRuleGroup(r *http.Request, params map[string]string) (int, any) {
+	folderUID, title := params["folderUID"], params["group"]
+	if len(g.ruleGroup(folderUID, title).Rules) == 0 {
+		return notFound("rule group")
+	}
+	g.deleteRules(func(rule *models.ProvisionedAlertRule) bool {
+		return *rule.FolderUID == folderUID && *rule.RuleGroup == title
+	})
+	return http.StatusNoContent, nil
+}
+
+func (g *Grafana) ruleGroup(folderUID, title string) *models.AlertRuleGroup {
+	group := &models.AlertRuleGroup{
+		FolderUID: folderUID,
+		Title:     title,
+		Interval:  g.ruleIntervals[folderUID+"/"+title],
+		Rules:     []*models.ProvisionedAlertRule{},
+	}
+	for _, rule := range g.rules {
+		if *rule.FolderUID == folderUID && *rule.RuleGroup == title {
+			group.Rules = append(group.Rules, rule)
+		}
+	}
+	sort.Slice(group.Rules, func(i, j int) bool { return group.Rules[i].ID < group.Rules[j].ID })
+	return group
+}
+
+func (g *Grafana) deleteFolderRules(folderUID string) {
+	g.deleteRules(func(rule *models.ProvisionedAlertRule) bool {
+		return *rule.FolderUID == folderUID
+	})
+}
+
+func (g *Grafana) deleteRules(match func(*models.ProvisionedAlertRule) bool) {
+	for uid, rule := range g.rules {
+		if match(rule) {
+			delete(g.rules, uid)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaFolders(t *testing.T) {
+	g := NewGrafana(t)
+
+	status, _ := request(t, g, "POST", "/api/folders", models.CreateFolderCommand{UID: "parent", Title: "Parent"})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "POST", "/api/folders", models.CreateFolderCommand{UID: "parent", Title: "Other"})
+	require.Equal(t, http.StatusConflict, status)
+	status, _ = request(t, g, "POST", "/api/folders", models.CreateFolderCommand{UID: "child", Title: "Child", ParentUID: "missing"})
+	require.Equal(t, http.StatusNotFound, status)
+	status, _ = request(t, g, "POST", "/api/folders", models.CreateFolderCommand{UID: "child", Title: "Child", ParentUID: "parent"})
+	require.Equal(t, http.StatusOK, status)
+
+	// A folder can't be moved into one of its subfolders
+	status, _ = request(t, g, "POST", "/api/folders/parent/move", models.MoveFolderCommand{ParentUID: "child"})
+	require.Equal(t, http.StatusConflict, status)
+	status, _ = request(t, g, "POST", "/api/folders/child/move", models.MoveFolderCommand{ParentUID: "missing"})
+	require.Equal(t, http.StatusBadRequest, status)
+	status, body := request(t, g, "POST", "/api/folders/child/move", models.MoveFolderCommand{})
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, body.(map[string]any)["parentUid"])
+
+	// Library panels block the deletion of their folder, the other contents are deleted with it
+	status, _ = request(t, g, "POST", "/api/folders/child/move", models.MoveFolderCommand{ParentUID: "parent"})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "POST", "/api/dashboards/db", models.SaveDashboardCommand{Dashboard: map[string]any{"uid": "dashboard", "title": "Dashboard"}, FolderUID: "child"})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "POST", "/api/library-elements", models.CreateLibraryElementCommand{UID: "panel", Name: "Panel", Kind: 1, FolderUID: "child", Model: map[string]any{}})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "DELETE", "/api/folders/parent", nil)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = request(t, g, "DELETE", "/api/library-elements/panel", nil)
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "DELETE", "/api/folders/parent", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, g.folders)
+	require.Empty(t, g.dashboards)
+}
+
+func TestGrafanaLibraryElementVersions(t *testing.T) {
+	g := NewGrafana(t)
+
+	status, _ := request(t, g, "POST", "/api/library-elements", models.CreateLibraryElementCommand{UID: "panel", Name: "Panel", Kind: 1, Model: map[string]any{}})
+	require.Equal(t, http.StatusOK, status)
+
+	update := models.PatchLibraryElementCommand{Name: "Renamed", Kind: 1, Model: map[string]any{}, Version: 1}
+	status, _ = request(t, g, "PATCH", "/api/library-elements/panel", update)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, int64(2), g.libraryElements["panel"].Version)
+
+	// Updates of a previous version are rejected
+	status, _ = request(t, g, "PATCH", "/api/library-elements/panel", update)
+	require.Equal(t, http.StatusPreconditionFailed, status)
+}
+
+func TestGrafanaServiceAccounts(t *testing.T) {
+	g := NewGrafana(t)
+
+	status, body := request(t, g, "POST", "/api/serviceaccounts", models.CreateServiceAccountForm{Name: "sa", Role: "Admin"})
+	require.Equal(t, http.StatusCreated, status)
+	id := int64(body.(map[string]any)["id"].(float64))
+	status, _ = request(t, g, "POST", "/api/serviceaccounts", models.CreateServiceAccountForm{Name: "sa", Role: "Viewer"})
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status, body = request(t, g, "GET", "/api/serviceaccounts/search?query=SA", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, float64(1), body.(map[string]any)["totalCount"])
+
+	tokensPath := "/api/serviceaccounts/" + strconv.FormatInt(id, 10) + "/tokens"
+	status, _ = request(t, g, "POST", tokensPath, models.AddServiceAccountTokenCommand{Name: "token", SecondsToLive: 60})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = request(t, g, "POST", tokensPath, models.AddServiceAccountTokenCommand{Name: "token"})
+	require.Equal(t, http.StatusConflict, status)
+
+	// Tokens are listed as expired once their expiration has passed
+	_, body = request(t, g, "GET", tokensPath, nil)
+	require.Empty(t, body.([]any)[0].(map[string]any)["hasExpired"])
+	for _, token := range g.serviceAccountTokens[id] {
+		token.Expiration = strfmt.DateTime(time.Now().Add(-time.Second))
+	}
+	_, body = request(t, g, "GET", tokensPath, nil)
+	require.Equal(t, true, body.([]any)[0].(map[string]any)["hasExpired"])
+
+	// The tokens are deleted with their service account
+	status, _ = request(t, g, "DELETE", "/api/serviceaccounts/"+strconv.FormatInt(id, 10), nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, g.serviceAccountTokens)
+}
@@ -0,0 +1,109 @@
+package fake
+
+import (
+	"net/http"
+	"testing"
+
+	onCallAPI "github.com/grafana/amixr-api-go-client"
+)
+
+// OnCall is a fake Grafana OnCall API server. It implements the integration API.
+type OnCall struct {
+	server
+
+	integrations map[string]*onCallAPI.Integration
+}
+
+// NewOnCall starts a fake Grafana OnCall API server, which is stopped at the end of the test.
+func NewOnCall(t testing.TB) *OnCall {
+	o := &OnCall{
+		integrations: map[string]*onCallAPI.Integration{},
+	}
+
+	o.handle("GET", "/api/v1/integrations", o.listIntegrations)
+	o.handle("POST", "/api/v1/integrations", o.createIntegration)
+	o.handle("GET", "/api/v1/integrations/{id}", o.getIntegration)
+	o.handle("PUT", "/api/v1/integrations/{id}", o.updateIntegration)
+	o.handle("DELETE", "/api/v1/integrations/{id}", o.deleteIntegration)
+
+	o.start(t)
+	return o
+}
+
+func (o *OnCall) providerConfig() map[string]string {
+	return map[string]string{
+		"oncall_url":          o.URL(),
+		"oncall_access_token": "fake-token",
+	}
+}
+
+// paginated returns a single page of results, in the format of the OnCall API.
+func paginated[T any](results []T) map[string]any {
+	return map[string]any{"count": len(results), "next": nil, "previous": nil, "results": results}
+}
+
+func (o *OnCall) listIntegrations(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, paginated(sortedValues(o.integrations))
+}
+
+func (o *OnCall) getIntegration(r *http.Request, params map[string]string) (int, any) {
+	integration, ok := o.integrations[params["id"]]
+	if !ok {
+		return http.StatusNotFound, map[string]any{"detail": "Not found."}
+	}
+	return http.StatusOK, integration
+}
+
+func (o *OnCall) createIntegration(r *http.Request, _ map[string]string) (int, any) {
+	var body onCallAPI.CreateIntegrationOptions
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	id := o.newUID("C")
+	integration := &onCallAPI.Integration{
+		ID:           id,
+		TeamId:       body.TeamId,
+		Name:         body.Name,
+		Type:         body.Type,
+		Link:         o.URL() + "/integrations/v1/" + body.Type + "/" + id + "/",
+		Templates:    body.Templates,
+		DefaultRoute: body.DefaultRoute,
+	}
+	if integration.DefaultRoute == nil {
+		integration.DefaultRoute = &onCallAPI.DefaultRoute{}
+	}
+	integration.DefaultRoute.ID = o.newUID("R")
+	o.integrations[id] = integration
+	return http.StatusCreated, integration
+}
+
+func (o *OnCall) updateIntegration(r *http.Request, params map[string]string) (int, any) {
+	integration, ok := o.integrations[params["id"]]
+	if !ok {
+		return http.StatusNotFound, map[string]any{"detail": "Not found."}
+	}
+	var body onCallAPI.UpdateIntegrationOptions
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	if body.Name != "" {
+		integration.Name = body.Name
+	}
+	integration.TeamId = body.TeamId
+	if body.Templates != nil {
+		integration.Templates = body.Templates
+	}
+	if body.DefaultRoute != nil {
+		body.DefaultRoute.ID = integration.DefaultRoute.ID
+		integration.DefaultRoute = body.DefaultRoute
+	}
+	return http.StatusOK, integration
+}
+
+func (o *OnCall) deleteIntegration(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := o.integrations[params["id"]]; !ok {
+		return http.StatusNotFound, map[string]any{"detail": "Not found."}
+	}
+	delete(o.integrations, params["id"])
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,63 @@
+package fake
+
+import (
+	"net/http"
+)
+
+const sloPath = "/api/plugins/grafana-slo-app/resources/v1/slo"
+
+// The SLO plugin API is served by Grafana, so SLOs are part of the fake Grafana server.
+func (g *Grafana) handleSLO() {
+	g.handle("GET", sloPath, g.listSLOs)
+	g.handle("POST", sloPath, g.createSLO)
+	g.handle("GET", sloPath+"/{uuid}", g.getSLO)
+	g.handle("PUT", sloPath+"/{uuid}", g.updateSLO)
+	g.handle("DELETE", sloPath+"/{uuid}", g.deleteSLO)
+}
+
+func (g *Grafana) listSLOs(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, map[string]any{"slos": sortedValues(g.slos)}
+}
+
+func (g *Grafana) createSLO(r *http.Request, _ map[string]string) (int, any) {
+	var body map[string]any
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	uuid, _ := body["uuid"].(string)
+	if uuid == "" {
+		uuid = g.newUID("slo")
+	}
+	body["uuid"] = uuid
+	g.slos[uuid] = body
+	return http.StatusAccepted, map[string]any{"uuid": uuid, "message": "SLO created"}
+}
+
+func (g *Grafana) getSLO(r *http.Request, params map[string]string) (int, any) {
+	slo, ok := g.slos[params["uuid"]]
+	if !ok {
+		return notFound("SLO")
+	}
+	return http.StatusOK, slo
+}
+
+func (g *Grafana) updateSLO(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := g.slos[params["uuid"]]; !ok {
+		return notFound("SLO")
+	}
+	var body map[string]any
+	if err := readJSON(r, &body); err != nil {
+		return badRequest(err)
+	}
+	body["uuid"] = params["uuid"]
+	g.slos[params["uuid"]] = body
+	return http.StatusOK, nil
+}
+
+func (g *Grafana) deleteSLO(r *http.Request, params map[string]string) (int, any) {
+	if _, ok := g.slos[params["uuid"]]; !ok {
+		return notFound("SLO")
+	}
+	delete(g.slos, params["uuid"])
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,233 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+)
+
+const smTenantID = 1
+
+// SyntheticMonitoring is a fake Synthetic Monitoring API server, with a single tenant.
+// It implements the check and probe APIs.
+type SyntheticMonitoring struct {
+	server
+
+	checks map[int64]*sm.Check
+	probes map[int64]*sm.Probe
+}
+
+// NewSyntheticMonitoring starts a fake Synthetic Monitoring API server, which is stopped at the end of the test.
+// It has a public probe, named `Atlanta` like in the examples, that checks can use.
+func NewSyntheticMonitoring(t testing.TB) *SyntheticMonitoring {
+	s := &SyntheticMonitoring{
+		checks: map[int64]*sm.Check{},
+		probes: map[int64]*sm.Probe{},
+	}
+	publicProbe := &sm.Probe{Id: s.newID(), TenantId: smTenantID, Name: "Atlanta", Region: "AMER", Public: true, Online: true}
+	s.probes[publicProbe.Id] = publicProbe
+
+	s.handle("GET", "/api/v1/tenant", s.getTenant)
+
+	s.handle("GET", "/api/v1/probe/list", s.listProbes)
+	s.handle("POST", "/api/v1/probe/add", s.addProbe)
+	s.handle("POST", "/api/v1/probe/update", s.updateProbe)
+	s.handle("GET", "/api/v1/probe/{id}", s.getProbe)
+	s.handle("DELETE", "/api/v1/probe/delete/{id}", s.deleteProbe)
+
+	s.handle("GET", "/api/v1/check/list", s.listChecks)
+	s.handle("POST", "/api/v1/check/add", s.addCheck)
+	s.handle("POST", "/api/v1/check/update", s.updateCheck)
+	s.handle("GET", "/api/v1/check/{id}", s.getCheck)
+	s.handle("DELETE", "/api/v1/check/delete/{id}", s.deleteCheck)
+	s.handle("POST", "/api/v1/check/adhoc", s.runAdHocCheck)
+
+	s.start(t)
+	return s
+}
+
+func (s *SyntheticMonitoring) providerConfig() map[string]string {
+	return map[string]string{
+		"sm_url":          s.URL(),
+		"sm_access_token": "fake-token",
+	}
+}
+
+// smError returns an error in the format of the Synthetic Monitoring API.
+func smError(status int, message string) (int, any) {
+	return status, map[string]any{"msg": message, "err": message}
+}
+
+func (s *SyntheticMonitoring) getTenant(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sm.Tenant{Id: smTenantID, OrgId: 1, StackId: 1, Status: sm.TenantStatus_ACTIVE}
+}
+
+// Probes
+
+func (s *SyntheticMonitoring) listProbes(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(s.probes)
+}
+
+func (s *SyntheticMonitoring) probe(params map[string]string) (*sm.Probe, bool) {
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	probe, ok := s.probes[id]
+	return probe, ok
+}
+
+func (s *SyntheticMonitoring) getProbe(r *http.Request, params map[string]string) (int, any) {
+	probe, ok := s.probe(params)
+	if !ok {
+		return smError(http.StatusNotFound, "probe not found")
+	}
+	return http.StatusOK, probe
+}
+
+func (s *SyntheticMonitoring) addProbe(r *http.Request, _ map[string]string) (int, any) {
+	var probe sm.Probe
+	if err := readJSON(r, &probe); err != nil {
+		return smError(http.StatusBadRequest, err.Error())
+	}
+	probe.Id = s.newID()
+	probe.TenantId = smTenantID
+	probe.Created = float64(time.Now().Unix())
+	probe.Modified = probe.Created
+	s.probes[probe.Id] = &probe
+	return http.StatusOK, map[string]any{"probe": probe, "token": []byte(fmt.Sprintf("probe-token-%d", probe.Id))}
+}
+
+func (s *SyntheticMonitoring) updateProbe(r *http.Request, _ map[string]string) (int, any) {
+	var probe sm.Probe
+	if err := readJSON(r, &probe); err != nil {
+		return smError(http.StatusBadRequest, err.Error())
+	}
+	existing, ok := s.probes[probe.Id]
+	if !ok {
+		return smError(http.StatusNotFound, "probe not found")
+	}
+	probe.TenantId = existing.TenantId
+	probe.Created = existing.Created
+	probe.Modified = float64(time.Now().Unix())
+	s.probes[probe.Id] = &probe
+	return http.StatusOK, map[string]any{"probe": probe}
+}
+
+func (s *SyntheticMonitoring) deleteProbe(r *http.Request, params map[string]string) (int, any) {
+	probe, ok := s.probe(params)
+	if !ok {
+		return smError(http.StatusNotFound, "probe not found")
+	}
+	for _, check := range s.checks {
+		for _, id := range check.Probes {
+			if id == probe.Id {
+				return smError(http.StatusBadRequest, "probe is used by checks")
+			}
+		}
+	}
+	delete(s.probes, probe.Id)
+	return http.StatusOK, map[string]any{"msg": "probe deleted", "probeId": probe.Id}
+}
+
+// Checks
+
+func (s *SyntheticMonitoring) listChecks(r *http.Request, _ map[string]string) (int, any) {
+	return http.StatusOK, sortedValues(s.checks)
+}
+
+func (s *SyntheticMonitoring) check(params map[string]string) (*sm.Check, bool) {
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	check, ok := s.checks[id]
+	return check, ok
+}
+
+func (s *SyntheticMonitoring) getCheck(r *http.Request, params map[string]string) (int, any) {
+	check, ok := s.check(params)
+	if !ok {
+		return smError(http.StatusNotFound, "check not found")
+	}
+	return http.StatusOK, check
+}
+
+func (s *SyntheticMonitoring) addCheck(r *http.Request, _ map[string]string) (int, any) {
+	var check sm.Check
+	if err := readJSON(r, &check); err != nil {
+		return smError(http.StatusBadRequest, err.Error())
+	}
+	if status, body := s.validateCheck(&check); status != http.StatusOK {
+		return status, body
+	}
+	check.Id = s.newID()
+	check.TenantId = smTenantID
+	check.Created = float64(time.Now().Unix())
+	check.Modified = check.Created
+	s.checks[check.Id] = &check
+	return http.StatusOK, check
+}
+
+func (s *SyntheticMonitoring) updateCheck(r *http.Request, _ map[string]string) (int, any) {
+	var check sm.Check
+	if err := readJSON(r, &check); err != nil {
+		return smError(http.StatusBadRequest, err.Error())
+	}
+	existing, ok := s.checks[check.Id]
+	if !ok {
+		return smError(http.StatusNotFound, "check not found")
+	}
+	if status, body := s.validateCheck(&check); status != http.StatusOK {
+		return status, body
+	}
+	check.TenantId = existing.TenantId
+	check.Created = existing.Created
+	check.Modified = float64(time.Now().Unix())
+	s.checks[check.Id] = &check
+	return http.StatusOK, check
+}
+
+func (s *SyntheticMonitoring) deleteCheck(r *http.Request, params map[string]string) (int, any) {
+	check, ok := s.check(params)
+	if !ok {
+		return smError(http.StatusNotFound, "check not found")
+	}
+	delete(s.checks, check.Id)
+	return http.StatusOK, map[string]any{"msg": "check deleted", "checkId": check.Id}
+}
+
+// runAdHocCheck accepts ad-hoc checks, but doesn't run them.
+func (s *SyntheticMonitoring) runAdHocCheck(r *http.Request, _ map[string]string) (int, any) {
+	var check sm.Check
+	if err := readJSON(r, &check); err != nil {
+		return smError(http.StatusBadRequest, err.Error())
+	}
+	if status, body := s.validateCheck(&check); status != http.StatusOK {
+		return status, body
+	}
+	return http.StatusOK, sm.AdHocCheck{
+		Id:       s.newUID("adhoc"),
+		TenantId: smTenantID,
+		Timeout:  check.Timeout,
+		Settings: check.Settings,
+		Probes:   check.Probes,
+		Target:   check.Target,
+	}
+}
+
+func (s *SyntheticMonitoring) validateCheck(check *sm.Check) (int, any) {
+	if len(check.Probes) == 0 {
+		return smError(http.StatusBadRequest, "at least one probe is required")
+	}
+	for _, id := range check.Probes {
+		if _, ok := s.probes[id]; !ok {
+			return smError(http.StatusBadRequest, fmt.Sprintf("probe %d does not exist", id))
+		}
+	}
+	return http.StatusOK, nil
+}
@@ -18,6 +18,14 @@ import (
 // This is meant to be used at least once in every resource's tests to ensure that
 // the resource's lister function is working correctly.
 func CheckLister(terraformResource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return CheckListerWithClient(Provider.Meta().(*common.Client), terraformResource)(s)
+	}
+}
+
+// CheckListerWithClient works like CheckLister, but lists the resources with the given client
+// instead of the one of the acceptance tests' provider. ex: A client of fake servers.
+func CheckListerWithClient(client *common.Client, terraformResource string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Get the resource from the state
 		rs, ok := s.RootModule().Resources[terraformResource]
@@ -50,7 +58,7 @@ func CheckLister(terraformResource string) resource.TestCheckFunc {
 		if resource.Category == common.CategoryCloud {
 			listerData = cloud.NewListerData(os.Getenv("GRAFANA_CLOUD_ORG"))
 		}
-		ids, err := lister(ctx, Provider.Meta().(*common.Client), listerData)
+		ids, err := lister(ctx, client, listerData)
 		if err != nil {
 			return fmt.Errorf("error listing %s: %w", terraformResource, err)
 		}
@@ -0,0 +1,135 @@
+package testutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// PlanResourceChange plans the change of a resource from the given state to the given config through the provider server, and returns the diagnostics of the plan.
+// Attributes that aren't set in the config keep their value from the state, like computed attributes in a Terraform plan.
+func PlanResourceChange(t *testing.T, server tfprotov5.ProviderServer, resourceType string, state, config map[string]tftypes.Value) []*tfprotov5.Diagnostic {
+	t.Helper()
+
+	resp, _ := planResourceChange(t, server, resourceType, state, nil, config)
+	return resp.Diagnostics
+}
+
+// ApplyResourceChange plans and applies the change of a resource from the given state to the given config through the provider server,
+// and returns the diagnostics of the plan and of the apply. Attributes are merged like in PlanResourceChange.
+// The identity is the one returned by ReadResource, it is planned and applied along with the state like Terraform does.
+// It can be used to test what happens when the resource is modified outside of Terraform between the refresh and the apply.
+func ApplyResourceChange(t *testing.T, server tfprotov5.ProviderServer, resourceType string, state map[string]tftypes.Value, identity *tfprotov5.ResourceIdentityData, config map[string]tftypes.Value) []*tfprotov5.Diagnostic {
+	t.Helper()
+
+	planResp, values := planResourceChange(t, server, resourceType, state, identity, config)
+	if planResp.PlannedState == nil || hasError(planResp.Diagnostics) {
+		return planResp.Diagnostics
+	}
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:        resourceType,
+		PriorState:      &values.priorState,
+		PlannedState:    planResp.PlannedState,
+		Config:          &values.config,
+		PlannedPrivate:  planResp.PlannedPrivate,
+		PlannedIdentity: planResp.PlannedIdentity,
+	})
+	if err != nil {
+		t.Fatalf("failed to apply %s: %v", resourceType, err)
+	}
+	return append(planResp.Diagnostics, resp.Diagnostics...)
+}
+
+// ReadResource reads the resource with the given ID through the provider server, and returns its state and identity, ex: to pass them to ApplyResourceChange.
+func ReadResource(t *testing.T, server tfprotov5.ProviderServer, resourceType string, id string) (map[string]tftypes.Value, *tfprotov5.ResourceIdentityData) {
+	t.Helper()
+
+	objectType := resourceObjectType(t, server, resourceType)
+	currentState := resourceValue(t, objectType, map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, id)})
+	resp, err := server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{
+		TypeName:     resourceType,
+		CurrentState: &currentState,
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", resourceType, err)
+	}
+	if hasError(resp.Diagnostics) {
+		t.Fatalf("failed to read %s: %s: %s", resourceType, resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+	}
+	if resp.NewState == nil {
+		t.Fatalf("%s %s not found", resourceType, id)
+	}
+
+	value, err := resp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("failed to decode the state of %s: %v", resourceType, err)
+	}
+	state := map[string]tftypes.Value{}
+	if err := value.As(&state); err != nil {
+		t.Fatalf("failed to decode the state of %s: %v", resourceType, err)
+	}
+	return state, resp.NewIdentity
+}
+
+type resourceChangeValues struct {
+	priorState, proposedNewState, config tfprotov5.DynamicValue
+}
+
+func planResourceChange(t *testing.T, server tfprotov5.ProviderServer, resourceType string, state map[string]tftypes.Value, identity *tfprotov5.ResourceIdentityData, config map[string]tftypes.Value) (*tfprotov5.PlanResourceChangeResponse, resourceChangeValues) {
+	t.Helper()
+
+	objectType := resourceObjectType(t, server, resourceType)
+	values := resourceChangeValues{
+		priorState:       resourceValue(t, objectType, state),
+		proposedNewState: resourceValue(t, objectType, state, config),
+		config:           resourceValue(t, objectType, config),
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       &values.priorState,
+		ProposedNewState: &values.proposedNewState,
+		Config:           &values.config,
+		PriorIdentity:    identity,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan %s: %v", resourceType, err)
+	}
+	return resp, values
+}
+
+func resourceObjectType(t *testing.T, server tfprotov5.ProviderServer, resourceType string) tftypes.Object {
+	t.Helper()
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get the provider schema: %v", err)
+	}
+	schema, ok := schemas.ResourceSchemas[resourceType]
+	if !ok {
+		t.Fatalf("resource %s not found", resourceType)
+	}
+	return schema.ValueType().(tftypes.Object)
+}
+
+// resourceValue builds a value of the resource from the given attributes. Attributes that are set several times take their last value, others are null.
+func resourceValue(t *testing.T, objectType tftypes.Object, attributes ...map[string]tftypes.Value) tfprotov5.DynamicValue {
+	t.Helper()
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		for _, a := range attributes {
+			if v, ok := a[name]; ok {
+				values[name] = v
+			}
+		}
+	}
+	dynamicValue, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("failed to create the value: %v", err)
+	}
+	return dynamicValue
+}
@@ -22,7 +22,23 @@ import (
 var (
 	// ProtoV5ProviderFactories is a static map containing the grafana provider instance
 	// It is used to configure the provider in acceptance tests
-	ProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	// The config is empty because we'll use environment variables to configure the provider
+	ProtoV5ProviderFactories = ProtoV5ProviderFactoriesWithConfig(nil)
+
+	// Provider is the "main" provider instance
+	//
+	// This Provider can be used in testing code for API calls without requiring
+	// the use of saving and referencing specific ProviderFactories instances.
+	//
+	// It is configured from the main provider package when the test suite is initialized
+	// but it is used in tests of every package
+	Provider *schema.Provider
+)
+
+// ProtoV5ProviderFactoriesWithConfig returns a map containing a grafana provider instance configured with the given attributes
+// Attributes that aren't set are configured from environment variables
+func ProtoV5ProviderFactoriesWithConfig(config map[string]string) map[string]func() (tfprotov5.ProviderServer, error) {
+	return map[string]func() (tfprotov5.ProviderServer, error){
 		"grafana": func() (tfprotov5.ProviderServer, error) {
 			// Create a provider server
 			ctx := context.Background()
@@ -32,7 +48,6 @@ var (
 			}
 
 			// Get the provider schema and create a provider configuration
-			// The config is empty because we'll use environment variables to configure the provider
 			schemaResp, err := server.GetProviderSchema(ctx, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get provider schema: %v", err)
@@ -40,6 +55,9 @@ var (
 			fields := map[string]tftypes.Value{}
 			for _, v := range schemaResp.Provider.Block.Attributes {
 				fields[v.Name] = tftypes.NewValue(v.Type, nil)
+				if value, ok := config[v.Name]; ok {
+					fields[v.Name] = tftypes.NewValue(v.Type, value)
+				}
 			}
 			testValue := tftypes.NewValue(schemaResp.Provider.ValueType(), fields)
 			testDynamicValue, err := tfprotov5.NewDynamicValue(schemaResp.Provider.ValueType(), testValue)
@@ -59,19 +77,49 @@ var (
 				}
 				return nil, fmt.Errorf("failed to configure provider: %v", err)
 			}
+			if len(config) > 0 {
+				// Terraform configures the provider again from the test's config, so the attributes must be set then as well
+				return &configuredProviderServer{providerServer: server.(providerServer), configType: schemaResp.Provider.ValueType(), config: config}, nil
+			}
 			return server, nil
 		},
 	}
+}
 
-	// Provider is the "main" provider instance
-	//
-	// This Provider can be used in testing code for API calls without requiring
-	// the use of saving and referencing specific ProviderFactories instances.
-	//
-	// It is configured from the main provider package when the test suite is initialized
-	// but it is used in tests of every package
-	Provider *schema.Provider
-)
+// providerServer is the provider server, with the RPCs that aren't part of tfprotov5.ProviderServer yet
+type providerServer interface {
+	tfprotov5.ProviderServerWithActions
+	tfprotov5.ProviderServerWithListResource
+}
+
+// configuredProviderServer sets provider attributes that aren't set in the Terraform config
+type configuredProviderServer struct {
+	providerServer
+	configType tftypes.Type
+	config     map[string]string
+}
+
+func (s *configuredProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	value, err := req.Config.Unmarshal(s.configType)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]tftypes.Value{}
+	if err := value.As(&fields); err != nil {
+		return nil, err
+	}
+	for name, v := range s.config {
+		if field, ok := fields[name]; ok && field.IsNull() {
+			fields[name] = tftypes.NewValue(field.Type(), v)
+		}
+	}
+	config, err := tfprotov5.NewDynamicValue(s.configType, tftypes.NewValue(s.configType, fields))
+	if err != nil {
+		return nil, err
+	}
+	req.Config = &config
+	return s.providerServer.ConfigureProvider(ctx, req)
+}
 
 func init() {
 	Provider = provider.Provider("testacc")
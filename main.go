@@ -12,6 +12,7 @@ import (
 
 //go:generate go run ./tools/genimports examples
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
+//go:generate go run ./tools/genactiondocs docs examples
 //go:generate go run ./tools/setcategories docs
 
 var (
@@ -47,6 +48,11 @@ func main() {
 		serveOpts...,
 	)
 
+	// Terraform stops the provider gracefully once it's done with it, the temporary credentials of connections can then be deleted
+	if closeErr := provider.CloseGrafanaConnections(); closeErr != nil {
+		log.Printf("[WARN] failed to delete the temporary service accounts of grafana_connection blocks: %v", closeErr)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -16,7 +16,6 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v3/pkg/generate/utils"
 	"github.com/grafana/terraform-provider-grafana/v3/pkg/provider"
 	"github.com/hashicorp/hcl/v2/hclwrite"
-	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -322,7 +321,7 @@ func generateImportBlocks(ctx context.Context, client *common.Client, listerData
 	if err := writeBlocks(generatedFilename("imports.tf"), allBlocks...); err != nil {
 		return failure(err)
 	}
-	_, err = cfg.Terraform.Plan(ctx, tfexec.GenerateConfigOut(generatedFilename("resources.tf")))
+	err = generateConfig(ctx, cfg.Terraform, generatedFilename("resources.tf"))
 	if err != nil && !strings.Contains(err.Error(), "Missing required argument") {
 		// If resources.tf was created and is not empty, return the error as a "non-critical" error
 		if stat, statErr := os.Stat(generatedFilename("resources.tf")); statErr == nil && stat.Size() > 0 {
@@ -1,12 +1,14 @@
 package generate
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -80,6 +82,19 @@ func setupTerraform(cfg *Config) (*tfexec.Terraform, error) {
 	return tf, nil
 }
 
+// generateConfig runs `terraform plan -generate-config-out`, which terraform-exec doesn't support, to write the configuration of the imported resources.
+// The error includes the output of Terraform, ex: to detect missing required arguments in the generated configuration.
+func generateConfig(ctx context.Context, tf *tfexec.Terraform, out string) error {
+	cmd := exec.CommandContext(ctx, tf.ExecPath(), "plan", "-no-color", "-input=false", "-generate-config-out="+out)
+	cmd.Dir = tf.WorkingDir()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w\n%s", err, stderr.String())
+	}
+	return nil
+}
+
 func writeBlocks(filepath string, blocks ...*hclwrite.Block) error {
 	return writeBlocksFile(filepath, false, blocks...)
 }
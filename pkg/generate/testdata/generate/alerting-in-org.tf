@@ -33,6 +33,15 @@ resource "grafana_mute_timing" "my_mute_timing" {
   }
 }
 
+resource "grafana_mute_timing" "with_separator" {
+  org_id = grafana_organization.test.id
+  name   = "Team: Business Hours"
+
+  intervals {
+    weekdays = ["monday:friday"]
+  }
+}
+
 resource "grafana_message_template" "my_template" {
   org_id   = grafana_organization.test.id
   name     = "My Reusable Template"
@@ -28,6 +28,11 @@ import {
   id = "2:My Mute Timing"
 }
 
+import {
+  to = grafana_mute_timing._2_Team___Business_Hours
+  id = "2:Team\\: Business Hours"
+}
+
 import {
   to = grafana_notification_policy._1_policy
   id = "1:policy"
@@ -67,6 +67,15 @@ resource "grafana_mute_timing" "_2_My_Mute_Timing" {
   }
 }
 
+# __generated__ by Terraform from "2:Team\\: Business Hours"
+resource "grafana_mute_timing" "_2_Team___Business_Hours" {
+  name   = "Team: Business Hours"
+  org_id = grafana_organization._2.id
+  intervals {
+    weekdays = ["monday:friday"]
+  }
+}
+
 # __generated__ by Terraform from "1:policy"
 resource "grafana_notification_policy" "_1_policy" {
   contact_point      = "grafana-default-email"
@@ -0,0 +1,319 @@
+// Package lint checks dashboards, rule groups and message templates offline, without a Grafana instance.
+// It reads Terraform files (`grafana_dashboard`, `grafana_rule_group` and `grafana_message_template` resources),
+// dashboard and rule group JSON files, and template files.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+)
+
+// Config is the configuration of Lint.
+type Config struct {
+	// Paths are the files and directories to check. Directories are walked recursively.
+	Paths []string
+	// DataSources and LibraryPanels are the UIDs or names of those that exist outside of the checked files.
+	// The ones managed in the checked Terraform files are added to them.
+	DataSources   []string
+	LibraryPanels []string
+}
+
+// Issue is a problem found in a file. Resource is the address of the resource, for issues in Terraform files.
+type Issue struct {
+	File     string
+	Resource string
+	grafana.LintIssue
+}
+
+func (i Issue) String() string {
+	location := i.File
+	if i.Resource != "" {
+		location += ": " + i.Resource
+	}
+	return fmt.Sprintf("%s: %s: %s", location, i.Severity, i.Message)
+}
+
+// templateExtensions are the extensions of message template files.
+var templateExtensions = map[string]bool{".tmpl": true, ".gotmpl": true}
+
+// Lint checks the files of the config and returns the issues found.
+func Lint(cfg Config) ([]Issue, error) {
+	files, err := listFiles(cfg.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	var resources []resourceBlock
+	for _, file := range files {
+		if filepath.Ext(file) != ".tf" {
+			continue
+		}
+		fileResources, diags := parseResources(file)
+		if diags.HasErrors() {
+			issues = append(issues, Issue{File: file, LintIssue: grafana.LintIssue{Severity: grafana.LintError, Message: diags.Error()}})
+		}
+		resources = append(resources, fileResources...)
+	}
+
+	refs := grafana.LintReferences{DataSources: map[string]bool{}, LibraryPanels: map[string]bool{}}
+	for _, ds := range cfg.DataSources {
+		refs.DataSources[ds] = true
+	}
+	for _, panel := range cfg.LibraryPanels {
+		refs.LibraryPanels[panel] = true
+	}
+	for _, r := range resources {
+		var known map[string]bool
+		switch r.resourceType {
+		case "grafana_data_source":
+			known = refs.DataSources
+		case "grafana_library_panel":
+			known = refs.LibraryPanels
+		default:
+			continue
+		}
+		for _, attribute := range []string{"uid", "name"} {
+			if value, ok := r.attributes[attribute].(string); ok && value != "" {
+				known[value] = true
+			}
+		}
+	}
+
+	// The same dashboard can be in a JSON file and in the `config_json` of a resource, with the `file` function.
+	// It's only checked once, in the JSON file.
+	checkedDashboards := map[string]bool{}
+	lintDashboard := func(configJSON string) []grafana.LintIssue {
+		key := grafana.NormalizeDashboardConfigJSON(configJSON)
+		if checkedDashboards[key] {
+			return nil
+		}
+		checkedDashboards[key] = true
+		return grafana.LintDashboard(configJSON, refs)
+	}
+
+	for _, file := range files {
+		ext := filepath.Ext(file)
+		if ext != ".json" && !templateExtensions[ext] {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var fileIssues []grafana.LintIssue
+		if templateExtensions[ext] {
+			fileIssues = grafana.LintMessageTemplate(string(content))
+		} else {
+			var model map[string]interface{}
+			if err := json.Unmarshal(content, &model); err != nil {
+				fileIssues = []grafana.LintIssue{{Severity: grafana.LintError, Message: fmt.Sprintf("invalid JSON: %v", err)}}
+			} else if model["groups"] != nil || model["rules"] != nil {
+				fileIssues = grafana.LintRuleGroupJSON(content)
+			} else if model["panels"] != nil || model["rows"] != nil || model["schemaVersion"] != nil {
+				fileIssues = lintDashboard(string(content))
+			}
+		}
+		for _, issue := range fileIssues {
+			issues = append(issues, Issue{File: file, LintIssue: issue})
+		}
+	}
+
+	for _, r := range resources {
+		var resourceIssues []grafana.LintIssue
+		switch r.resourceType {
+		case "grafana_dashboard":
+			if configJSON, ok := r.attributes["config_json"].(string); ok {
+				resourceIssues = lintDashboard(configJSON)
+			}
+		case "grafana_rule_group":
+			resourceIssues = grafana.LintRuleGroupResource(r.attributes)
+		case "grafana_message_template":
+			if template, ok := r.attributes["template"].(string); ok {
+				resourceIssues = grafana.LintMessageTemplate(template)
+			}
+		}
+		for _, issue := range resourceIssues {
+			issues = append(issues, Issue{File: r.file, Resource: r.resourceType + "." + r.name, LintIssue: issue})
+		}
+	}
+
+	return issues, nil
+}
+
+func listFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				// Ex: `.terraform`, which contains the modules and providers
+				if file != path && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			files = append(files, file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+type resourceBlock struct {
+	file         string
+	resourceType string
+	name         string
+	// attributes are the attributes and nested blocks that could be evaluated offline.
+	attributes map[string]interface{}
+}
+
+func parseResources(file string) ([]resourceBlock, hcl.Diagnostics) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, hcl.Diagnostics{{Severity: hcl.DiagError, Summary: err.Error()}}
+	}
+	hclFile, diags := hclsyntax.ParseConfig(content, file, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	dir := filepath.Dir(file)
+	var resources []resourceBlock
+	for _, block := range hclFile.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], "grafana_") {
+			continue
+		}
+		resources = append(resources, resourceBlock{
+			file:         file,
+			resourceType: block.Labels[0],
+			name:         block.Labels[1],
+			attributes:   bodyAttributes(block.Body, dir),
+		})
+	}
+	return resources, nil
+}
+
+// bodyAttributes evaluates the attributes of a block, and its nested blocks as lists of objects, like the SDK represents them.
+// Attributes that reference variables, other resources or unsupported functions can't be evaluated offline, and are left out.
+func bodyAttributes(body *hclsyntax.Body, dir string) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for name, attribute := range body.Attributes {
+		value, diags := attribute.Expr.Value(evalContext(attribute.Expr, dir))
+		if diags.HasErrors() || !value.IsWhollyKnown() {
+			continue
+		}
+		if v := ctyToGo(value); v != nil {
+			attributes[name] = v
+		}
+	}
+	for _, block := range body.Blocks {
+		list, _ := attributes[block.Type].([]interface{})
+		attributes[block.Type] = append(list, bodyAttributes(block.Body, dir))
+	}
+	return attributes
+}
+
+// evalContext returns the context to evaluate an expression offline: references are unknown, except `path`, and only common functions are available.
+func evalContext(expr hclsyntax.Expression, dir string) *hcl.EvalContext {
+	variables := map[string]cty.Value{}
+	for _, traversal := range expr.Variables() {
+		variables[traversal.RootName()] = cty.DynamicVal
+	}
+	variables["path"] = cty.ObjectVal(map[string]cty.Value{
+		"module": cty.StringVal(dir),
+		"root":   cty.StringVal(dir),
+		"cwd":    cty.StringVal(dir),
+	})
+
+	return &hcl.EvalContext{
+		Variables: variables,
+		Functions: map[string]function.Function{
+			"file":       fileFunc(dir),
+			"jsonencode": stdlib.JSONEncodeFunc,
+			"jsondecode": stdlib.JSONDecodeFunc,
+			"merge":      stdlib.MergeFunc,
+			"concat":     stdlib.ConcatFunc,
+			"format":     stdlib.FormatFunc,
+			"join":       stdlib.JoinFunc,
+			"lower":      stdlib.LowerFunc,
+			"upper":      stdlib.UpperFunc,
+			"replace":    stdlib.ReplaceFunc,
+			"trimspace":  stdlib.TrimSpaceFunc,
+			"tostring":   stdlib.MakeToFunc(cty.String),
+			"tonumber":   stdlib.MakeToFunc(cty.Number),
+		},
+	}
+}
+
+// fileFunc is Terraform's `file` function. Relative paths are relative to the directory of the Terraform file.
+func fileFunc(dir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "path", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			path := args[0].AsString()
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.StringVal(string(content)), nil
+		},
+	})
+}
+
+func ctyToGo(value cty.Value) interface{} {
+	if value.IsNull() {
+		return nil
+	}
+	t := value.Type()
+	switch {
+	case t == cty.String:
+		return value.AsString()
+	case t == cty.Bool:
+		return value.True()
+	case t == cty.Number:
+		if i, accuracy := value.AsBigFloat().Int64(); accuracy == 0 {
+			return int(i)
+		}
+		f, _ := value.AsBigFloat().Float64()
+		return f
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		list := []interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			list = append(list, ctyToGo(v))
+		}
+		return list
+	case t.IsMapType() || t.IsObjectType():
+		m := map[string]interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			m[k.AsString()] = ctyToGo(v)
+		}
+		return m
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	issues, err := lint.Lint(lint.Config{Paths: []string{"testdata"}})
+	require.NoError(t, err)
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	require.Equal(t, []string{
+		`testdata/dashboard.json: error: panel "Errors": duplicate panel ID 1`,
+		`testdata/dashboard.json: warning: panel "Errors": unknown panel type "timeserie"`,
+		`testdata/dashboard.json: warning: panel "Errors": unknown data source "loki-uid"`,
+		`testdata/dashboard.json: error: panel "Shared": library panel reference without a uid`,
+		`testdata/dashboard.json: warning: variable "unused" is not used`,
+		`testdata/rules.json: error: rule "Exported rule": condition "B" does not match any ref_id`,
+		`testdata/main.tf: grafana_rule_group.rules: error: rule "High latency": condition "C" does not match any ref_id`,
+		`testdata/main.tf: grafana_rule_group.rules: error: rule "High latency", query B: expression "X" does not match any ref_id`,
+		`testdata/main.tf: grafana_message_template.broken: error: invalid template: template: :1: unexpected EOF`,
+	}, got)
+}
+
+func TestLint_knownReferences(t *testing.T) {
+	issues, err := lint.Lint(lint.Config{
+		Paths:       []string{"testdata/dashboard.json"},
+		DataSources: []string{"prometheus-uid", "loki-uid"},
+	})
+	require.NoError(t, err)
+	for _, issue := range issues {
+		require.NotContains(t, issue.Message, "unknown data source")
+	}
+
+	// Without known data sources, references aren't checked
+	issues, err = lint.Lint(lint.Config{Paths: []string{"testdata/dashboard.json"}})
+	require.NoError(t, err)
+	for _, issue := range issues {
+		require.NotContains(t, issue.Message, "unknown data source")
+	}
+}
@@ -0,0 +1,61 @@
+resource "grafana_data_source" "prometheus" {
+  type = "prometheus"
+  name = "Prometheus"
+  uid  = "prometheus-uid"
+  url  = "http://prometheus:9090"
+}
+
+resource "grafana_folder" "rules" {
+  title = "Rules"
+}
+
+resource "grafana_dashboard" "service" {
+  config_json = file("${path.module}/dashboard.json")
+}
+
+resource "grafana_dashboard" "inline" {
+  config_json = jsonencode({
+    title = "Inline"
+    panels = [{
+      id         = 1
+      type       = "stat"
+      title      = "Up"
+      datasource = { type = "prometheus", uid = grafana_data_source.prometheus.uid }
+    }]
+  })
+}
+
+resource "grafana_rule_group" "rules" {
+  name             = "Rules"
+  folder_uid       = grafana_folder.rules.uid
+  interval_seconds = 60
+
+  rule {
+    name      = "High latency"
+    condition = "C"
+
+    data {
+      ref_id         = "A"
+      datasource_uid = grafana_data_source.prometheus.uid
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+      model = jsonencode({ expr = "latency_seconds" })
+    }
+    data {
+      ref_id         = "B"
+      datasource_uid = "__expr__"
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+      model = jsonencode({ type = "threshold", expression = "X" })
+    }
+  }
+}
+
+resource "grafana_message_template" "broken" {
+  name     = "Broken"
+  template = "{{ define \"broken\" }}{{ .Status | toUpper }}"
+}
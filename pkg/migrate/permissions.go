@@ -0,0 +1,158 @@
+// Package migrate generates the Terraform configuration to move resources in a state to other resource types,
+// without recreating them in Grafana: resource blocks for the new resources, `import` blocks to adopt them,
+// and `removed` blocks to forget the old resources without destroying them. It requires Terraform 1.7+.
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+)
+
+// permissionMigration is the migration of a resource managing the entire set of permissions of a Grafana resource
+// to the `_item` resource, which manages a single permission.
+type permissionMigration struct {
+	itemResourceType string
+	// uidAttribute is the attribute of the permissioned resource, in both resources.
+	uidAttribute string
+	// roleAttribute is the attribute of built-in role permissions, in the authoritative resource.
+	roleAttribute string
+}
+
+var permissionMigrations = map[string]permissionMigration{
+	"grafana_folder_permission":      {itemResourceType: "grafana_folder_permission_item", uidAttribute: "folder_uid", roleAttribute: "role"},
+	"grafana_dashboard_permission":   {itemResourceType: "grafana_dashboard_permission_item", uidAttribute: "dashboard_uid", roleAttribute: "role"},
+	"grafana_data_source_permission": {itemResourceType: "grafana_data_source_permission_item", uidAttribute: "datasource_uid", roleAttribute: "built_in_role"},
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// PermissionItems returns the configuration to migrate the `grafana_folder_permission`, `grafana_dashboard_permission`
+// and `grafana_data_source_permission` resources of a state to their `_item` variants.
+// Resources in child modules aren't migrated, since their configuration is in the module. Their addresses are returned instead.
+func PermissionItems(state *tfjson.State) (*hclwrite.File, []string, error) {
+	file := hclwrite.NewEmptyFile()
+	if state.Values == nil || state.Values.RootModule == nil {
+		return file, nil, nil
+	}
+
+	var skipped []string
+	var walkChildModules func(modules []*tfjson.StateModule)
+	walkChildModules = func(modules []*tfjson.StateModule) {
+		for _, module := range modules {
+			for _, r := range module.Resources {
+				if _, ok := permissionMigrations[r.Type]; ok && r.Mode == tfjson.ManagedResourceMode {
+					skipped = append(skipped, r.Address)
+				}
+			}
+			walkChildModules(module.ChildModules)
+		}
+	}
+	walkChildModules(state.Values.RootModule.ChildModules)
+
+	resources := state.Values.RootModule.Resources
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Address < resources[j].Address })
+	removed := map[string]bool{}
+	for _, r := range resources {
+		migration, ok := permissionMigrations[r.Type]
+		if !ok || r.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+		if err := migration.appendItems(file.Body(), r); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate %s: %w", r.Address, err)
+		}
+
+		// Instances of resources with `count` or `for_each` are all removed by a single block
+		if key := r.Type + "." + r.Name; !removed[key] {
+			removed[key] = true
+			block := file.Body().AppendNewBlock("removed", nil)
+			block.Body().SetAttributeTraversal("from", traversal(r.Type, r.Name))
+			block.Body().AppendNewBlock("lifecycle", nil).Body().SetAttributeValue("destroy", cty.False)
+			file.Body().AppendNewline()
+		}
+	}
+	return file, skipped, nil
+}
+
+func (m permissionMigration) appendItems(body *hclwrite.Body, r *tfjson.StateResource) error {
+	var itemIDType *common.ResourceID
+	for _, resource := range grafana.Resources {
+		if resource.Name == m.itemResourceType {
+			itemIDType = resource.IDType
+		}
+	}
+	if itemIDType == nil {
+		return fmt.Errorf("resource type %s not found", m.itemResourceType)
+	}
+
+	uid, _ := r.AttributeValues[m.uidAttribute].(string)
+	id, _ := r.AttributeValues["id"].(string)
+	orgIDStr, _ := r.AttributeValues["org_id"].(string)
+	orgID, err := strconv.ParseInt(orgIDStr, 10, 64)
+	if err != nil || orgID == 0 {
+		orgID, _ = grafana.SplitOrgResourceID(id)
+	}
+
+	name := r.Name
+	if r.Index != nil {
+		name += "_" + fmt.Sprint(r.Index)
+	}
+
+	permissions, _ := r.AttributeValues["permissions"].([]interface{})
+	for _, p := range permissions {
+		permission, _ := p.(map[string]interface{})
+		targetType, targetAttribute, identifier := permissionTarget(permission, m.roleAttribute)
+		if targetType == "" {
+			continue
+		}
+		itemName := invalidNameChars.ReplaceAllString(strings.Join([]string{name, targetType, identifier}, "_"), "_")
+
+		block := body.AppendNewBlock("resource", []string{m.itemResourceType, itemName})
+		block.Body().SetAttributeValue("org_id", cty.StringVal(strconv.FormatInt(orgID, 10)))
+		block.Body().SetAttributeValue(m.uidAttribute, cty.StringVal(uid))
+		block.Body().SetAttributeValue(targetAttribute, cty.StringVal(identifier))
+		block.Body().SetAttributeValue("permission", cty.StringVal(fmt.Sprint(permission["permission"])))
+		body.AppendNewline()
+
+		block = body.AppendNewBlock("import", nil)
+		block.Body().SetAttributeTraversal("to", traversal(m.itemResourceType, itemName))
+		block.Body().SetAttributeValue("id", cty.StringVal(itemIDType.Make(orgID, uid, targetType, identifier)))
+		body.AppendNewline()
+	}
+	return nil
+}
+
+// permissionTarget returns the type (role, team or user), the attribute of the `_item` resource and the identifier of the target of a permission.
+// Teams and users are unset when their ID is 0.
+func permissionTarget(permission map[string]interface{}, roleAttribute string) (string, string, string) {
+	if role, _ := permission[roleAttribute].(string); role != "" {
+		return "role", "role", role
+	}
+	teamID, _ := permission["team_id"].(string)
+	if _, teamID = grafana.SplitOrgResourceID(teamID); teamID != "" && teamID != "0" {
+		return "team", "team", teamID
+	}
+	userID, _ := permission["user_id"].(string)
+	if _, userID = grafana.SplitOrgResourceID(userID); userID != "" && userID != "0" {
+		return "user", "user", userID
+	}
+	return "", "", ""
+}
+
+func traversal(root string, attrs ...string) hcl.Traversal {
+	tr := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attr := range attrs {
+		tr = append(tr, hcl.TraverseAttr{Name: attr})
+	}
+	return tr
+}
@@ -0,0 +1,27 @@
+package migrate_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/migrate"
+)
+
+func TestPermissionItems(t *testing.T) {
+	content, err := os.ReadFile("testdata/state.json")
+	require.NoError(t, err)
+	var state tfjson.State
+	require.NoError(t, json.Unmarshal(content, &state))
+
+	file, skipped, err := migrate.PermissionItems(&state)
+	require.NoError(t, err)
+	require.Equal(t, []string{"module.team.grafana_folder_permission.this"}, skipped)
+
+	expected, err := os.ReadFile("testdata/permissions.tf")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(file.Bytes()))
+}
@@ -0,0 +1,100 @@
+resource "grafana_dashboard_permission_item" "services_api_role_Viewer" {
+  org_id        = "2"
+  dashboard_uid = "api:overview"
+  role          = "Viewer"
+  permission    = "View"
+}
+
+import {
+  to = grafana_dashboard_permission_item.services_api_role_Viewer
+  id = "2:api\\:overview:role:Viewer"
+}
+
+removed {
+  from = grafana_dashboard_permission.services
+  lifecycle {
+    destroy = false
+  }
+}
+
+resource "grafana_dashboard_permission_item" "team_team_4" {
+  org_id        = "1"
+  dashboard_uid = "team-dashboard"
+  team          = "4"
+  permission    = "Edit"
+}
+
+import {
+  to = grafana_dashboard_permission_item.team_team_4
+  id = "1:team-dashboard:team:4"
+}
+
+removed {
+  from = grafana_dashboard_permission.team
+  lifecycle {
+    destroy = false
+  }
+}
+
+resource "grafana_folder_permission_item" "collaborator_role_Editor" {
+  org_id     = "1"
+  folder_uid = "team-folder"
+  role       = "Editor"
+  permission = "Edit"
+}
+
+import {
+  to = grafana_folder_permission_item.collaborator_role_Editor
+  id = "1:team-folder:role:Editor"
+}
+
+resource "grafana_folder_permission_item" "collaborator_team_3" {
+  org_id     = "1"
+  folder_uid = "team-folder"
+  team       = "3"
+  permission = "View"
+}
+
+import {
+  to = grafana_folder_permission_item.collaborator_team_3
+  id = "1:team-folder:team:3"
+}
+
+resource "grafana_folder_permission_item" "collaborator_user_5" {
+  org_id     = "1"
+  folder_uid = "team-folder"
+  user       = "5"
+  permission = "Admin"
+}
+
+import {
+  to = grafana_folder_permission_item.collaborator_user_5
+  id = "1:team-folder:user:5"
+}
+
+removed {
+  from = grafana_folder_permission.collaborator
+  lifecycle {
+    destroy = false
+  }
+}
+
+resource "grafana_folder_permission_item" "team_team_4" {
+  org_id     = "1"
+  folder_uid = "team-folder"
+  team       = "4"
+  permission = "View"
+}
+
+import {
+  to = grafana_folder_permission_item.team_team_4
+  id = "1:team-folder:team:4"
+}
+
+removed {
+  from = grafana_folder_permission.team
+  lifecycle {
+    destroy = false
+  }
+}
+
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/cloud"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -59,8 +62,103 @@ func CreateClients(providerConfig ProviderConfig) (*common.Client, error) {
 		c.OnCallClient = onCallClient
 	}
 
+	c.GrafanaConnection = grafanaConnectionFunc(providerConfig, c.GrafanaCloudAPI)
+
 	grafana.StoreDashboardSHA256 = providerConfig.StoreDashboardSha256.ValueBool()
+	grafana.AnnotateChanges = providerConfig.AnnotateChanges.ValueBool()
+	grafana.ChangeMessage = providerConfig.ChangeMessage.ValueString()
+
+	return c, nil
+}
+
+// connectionTokenSecondsToLive is the lifetime of the tokens of `grafana_connection` blocks with a `stack_slug`.
+// They are deleted when the provider stops. If that fails, they're deleted by the next provider connecting to the stack once they have expired.
+const connectionTokenSecondsToLive = 24 * 60 * 60
+
+// connectionServiceAccountName is the name of the service account of `grafana_connection` blocks with a `stack_slug`.
+// It's created once per stack and reused by every provider, which only creates a new token.
+const connectionServiceAccountName = "terraform-connection"
+
+// grafanaConnections are the clients of `grafana_connection` blocks. They are shared by the SDK and framework providers,
+// and kept for the lifetime of the provider, so that resources of the same instance share the alerting mutex and the server info,
+// and that a single token is created per stack.
+var grafanaConnections = struct {
+	sync.Mutex
+	clients  map[grafanaConnectionKey]*common.Client
+	cleanups []func() error
+}{clients: map[grafanaConnectionKey]*common.Client{}}
+
+type grafanaConnectionKey struct {
+	common.GrafanaConnectionConfig
+	cloudAPIURL string
+}
+
+// grafanaConnectionFunc returns the function creating the clients of resources with a `grafana_connection` block.
+// Clients of stacks use a service account token, which is created with the cloud client of the provider.
+func grafanaConnectionFunc(providerConfig ProviderConfig, cloudClient *gcom.APIClient) func(context.Context, common.GrafanaConnectionConfig) (*common.Client, error) {
+	return func(ctx context.Context, connection common.GrafanaConnectionConfig) (*common.Client, error) {
+		grafanaConnections.Lock()
+		defer grafanaConnections.Unlock()
+
+		key := grafanaConnectionKey{GrafanaConnectionConfig: connection}
+		if connection.StackSlug != "" {
+			key.cloudAPIURL = providerConfig.CloudAPIURL.ValueString()
+		}
+		if client, ok := grafanaConnections.clients[key]; ok {
+			return client, nil
+		}
+
+		grafanaURL, auth := connection.URL, connection.Auth
+		if connection.StackSlug != "" {
+			if cloudClient == nil {
+				return nil, fmt.Errorf("the Cloud API client is required to connect to stack %s. Set the cloud_access_policy_token provider attribute", connection.StackSlug)
+			}
+			stackURL, token, cleanup, err := cloud.CreateStackServiceAccountToken(ctx, cloudClient, connection.StackSlug, connectionServiceAccountName, connectionTokenSecondsToLive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create a token for stack %s: %w", connection.StackSlug, err)
+			}
+			grafanaConnections.cleanups = append(grafanaConnections.cleanups, cleanup)
+			grafanaURL, auth = stackURL, token
+		}
+
+		client, err := createGrafanaConnectionClient(providerConfig, grafanaURL, auth)
+		if err != nil {
+			return nil, err
+		}
+		grafanaConnections.clients[key] = client
+		return client, nil
+	}
+}
+
+// CloseGrafanaConnections deletes the service account tokens of `grafana_connection` blocks. It must be called when the provider stops.
+func CloseGrafanaConnections() error {
+	grafanaConnections.Lock()
+	defer grafanaConnections.Unlock()
+
+	var errs []error
+	for _, cleanup := range grafanaConnections.cleanups {
+		errs = append(errs, cleanup())
+	}
+	grafanaConnections.clients = map[grafanaConnectionKey]*common.Client{}
+	grafanaConnections.cleanups = nil
+	return errors.Join(errs...)
+}
+
+// createGrafanaConnectionClient creates the clients of a Grafana instance, with the settings of the provider but the given URL and auth.
+func createGrafanaConnectionClient(providerConfig ProviderConfig, grafanaURL, auth string) (*common.Client, error) {
+	providerConfig.URL = types.StringValue(grafanaURL)
+	providerConfig.Auth = types.StringValue(auth)
 
+	c := &common.Client{}
+	if err := createGrafanaAPIClient(c, providerConfig); err != nil {
+		return nil, err
+	}
+	if err := createMLClient(c, providerConfig); err != nil {
+		return nil, err
+	}
+	if err := createSLOClient(c, providerConfig); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -112,6 +210,9 @@ func createGrafanaAPIClient(client *common.Client, providerConfig ProviderConfig
 	}
 	client.GrafanaAPI = goapi.NewHTTPClientWithConfig(strfmt.Default, &cfg)
 	client.GrafanaAPIConfig = &cfg
+	client.GrafanaServerInfo = sync.OnceValues(func() (*common.GrafanaServerInfo, error) {
+		return common.FetchGrafanaServerInfo(client.GrafanaAPI)
+	})
 
 	return nil
 }
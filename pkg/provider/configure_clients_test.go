@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -142,3 +143,32 @@ func TestCreateClients(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateClients_grafanaConnection(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, CloseGrafanaConnections()) })
+
+	config := ProviderConfig{
+		URL:  types.StringValue("http://localhost:3000"),
+		Auth: types.StringValue("admin:admin"),
+	}
+	sdkClients, err := CreateClients(config)
+	require.NoError(t, err)
+	frameworkClients, err := CreateClients(config)
+	require.NoError(t, err)
+
+	// The SDK and framework providers share the clients of connections, for the lifetime of the provider
+	connection := common.GrafanaConnectionConfig{URL: "http://other:3000", Auth: "admin:admin"}
+	client, err := sdkClients.GrafanaConnection(context.Background(), connection)
+	require.NoError(t, err)
+	require.Equal(t, "http://other:3000", client.GrafanaAPIURL)
+	sameClient, err := frameworkClients.GrafanaConnection(context.Background(), connection)
+	require.NoError(t, err)
+	require.Same(t, client, sameClient)
+
+	otherClient, err := sdkClients.GrafanaConnection(context.Background(), common.GrafanaConnectionConfig{URL: "http://other:3000", Auth: "viewer:viewer"})
+	require.NoError(t, err)
+	require.NotSame(t, client, otherClient)
+
+	_, err = sdkClients.GrafanaConnection(context.Background(), common.GrafanaConnectionConfig{StackSlug: "mystack"})
+	require.EqualError(t, err, "the Cloud API client is required to connect to stack mystack. Set the cloud_access_policy_token provider attribute")
+}
@@ -8,12 +8,16 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 )
 
 type ProviderConfig struct {
@@ -29,7 +33,9 @@ type ProviderConfig struct {
 	CACert             types.String `tfsdk:"ca_cert"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 
-	StoreDashboardSha256 types.Bool `tfsdk:"store_dashboard_sha256"`
+	StoreDashboardSha256 types.Bool   `tfsdk:"store_dashboard_sha256"`
+	AnnotateChanges      types.Bool   `tfsdk:"annotate_changes"`
+	ChangeMessage        types.String `tfsdk:"change_message"`
 
 	CloudAccessPolicyToken types.String `tfsdk:"cloud_access_policy_token"`
 	CloudAPIURL            types.String `tfsdk:"cloud_api_url"`
@@ -61,6 +67,10 @@ func (c *ProviderConfig) SetDefaults() error {
 	if c.StoreDashboardSha256, err = envDefaultFuncBool(c.StoreDashboardSha256, "GRAFANA_STORE_DASHBOARD_SHA256", false); err != nil {
 		return fmt.Errorf("failed to parse GRAFANA_STORE_DASHBOARD_SHA256: %w", err)
 	}
+	if c.AnnotateChanges, err = envDefaultFuncBool(c.AnnotateChanges, "GRAFANA_ANNOTATE_CHANGES", false); err != nil {
+		return fmt.Errorf("failed to parse GRAFANA_ANNOTATE_CHANGES: %w", err)
+	}
+	c.ChangeMessage = envDefaultFuncString(c.ChangeMessage, "GRAFANA_CHANGE_MESSAGE", grafana.DefaultChangeMessage())
 	if c.Retries, err = envDefaultFuncInt64(c.Retries, "GRAFANA_RETRIES", 3); err != nil {
 		return fmt.Errorf("failed to parse GRAFANA_RETRIES: %w", err)
 	}
@@ -104,6 +114,11 @@ type frameworkProvider struct {
 	version string
 }
 
+var (
+	_ provider.ProviderWithListResources = (*frameworkProvider)(nil)
+	_ provider.ProviderWithActions       = (*frameworkProvider)(nil)
+)
+
 func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "grafana"
 	resp.Version = p.version
@@ -161,6 +176,14 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:            true,
 				MarkdownDescription: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate.",
 			},
+			"annotate_changes": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set to true to create a Grafana annotation each time Terraform changes a dashboard, rule group or notification policy. Annotations are tagged with `terraform` and the resource type, and dashboard annotations with the version delta. May alternatively be set via the `GRAFANA_ANNOTATE_CHANGES` environment variable.",
+			},
+			"change_message": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Text of the change annotations (see `annotate_changes`), also used as the version message of dashboards without a `message`. Ex: a commit SHA passed with a `TF_VAR_` variable. May alternatively be set via the `GRAFANA_CHANGE_MESSAGE` environment variable. Defaults to the commit SHA of common CI systems (GitHub Actions, GitLab CI, Azure Pipelines, CircleCI, Jenkins).",
+			},
 
 			"cloud_access_policy_token": schema.StringAttribute{
 				Optional:            true,
@@ -218,6 +241,8 @@ func (p *frameworkProvider) Configure(ctx context.Context, req provider.Configur
 
 	resp.ResourceData = clients
 	resp.DataSourceData = clients
+	resp.ListResourceData = clients
+	resp.ActionData = clients
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -230,6 +255,16 @@ func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resou
 	return pluginFrameworkResources()
 }
 
+// ListResources defines the list resources implemented in the provider, from the listers of the resources.
+func (p *frameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return pluginFrameworkListResources()
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *frameworkProvider) Actions(_ context.Context) []func() action.Action {
+	return pluginFrameworkActions()
+}
+
 // FrameworkProvider returns a terraform-plugin-framework Provider.
 // This is the recommended way forward for new resources.
 func FrameworkProvider(version string) provider.Provider {
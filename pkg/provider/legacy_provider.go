@@ -123,6 +123,16 @@ func Provider(version string) *schema.Provider {
 				Optional:    true,
 				Description: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate.",
 			},
+			"annotate_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to create a Grafana annotation each time Terraform changes a dashboard, rule group or notification policy. Annotations are tagged with `terraform` and the resource type, and dashboard annotations with the version delta. May alternatively be set via the `GRAFANA_ANNOTATE_CHANGES` environment variable.",
+			},
+			"change_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Text of the change annotations (see `annotate_changes`), also used as the version message of dashboards without a `message`. Ex: a commit SHA passed with a `TF_VAR_` variable. May alternatively be set via the `GRAFANA_CHANGE_MESSAGE` environment variable. Defaults to the commit SHA of common CI systems (GitHub Actions, GitLab CI, Azure Pipelines, CircleCI, Jenkins).",
+			},
 
 			"oncall_access_token": {
 				Type:        schema.TypeString,
@@ -205,6 +215,8 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			OncallAccessToken:      stringValueOrNull(d, "oncall_access_token"),
 			OncallURL:              stringValueOrNull(d, "oncall_url"),
 			StoreDashboardSha256:   boolValueOrNull(d, "store_dashboard_sha256"),
+			AnnotateChanges:        boolValueOrNull(d, "annotate_changes"),
+			ChangeMessage:          stringValueOrNull(d, "change_message"),
 			HTTPHeaders:            headers,
 			Retries:                int64ValueOrNull(d, "retries"),
 			RetryStatusCodes:       statusCodes,
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/search":
+			w.Write([]byte(`[{"uid":"abc","title":"A","type":"dash-db"},{"uid":"def","title":"B","type":"dash-db"}]`))
+		case "/api/dashboards/uid/abc":
+			w.Write([]byte(`{"dashboard":{"uid":"abc","id":1,"version":1,"title":"A","tags":["prod"]},"meta":{"folderUid":"f1","url":"/d/abc"}}`))
+		case "/api/dashboards/uid/def":
+			w.Write([]byte(`{"dashboard":{"uid":"def","id":2,"version":1,"title":"B"},"meta":{"folderUid":"f2","url":"/d/def"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	providerServer, err := MakeProviderServer(ctx, "dev")
+	require.NoError(t, err)
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: dynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"url":  tftypes.NewValue(tftypes.String, server.URL),
+			"auth": tftypes.NewValue(tftypes.String, "token"),
+		}),
+	})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+
+	listSchema := schemas.ListResourceSchemas["grafana_dashboard"]
+	require.NotNil(t, listSchema)
+	for name, tc := range map[string]struct {
+		config          map[string]tftypes.Value
+		includeResource bool
+		expected        []string
+	}{
+		"all": {
+			expected: []string{"0:abc", "0:def"},
+		},
+		"by folder": {
+			config:   map[string]tftypes.Value{"folder_uid": tftypes.NewValue(tftypes.String, "f2")},
+			expected: []string{"0:def"},
+		},
+		"by tags": {
+			config:          map[string]tftypes.Value{"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "prod")})},
+			includeResource: true,
+			expected:        []string{"0:abc"},
+		},
+		"by org": {
+			config: map[string]tftypes.Value{"org_id": tftypes.NewValue(tftypes.Number, 2)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stream, err := providerServer.(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{
+				TypeName:        "grafana_dashboard",
+				Config:          dynamicValue(t, listSchema, tc.config),
+				IncludeResource: tc.includeResource,
+			})
+			require.NoError(t, err)
+
+			var ids []string
+			for result := range stream.Results {
+				require.Empty(t, result.Diagnostics)
+				require.NotNil(t, result.Identity)
+				require.Equal(t, tc.includeResource, result.Resource != nil)
+				ids = append(ids, result.DisplayName)
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+// dynamicValue returns a config of the given schema, with the given attributes set and all others null.
+func dynamicValue(t *testing.T, schema *tfprotov5.Schema, attributes map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	value, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	require.NoError(t, err)
+	return &value
+}
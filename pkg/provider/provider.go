@@ -3,28 +3,20 @@ package provider
 import (
 	"context"
 
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
-	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
 )
 
 func MakeProviderServer(ctx context.Context, version string) (tfprotov5.ProviderServer, error) {
 	// While we still have the SDK2 provider, we have to use the provider v5 protocol
-	// See https://developer.hashicorp.com/terraform/plugin/mux/translating-protocol-version-6-to-5
-	downgradedFrameworkProvider, err := tf6to5server.DowngradeServer(
-		context.Background(),
-		providerserver.NewProtocol6(FrameworkProvider(version)),
-	)
-	if err != nil {
-		return nil, err
-	}
-
+	// The framework provider is served with it directly, rather than being downgraded from v6, since the downgrade drops some fields of list requests
 	providers := []func() tfprotov5.ProviderServer{
+		providerserver.NewProtocol5(FrameworkProvider(version)),
 		func() tfprotov5.ProviderServer {
-			return downgradedFrameworkProvider
+			return common.PlanWarningsServer{ProviderServer: Provider(version).GRPCProvider()}
 		},
-		Provider(version).GRPCProvider,
 	}
 	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
 	if err != nil {
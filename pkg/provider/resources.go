@@ -10,7 +10,9 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/oncall"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/slo"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/syntheticmonitoring"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -84,3 +86,45 @@ func pluginFrameworkResources() []func() resource.Resource {
 	}
 	return resources
 }
+
+func Actions() []*common.Action {
+	var actions []*common.Action
+	actions = append(actions, grafana.Actions...)
+	actions = append(actions, syntheticmonitoring.Actions...)
+	return actions
+}
+
+func pluginFrameworkActions() []func() action.Action {
+	var actions []func() action.Action
+	for _, a := range Actions() {
+		actionSchema := a.PluginFrameworkSchema
+		actions = append(actions, func() action.Action { return actionSchema })
+	}
+	return actions
+}
+
+func pluginFrameworkListResources() []func() list.ListResource {
+	var listResources []func() list.ListResource
+	for _, r := range Resources() {
+		if r.ListIDsFunc == nil || r.IDType == nil {
+			continue
+		}
+		listResource := common.NewListResource(r, listerData(r))
+		listResources = append(listResources, func() list.ListResource { return listResource })
+	}
+	return listResources
+}
+
+// listerData returns the data passed to the lister of the resource, the same way it is done by the config generator.
+func listerData(r *common.Resource) common.ListerDataFunc {
+	if r.Category == common.CategoryCloud {
+		return func(_ *common.Client, filters common.ListFilters) any {
+			return cloud.NewListerData(filters.OrgSlug)
+		}
+	}
+	return func(client *common.Client, _ common.ListFilters) any {
+		// Other organizations can only be listed with basic auth
+		singleOrg := client.GrafanaAPIConfig == nil || client.GrafanaAPIConfig.BasicAuth == nil
+		return grafana.NewListerData(singleOrg)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grafana/terraform-provider-grafana/v3/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// tfplugindocs doesn't support actions yet, so their docs are generated here, in the same format as resources
+func main() {
+	docsPath, examplesPath := os.Args[1], os.Args[2]
+	if err := generateActionDocs(docsPath, examplesPath); err != nil {
+		panic(err)
+	}
+}
+
+func generateActionDocs(docsPath, examplesPath string) error {
+	actionsPath := filepath.Join(docsPath, "actions")
+	if err := os.RemoveAll(actionsPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(actionsPath, 0700); err != nil {
+		return err
+	}
+
+	for _, a := range provider.Actions() {
+		if a.Category == "" {
+			return fmt.Errorf("action %s does not have a category", a.Name)
+		}
+		var resp action.SchemaResponse
+		a.PluginFrameworkSchema.Schema(context.Background(), action.SchemaRequest{}, &resp)
+		if resp.Diagnostics.HasError() {
+			return fmt.Errorf("failed to get the schema of action %s: %v", a.Name, resp.Diagnostics)
+		}
+		example, err := os.ReadFile(filepath.Join(examplesPath, "actions", a.Name, "action.tf"))
+		if err != nil {
+			return fmt.Errorf("action %s does not have an example: %w", a.Name, err)
+		}
+
+		description := strings.TrimSpace(resp.Schema.MarkdownDescription)
+		var doc strings.Builder
+		fmt.Fprintf(&doc, "---\n# generated by tools/genactiondocs\npage_title: %q\nsubcategory: %q\ndescription: |-\n", a.Name+" Action - terraform-provider-grafana", a.Category)
+		for _, line := range strings.Split(description, "\n") {
+			if line == "" {
+				doc.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(&doc, "  %s\n", line)
+		}
+		fmt.Fprintf(&doc, "---\n\n# %s (Action)\n\n%s\n\n", a.Name, description)
+		fmt.Fprintf(&doc, "## Example Usage\n\n```terraform\n%s\n```\n\n", strings.TrimSpace(string(example)))
+
+		doc.WriteString("## Schema\n")
+		var required, optional []string
+		for name, attribute := range resp.Schema.Attributes {
+			line := fmt.Sprintf("- `%s` (%s) %s", name, typeName(attribute.GetType()), attribute.GetDescription())
+			if attribute.IsRequired() {
+				required = append(required, line)
+			} else {
+				optional = append(optional, line)
+			}
+		}
+		for _, section := range []struct {
+			title string
+			lines []string
+		}{{"Required", required}, {"Optional", optional}} {
+			if len(section.lines) == 0 {
+				continue
+			}
+			sort.Strings(section.lines)
+			fmt.Fprintf(&doc, "\n### %s\n\n%s\n", section.title, strings.Join(section.lines, "\n"))
+		}
+
+		fileName := filepath.Join(actionsPath, strings.TrimPrefix(a.Name, "grafana_")+".md")
+		if err := os.WriteFile(fileName, []byte(doc.String()), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func typeName(t attr.Type) string {
+	switch t := t.(type) {
+	case basetypes.ListType:
+		return "List of " + typeName(t.ElemType)
+	case basetypes.MapType:
+		return "Map of " + typeName(t.ElemType)
+	case basetypes.BoolType:
+		return "Boolean"
+	case basetypes.Int64Type, basetypes.Float64Type, basetypes.NumberType:
+		return "Number"
+	default:
+		return "String"
+	}
+}